@@ -35,10 +35,6 @@ import (
 )
 
 var (
-	mu        sync.Mutex // guards accHeader and conHeader
-	accHeader string
-	conHeader string
-
 	errNilReponse = errors.New("nil response from API")
 	errBodyRead   = errors.New("error reading body")
 	errNoLink     = errors.New("Error: problem finding link")
@@ -50,8 +46,16 @@ var (
 // Client defines struct for Client
 type Client struct {
 	ctx           context.Context
-	configConnect *ConfigConnect
+	configConnect *ConfigConnect // guarded by tokens.mu
 	api           api.Client
+	tokens        *tokenState
+	tokenCache    TokenCache
+	mediaType     *versionedMediaType
+	batch         BatchOptions
+	cache         *topologyCache
+	endpoints     *api.Failover
+	telemetry     *api.Telemetry
+	logger        log.Logger
 }
 
 // Cluster defines struct for Cluster
@@ -64,38 +68,77 @@ type ConfigConnect struct {
 	Username string
 	Password string
 	Insecure bool
+
+	// Credentials, when set, supplies the username and password instead of
+	// Username and Password. It is asked on every login.
+	Credentials CredentialProvider
+
+	// TokenLifetime is how long a token issued by the array stays valid.
+	// When set, the token is refreshed before it expires instead of after a
+	// request fails with 401.
+	TokenLifetime time.Duration
+
+	// TokenRefreshWindow is how long before the end of TokenLifetime the
+	// token is refreshed. It defaults to a tenth of TokenLifetime.
+	TokenRefreshWindow time.Duration
+
+	// Endpoints lists other endpoints of the same system, such as the
+	// management IPs of its MDMs. Requests fail over to them, in order,
+	// when the endpoint the client was created with cannot be reached.
+	// Endpoint, when set, is added to them.
+	Endpoints []string
+
+	// DiscoverEndpoints adds the management IPs of the MDMs listed by the
+	// system to Endpoints after logging in, with the scheme and port of
+	// the endpoint in use.
+	DiscoverEndpoints bool
 }
 
 // GetVersion returns version
 func (c *Client) GetVersion() (string, error) {
-	ctx := c.Context()
-	defer c.ResetContext()
+	return c.GetVersionCtx(c.callContext())
+}
 
+// GetVersionCtx returns version
+func (c *Client) GetVersionCtx(ctx context.Context) (string, error) {
+	ctx, span := c.startSpan(ctx, "GetVersion")
+	defer span.end()
+
+	return c.getVersion(ctx, true)
+}
+
+// getVersion returns the version, logging in again after a 401 when reauth
+// is set. It is called with reauth unset during login.
+func (c *Client) getVersion(ctx context.Context, reauth bool) (string, error) {
+	token := c.api.GetToken()
 	resp, err := c.api.DoAndGetResponseBody(
-		ctx, http.MethodGet, "/api/version", nil, nil, c.configConnect.Version)
+		ctx, http.MethodGet, "/api/version", nil, nil, c.version())
 	if err != nil {
 		return "", err
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.DoLog(log.Log.Error, err.Error())
-		}
-	}()
-	// parse the response
-	switch {
-	case resp == nil:
+	if resp == nil {
 		return "", errNilReponse
-	case resp.StatusCode == http.StatusUnauthorized:
-		// Authenticate then try again
-		if _, err = c.Authenticate(c.configConnect); err != nil {
+	}
+	if resp.StatusCode == http.StatusUnauthorized && reauth {
+		// the rejected response holds a request slot until it is closed,
+		// so it is closed before logging in and trying again
+		c.closeBody(resp)
+		if err = c.login(ctx, token); err != nil {
 			return "", err
 		}
 		resp, err = c.api.DoAndGetResponseBody(
-			ctx, http.MethodGet, "/api/version", nil, nil, c.configConnect.Version)
+			ctx, http.MethodGet, "/api/version", nil, nil, c.version())
 		if err != nil {
 			return "", err
 		}
-	case !(resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices):
+		if resp == nil {
+			return "", errNilReponse
+		}
+	}
+	defer c.closeBody(resp)
+
+	// parse the response
+	if !(resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices) {
 		return "", c.api.ParseJSONError(resp)
 	}
 	version, err := extractString(resp)
@@ -109,79 +152,180 @@ func (c *Client) GetVersion() (string, error) {
 	return version, nil
 }
 
+// closeBody closes the body of res, logging the failures.
+func (c *Client) closeBody(res *http.Response) {
+	if err := res.Body.Close(); err != nil {
+		c.logger.Error(err.Error())
+	}
+}
+
 // updateVersion updates version
-func (c *Client) updateVersion() error {
-	version, err := c.GetVersion()
+func (c *Client) updateVersion(ctx context.Context) error {
+	version, err := c.getVersion(ctx, false)
 	if err != nil {
 		return err
 	}
+	unlock := c.lockConfig()
 	c.configConnect.Version = version
+	unlock()
 
-	updateHeaders(version)
+	c.mediaType.set(version)
 
 	return nil
 }
 
-func updateHeaders(version string) {
-	mu.Lock()
-	defer mu.Unlock()
-	accHeader = api.HeaderValContentTypeJSON
+// versionedMediaType holds the Accept and Content-Type header value for the
+// array version a Client talks to, e.g. "application/json;version=4.5".
+type versionedMediaType struct {
+	mu    sync.RWMutex // guards value
+	value string
+}
+
+func newVersionedMediaType(version string) *versionedMediaType {
+	m := &versionedMediaType{}
+	m.set(version)
+	return m
+}
+
+func (m *versionedMediaType) set(version string) {
+	if m == nil {
+		return
+	}
+	value := api.HeaderValContentTypeJSON
 	if version != "" {
-		accHeader = accHeader + ";version=" + version
+		value = value + ";version=" + version
 	}
-	conHeader = accHeader
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.value = value
+}
+
+func (m *versionedMediaType) get() string {
+	if m == nil {
+		return api.HeaderValContentTypeJSON
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.value
+}
+
+// requestHeaders returns the headers for a JSON request carrying body.
+func (c *Client) requestHeaders(body interface{}) map[string]string {
+	mediaType := c.mediaType.get()
+	headers := make(map[string]string, 2)
+	headers[api.HeaderKeyAccept] = mediaType
+	headers[api.HeaderKeyContentType] = mediaType
+	addMetaData(headers, body)
+	return headers
 }
 
 // Authenticate controls authentication to client
 func (c *Client) Authenticate(configConnect *ConfigConnect) (Cluster, error) {
+	return c.AuthenticateCtx(c.callContext(), configConnect)
+}
+
+// AuthenticateCtx controls authentication to client
+func (c *Client) AuthenticateCtx(ctx context.Context, configConnect *ConfigConnect) (Cluster, error) {
+	ctx, span := c.startSpan(ctx, "Authenticate")
+	defer span.end()
+
+	unlock := c.lockConfig()
 	configConnect.Version = c.configConnect.Version
 	c.configConnect = configConnect
+	unlock()
+	if c.tokens != nil {
+		c.tokens.setLifetime(configConnect.TokenLifetime, configConnect.TokenRefreshWindow)
+	}
+	if err := c.addEndpoints(ctx, append([]string{configConnect.Endpoint}, configConnect.Endpoints...)...); err != nil {
+		return Cluster{}, err
+	}
+
+	if err := c.login(ctx, ""); err != nil {
+		return Cluster{}, err
+	}
+
+	if configConnect.DiscoverEndpoints {
+		if err := c.discoverEndpoints(ctx); err != nil {
+			c.logger.Error(fmt.Sprintf("unable to discover the MDM endpoints: %s", err.Error()))
+		}
+	}
+
+	return Cluster{}, nil
+}
 
-	c.api.SetToken("")
+// doLogin exchanges the credentials of config for a new token. Callers
+// should use login, which merges concurrent attempts.
+func (c *Client) doLogin(ctx context.Context, config ConfigConnect) (err error) {
+	defer func() {
+		if err != nil {
+			c.api.SetToken("")
+		}
+	}()
+
+	// a /rest/auth session is renewed with its refresh token when it can be
+	if refresh := c.tokens.refreshToken(); refresh != "" && c.restAuth() {
+		refreshErr := c.refreshSession(ctx, refresh)
+		if refreshErr == nil {
+			return nil
+		}
+		c.logger.Debug(fmt.Sprintf("unable to refresh the session, logging in again: %s", refreshErr))
+	}
+
+	credentials, err := config.loginCredentials(ctx)
+	if err != nil {
+		c.logger.Error(err.Error())
+		return err
+	}
+
+	if c.restAuth() {
+		sessionErr := c.newSession(ctx, credentials)
+		if !errors.Is(sessionErr, errNoRESTAuth) {
+			return sessionErr
+		}
+		c.logger.Debug("/rest/auth is not available, logging in with api/login")
+		c.tokens.restAuthUnavailable()
+	}
 
 	headers := make(map[string]string, 1)
 	headers["Authorization"] = "Basic " + basicAuth(
-		configConnect.Username, configConnect.Password)
-
-	ctx := c.Context()
-	defer c.ResetContext()
+		credentials.Username, credentials.Password)
 
 	resp, err := c.api.DoAndGetResponseBody(
-		ctx, http.MethodGet, "api/login", headers, nil, c.configConnect.Version)
+		ctx, http.MethodGet, "api/login", headers, nil, c.version())
 	if err != nil {
-		log.DoLog(log.Log.Error, err.Error())
-		return Cluster{}, err
+		c.logger.Error(err.Error())
+		return err
 	}
 
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
-			log.DoLog(log.Log.Error, err.Error())
+			c.logger.Error(err.Error())
 		}
 	}()
 
 	// parse the response
 	switch {
 	case resp == nil:
-		return Cluster{}, errNilReponse
+		return errNilReponse
 	case !(resp.StatusCode >= 200 && resp.StatusCode <= 299):
-		return Cluster{}, c.api.ParseJSONError(resp)
+		return c.api.ParseJSONError(resp)
 	}
 
 	token, err := extractString(resp)
 	if err != nil {
-		return Cluster{}, nil
+		return nil
 	}
 
 	c.api.SetToken(token)
 
-	if c.configConnect.Version == "" {
-		err = c.updateVersion()
+	if c.version() == "" {
+		err = c.updateVersion(ctx)
 		if err != nil {
-			return Cluster{}, errors.New("error getting version of ScaleIO")
+			return errors.New("error getting version of ScaleIO")
 		}
 	}
 
-	return Cluster{}, nil
+	return nil
 }
 
 func basicAuth(username, password string) string {
@@ -189,50 +333,54 @@ func basicAuth(username, password string) string {
 	return base64.StdEncoding.EncodeToString([]byte(auth))
 }
 
-func (c *Client) xmlRequest(method, uri string, body, resp interface{}) (*http.Response, error) {
-	response, err := c.api.DoXMLRequest(context.Background(), method, uri, c.configConnect.Version, body, resp)
+func (c *Client) xmlRequest(ctx context.Context, method, uri string, body, resp interface{}) (*http.Response, error) {
+	response, err := c.api.DoXMLRequest(ctx, method, uri, c.version(), body, resp)
 	if err != nil {
-		log.DoLog(log.Log.Error, err.Error())
+		c.logger.Error(err.Error())
 	}
 	return response, err
 }
 
 func (c *Client) getJSONWithRetry(
+	ctx context.Context,
 	method, uri string,
 	body, resp interface{},
 ) error {
-	return getJSONWithRetryFunc(c, method, uri, body, resp)
+	err := c.requireFor(uri)
+	if err == nil {
+		err = c.cache.getJSON(method, uri, body, resp, func(resp interface{}) error {
+			return getJSONWithRetryFunc(ctx, c, method, uri, body, resp)
+		})
+	}
+	api.CallFromContext(ctx).SetError(err)
+	return err
 }
 
-var getJSONWithRetryFunc = func(c *Client, method, uri string, body, resp interface{}) error {
-	headers := make(map[string]string, 2)
-	headers[api.HeaderKeyAccept] = accHeader
-	headers[api.HeaderKeyContentType] = conHeader
-	addMetaData(headers, body)
+var getJSONWithRetryFunc = func(ctx context.Context, c *Client, method, uri string, body, resp interface{}) error {
+	headers := c.requestHeaders(body)
 
-	ctx := c.Context()
-	defer c.ResetContext()
+	c.refreshIfExpiring(ctx)
+	token := c.api.GetToken()
 
 	err := c.api.DoWithHeaders(
-		ctx, method, uri, headers, body, resp, c.configConnect.Version)
+		ctx, method, uri, headers, body, resp, c.version())
 	if err == nil {
 		return nil
 	}
 
 	// check if we need to authenticate
 	if e, ok := err.(*types.Error); ok {
-		log.DoLog(log.Log.Debug, err.Error())
+		c.logger.Debug(err.Error())
 		if e.HTTPStatusCode == 401 {
-			log.DoLog(log.Log.Info, "Need to re-auth")
 			// Authenticate then try again
-			if _, err := c.Authenticate(c.configConnect); err != nil {
-				return fmt.Errorf("Error Authenticating: %s", err)
+			if err := c.reauthenticate(ctx, token); err != nil {
+				return err
 			}
 			return c.api.DoWithHeaders(
-				ctx, method, uri, headers, body, resp, c.configConnect.Version)
+				ctx, method, uri, headers, body, resp, c.version())
 		}
 	}
-	log.DoLog(log.Log.Error, err.Error())
+	c.logger.Error(err.Error())
 
 	return err
 }
@@ -258,21 +406,26 @@ func extractString(resp *http.Response) (string, error) {
 }
 
 func (c *Client) getStringWithRetry(
+	ctx context.Context,
 	method, uri string,
 	body interface{},
-) (string, error) {
-	headers := make(map[string]string, 2)
-	headers[api.HeaderKeyAccept] = accHeader
-	headers[api.HeaderKeyContentType] = conHeader
-	addMetaData(headers, body)
+) (s string, err error) {
+	defer func() { api.CallFromContext(ctx).SetError(err) }()
+	defer c.cache.written(method, uri)
 
-	ctx := c.Context()
-	defer c.ResetContext()
+	if err := c.requireFor(uri); err != nil {
+		return "", err
+	}
+
+	headers := c.requestHeaders(body)
+
+	c.refreshIfExpiring(ctx)
+	token := c.api.GetToken()
 
 	checkResponse := func(resp *http.Response) (string, bool, error) {
 		defer func() {
 			if err := resp.Body.Close(); err != nil {
-				log.DoLog(log.Log.Error, err.Error())
+				c.logger.Error(err.Error())
 			}
 		}()
 
@@ -295,20 +448,19 @@ func (c *Client) getStringWithRetry(
 	}
 
 	resp, err := c.api.DoAndGetResponseBody(
-		ctx, method, uri, headers, body, c.configConnect.Version)
+		ctx, method, uri, headers, body, c.version())
 	if err != nil {
 		return "", err
 	}
 	s, retry, httpErr := checkResponse(resp)
 	if httpErr != nil {
 		if retry {
-			log.DoLog(log.Log.Info, "need to re-auth")
 			// Authenticate then try again
-			if _, err = c.Authenticate(c.configConnect); err != nil {
-				return "", fmt.Errorf("Error Authenticating: %s", err)
+			if err = c.reauthenticate(ctx, token); err != nil {
+				return "", err
 			}
 			resp, err = c.api.DoAndGetResponseBody(
-				ctx, method, uri, headers, body, c.configConnect.Version)
+				ctx, method, uri, headers, body, c.version())
 			if err != nil {
 				return "", err
 			}
@@ -324,6 +476,9 @@ func (c *Client) getStringWithRetry(
 // SetToken sets token
 func (c *Client) SetToken(token string) {
 	c.api.SetToken(token)
+	if c.tokens != nil {
+		c.tokens.tokenIssued(time.Now())
+	}
 }
 
 // GetToken returns token
@@ -333,14 +488,42 @@ func (c *Client) GetToken() string {
 
 // GetConfigConnect returns Config of client
 func (c *Client) GetConfigConnect() *ConfigConnect {
+	defer c.lockConfig()()
 	return c.configConnect
 }
 
+// lockConfig locks configConnect, which Authenticate replaces while logins
+// read it, and returns the function unlocking it.
+func (c *Client) lockConfig() (unlock func()) {
+	if c.tokens == nil {
+		return func() {}
+	}
+	c.tokens.mu.Lock()
+	return c.tokens.mu.Unlock
+}
+
+// config returns a copy of the settings of the client, which another
+// Authenticate may replace meanwhile.
+func (c *Client) config() ConfigConnect {
+	defer c.lockConfig()()
+	return *c.configConnect
+}
+
+// version returns the version of the array, empty while it is unknown.
+func (c *Client) version() string {
+	defer c.lockConfig()()
+	return c.configConnect.Version
+}
+
+// WithContext stores ctx on the client for use by the next call made through it.
+// The stored context is shared by every goroutine using the client, so
+// concurrent callers should use the Ctx variants of each method instead.
 func (c *Client) WithContext(ctx context.Context) *Client {
 	c.ctx = ctx
 	return c
 }
 
+// Context returns the context stored by WithContext, or context.Background.
 func (c *Client) Context() context.Context {
 	if c.ctx != nil {
 		return c.ctx
@@ -349,10 +532,19 @@ func (c *Client) Context() context.Context {
 	return context.Background()
 }
 
+// ResetContext clears the context stored by WithContext.
 func (c *Client) ResetContext() {
 	c.ctx = nil
 }
 
+// callContext returns the context stored by WithContext and clears it, so
+// that it applies to a single call of a method without a Ctx suffix.
+func (c *Client) callContext() context.Context {
+	ctx := c.Context()
+	c.ResetContext()
+	return ctx
+}
+
 // NewClient returns a new client
 func NewClient() (client *Client, err error) {
 	return NewClientWithArgs(
@@ -374,56 +566,76 @@ func NewClientWithArgs(
 	insecure,
 	useCerts bool,
 ) (client *Client, err error) {
-	if showHTTP {
-		debug = true
+	opts := api.ClientOptions{
+		Insecure: insecure,
+		UseCerts: useCerts,
+		Timeout:  time.Duration(timeout) * time.Second,
 	}
+
+	return NewClientWithOptions(endpoint, version, opts)
+}
+
+// NewClientWithOptions returns a new client that uses opts for its HTTP
+// connection, for example to set a retry policy.
+func NewClientWithOptions(
+	endpoint string,
+	version string,
+	opts api.ClientOptions,
+) (client *Client, err error) {
+	// GOSCALEIO_SHOWHTTP implies debug logging; the package defaults are
+	// left untouched so that clients created concurrently do not race.
+	debug := debug || showHTTP
+	if debug && opts.Logger == nil {
+		// log.Log at the debug level, for this client only
+		opts.Logger = log.WithLevel(log.Log, slog.LevelDebug)
+	}
+	logger := log.NewLogger(opts.Logger)
 	if debug {
-		log.SetLogLevel(slog.LevelDebug)
-		log.DoLog(log.Log.Info, "Setting log level to debug in GoScaleIO")
+		logger.Info("Setting log level to debug in GoScaleIO")
 	}
 
 	fields := map[string]interface{}{
 		"endpoint": endpoint,
-		"insecure": insecure,
-		"useCerts": useCerts,
+		"insecure": opts.Insecure,
+		"useCerts": opts.UseCerts,
 		"version":  version,
 		"debug":    debug,
 		"showHTTP": showHTTP,
 	}
-	log.DoLog(log.Log.Debug, fmt.Sprintf("goscaleio client init, Fields: %+v", fields))
+	logger.Debug(fmt.Sprintf("goscaleio client init, Fields: %+v", fields))
 
 	if endpoint == "" {
-		log.DoLog(log.Log.Error, fmt.Sprintf("endpoint is required, Fields: %+v", fields))
+		logger.Error(fmt.Sprintf("endpoint is required, Fields: %+v", fields))
 		return nil,
 			withFields(fields, "endpoint is required")
 	}
 
-	opts := api.ClientOptions{
-		Insecure: insecure,
-		UseCerts: useCerts,
-		ShowHTTP: showHTTP,
-		Timeout:  time.Duration(timeout) * time.Second,
+	if showHTTP {
+		opts.ShowHTTP = true
 	}
 
 	if ClientConnectTimeout != 0 {
 		opts.Timeout = ClientConnectTimeout
 	}
 
-	ac, err := api.New(context.Background(), endpoint, opts, debug)
+	ac, err := api.NewFailover(context.Background(), []string{endpoint}, opts, debug)
 	if err != nil {
-		log.DoLog(log.Log.Error, fmt.Sprintf("Unable to create HTTP client: %s", err.Error()))
+		logger.Error(fmt.Sprintf("Unable to create HTTP client: %s", err.Error()))
 		return nil, err
 	}
 
 	client = &Client{
-		api: ac,
+		api:       ac,
+		endpoints: ac,
 		configConnect: &ConfigConnect{
 			Version: version,
 		},
+		tokens:    &tokenState{},
+		mediaType: newVersionedMediaType(version),
+		telemetry: api.NewTelemetry(opts),
+		logger:    logger,
 	}
 
-	updateHeaders(version)
-
 	return client, nil
 }
 
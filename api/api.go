@@ -16,20 +16,25 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dell/goscaleio/log"
 	types "github.com/dell/goscaleio/types/v1"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -117,11 +122,16 @@ type Client interface {
 }
 
 type client struct {
-	http     *http.Client
-	host     string
-	token    string
-	showHTTP bool
-	debug    bool
+	http        *http.Client
+	host        string
+	tokenMu     sync.RWMutex // guards token
+	token       string
+	showHTTP    bool
+	debug       bool
+	retryPolicy *RetryPolicy
+	telemetry   *Telemetry
+	logger      log.Logger
+	limiter     *Limiter
 }
 
 // GetSecuredCipherSuites returns a slice of secured cipher suites.
@@ -149,6 +159,54 @@ type ClientOptions struct {
 	// ShowHTTP is a flag that indicates whether or not HTTP requests and
 	// responses should be logged to stdout
 	ShowHTTP bool
+
+	// RetryPolicy controls retries of requests that fail with a transient
+	// error. Requests are not retried when it is nil.
+	RetryPolicy *RetryPolicy
+
+	// TLSConfig is the base TLS configuration, e.g. to set a client
+	// certificate for mTLS or a minimum TLS version. It is cloned, and
+	// Insecure, UseCerts and CACertFile are applied on top of the copy.
+	TLSConfig *tls.Config
+
+	// CACertFile is the path to a PEM file with CA certificates to trust in
+	// addition to the system pool, or to TLSConfig.RootCAs when set.
+	CACertFile string
+
+	// PinnedCertSHA256 lists the hex SHA-256 fingerprints of the server
+	// certificates to accept. When set, connections to servers presenting
+	// any other leaf certificate are refused.
+	PinnedCertSHA256 []string
+
+	// Proxy returns the proxy to use for a request, as http.Transport.Proxy.
+	// Requests are not proxied when it is nil.
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// Transport, when set, is used to send requests instead of a transport
+	// built from the TLS options above, which are then ignored.
+	Transport http.RoundTripper
+
+	// TracerProvider creates the spans of the calls and of the requests
+	// they send. The global provider of otel is used when it is nil.
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider creates the duration histograms of the calls and of
+	// the requests. The global provider of otel is used when it is nil.
+	MeterProvider metric.MeterProvider
+
+	// Propagator injects the trace context into the request headers. The
+	// global propagator of otel is used when it is nil.
+	Propagator propagation.TextMapPropagator
+
+	// Logger receives the messages of the client, including the HTTP dumps
+	// of ShowHTTP, at the levels its handler enables. When it is nil they go
+	// to log.Log, and only when the debug level is set.
+	Logger *slog.Logger
+
+	// Limits caps the rate and the concurrency of the requests. The limits
+	// are shared by all the clients of the same endpoint, see LimiterFor.
+	// Requests are not limited when it is nil.
+	Limits *Limits
 }
 
 // New returns a new API client.
@@ -173,37 +231,21 @@ func New(
 		c.http.Timeout = opts.Timeout
 	}
 
-	if opts.Insecure {
-		c.http.Transport = &http.Transport{
-			// #nosec G402
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true, // #nosec G402
-				CipherSuites:       GetSecuredCipherSuites(),
-			},
-		}
-	}
-
-	if !opts.Insecure || opts.UseCerts {
-		pool, err := x509.SystemCertPool()
-		if err != nil {
-			return nil, errSysCerts
-		}
-
-		c.http.Transport = &http.Transport{
-			// #nosec G402
-			TLSClientConfig: &tls.Config{
-				RootCAs:            pool,
-				InsecureSkipVerify: opts.Insecure,
-				CipherSuites:       GetSecuredCipherSuites(),
-			},
-		}
+	transport, err := NewTransport(opts)
+	if err != nil {
+		return nil, err
 	}
+	c.http.Transport = transport
 
 	if opts.ShowHTTP {
 		c.showHTTP = true
 	}
 
 	c.debug = debug
+	c.retryPolicy = opts.RetryPolicy
+	c.telemetry = NewTelemetry(opts)
+	c.logger = log.NewLogger(opts.Logger)
+	c.limiter = LimiterFor(host, opts.Limits)
 
 	return c, nil
 }
@@ -278,7 +320,7 @@ func (c *client) DoWithHeaders(
 
 	defer func() {
 		if err := res.Body.Close(); err != nil {
-			log.DoLog(log.Log.Error, err.Error())
+			c.logger.Error(err.Error())
 		}
 	}()
 
@@ -292,7 +334,7 @@ func (c *client) DoWithHeaders(
 		}
 		dec := json.NewDecoder(res.Body)
 		if err = dec.Decode(resp); err != nil && err != io.EOF {
-			log.DoLog(log.Log.Error, fmt.Sprintf("Error: %s Unable to decode response into %+v", err.Error(), resp))
+			c.logger.Error(fmt.Sprintf("Error: %s Unable to decode response into %+v", err.Error(), resp))
 			return err
 		}
 	default:
@@ -310,8 +352,6 @@ func (c *client) DoAndGetResponseBody(
 ) (*http.Response, error) {
 	var (
 		err                error
-		req                *http.Request
-		res                *http.Response
 		ubf                = &bytes.Buffer{}
 		luri               = len(uri)
 		hostEndsWithSlash  = endsWithSlash(c.host)
@@ -337,48 +377,169 @@ func (c *client) DoAndGetResponseBody(
 		return nil, err
 	}
 
-	var isContentTypeSet bool
+	var (
+		contentType string
+		payload     []byte
+		stream      io.ReadCloser
+	)
 
 	// marshal the message body (assumes json format)
 	if r, ok := body.(io.ReadCloser); ok {
-		req, err = http.NewRequest(method, u.String(), r)
+		stream = r
 
 		defer func() {
 			if err := r.Close(); err != nil {
-				log.DoLog(log.Log.Error, err.Error())
+				c.logger.Error(err.Error())
 			}
 		}()
 
+		contentType = headerValContentTypeBinaryOctetStream
 		if v, ok := headers[HeaderKeyContentType]; ok {
-			req.Header.Set(HeaderKeyContentType, v)
-		} else {
-			req.Header.Set(
-				HeaderKeyContentType, headerValContentTypeBinaryOctetStream)
+			contentType = v
 		}
-		isContentTypeSet = true
 	} else if body != nil {
 		buf := &bytes.Buffer{}
 		enc := json.NewEncoder(buf)
 		if err = enc.Encode(body); err != nil {
 			return nil, err
 		}
-		req, err = http.NewRequest(method, u.String(), buf)
+		payload = buf.Bytes()
+
+		contentType = HeaderValContentTypeJSON
 		if v, ok := headers[HeaderKeyContentType]; ok {
-			req.Header.Set(HeaderKeyContentType, v)
+			contentType = v
+		}
+	}
+
+	return c.sender().Send(ctx, func() (*http.Request, error) {
+		return c.newRequest(ctx, method, u.String(), headers, contentType, payload, stream, version)
+	}, stream == nil)
+}
+
+// sender returns the Sender of the client's requests.
+func (c *client) sender() *Sender {
+	return &Sender{HTTP: c.http, RetryPolicy: c.retryPolicy, ShowHTTP: c.showHTTP, Telemetry: c.telemetry, Logger: c.logger, Limiter: c.limiter}
+}
+
+// Sender sends HTTP requests the way the API client does, within the limits
+// of Limiter, retrying them according to RetryPolicy, logging them to Logger,
+// redacted, when ShowHTTP is set and tracing them with Telemetry. It lets the
+// gateway client share that behaviour.
+type Sender struct {
+	HTTP        *http.Client
+	RetryPolicy *RetryPolicy
+	ShowHTTP    bool
+	Telemetry   *Telemetry
+	Logger      log.Logger
+	Limiter     *Limiter
+}
+
+// Send sends the request returned by newRequest, calling it again for every
+// attempt. replayable must be false when the request body can only be read
+// once, in which case the request is never retried.
+func (s *Sender) Send(
+	ctx context.Context,
+	newRequest func() (*http.Request, error),
+	replayable bool,
+) (*http.Response, error) {
+	var (
+		err error
+		req *http.Request
+		res *http.Response
+	)
+
+	for attempt := 1; ; attempt++ {
+		if req, err = newRequest(); err != nil {
+			return nil, err
+		}
+
+		var release func()
+		if release, err = s.Limiter.Acquire(ctx, req.Method, req.URL.Path); err != nil {
+			return nil, err
+		}
+
+		var endSpan func(*http.Response, error)
+		req, endSpan = s.Telemetry.startRequest(req, attempt)
+
+		if s.ShowHTTP {
+			logRequest(ctx, req, s.Logger)
+		}
+
+		// send the request
+		res, err = s.HTTP.Do(req)
+		endSpan(res, err)
+		if err != nil {
+			release()
 		} else {
-			req.Header.Set(HeaderKeyContentType, HeaderValContentTypeJSON)
+			res.Body = &releaseBody{ReadCloser: res.Body, release: release}
+		}
+
+		if !s.RetryPolicy.shouldRetry(ctx, attempt, req, replayable, res, err) {
+			break
+		}
+
+		delay := s.RetryPolicy.backoff(attempt, res)
+		if err != nil {
+			s.Logger.Info(fmt.Sprintf("%s %s failed on attempt %d: %s, retrying in %s",
+				req.Method, req.URL.Path, attempt, err.Error(), delay))
+		} else {
+			s.Logger.Info(fmt.Sprintf("%s %s returned %s on attempt %d, retrying in %s",
+				req.Method, req.URL.Path, res.Status, attempt, delay))
+			// drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, res.Body)
+			if err := res.Body.Close(); err != nil {
+				s.Logger.Error(err.Error())
+			}
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
 		}
-		isContentTypeSet = true
-	} else {
-		req, err = http.NewRequest(method, u.String(), nil)
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	if !isContentTypeSet {
-		isContentTypeSet = req.Header.Get(HeaderKeyContentType) != ""
+	if s.ShowHTTP {
+		logResponse(ctx, res, s.Logger)
+	}
+
+	return res, nil
+}
+
+// newRequest builds a request for a single attempt of DoAndGetResponseBody.
+// A request with a stream body can only be built once; the payload is
+// reread on every call.
+func (c *client) newRequest(
+	ctx context.Context,
+	method, uri string,
+	headers map[string]string,
+	contentType string,
+	payload []byte,
+	stream io.ReadCloser,
+	version string,
+) (*http.Request, error) {
+	var (
+		err error
+		req *http.Request
+	)
+
+	switch {
+	case stream != nil:
+		req, err = http.NewRequestWithContext(ctx, method, uri, stream)
+	case payload != nil:
+		req, err = http.NewRequestWithContext(ctx, method, uri, bytes.NewReader(payload))
+	default:
+		req, err = http.NewRequestWithContext(ctx, method, uri, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	isContentTypeSet := contentType != ""
+	if isContentTypeSet {
+		req.Header.Set(HeaderKeyContentType, contentType)
 	}
 
 	// add headers to the request
@@ -389,52 +550,70 @@ func (c *client) DoAndGetResponseBody(
 		req.Header.Add(header, value)
 	}
 
-	if version != "" {
-		ver, err := strconv.ParseFloat(version, 64)
-		if err != nil {
-			return nil, err
-		}
+	// an explicit Authorization header, as sent by login, takes precedence
+	// over the session token
+	_, hasAuthorization := headers["Authorization"]
+	if err := c.setAuthorization(req, version, !hasAuthorization); err != nil {
+		return nil, err
+	}
 
-		// set the auth token
-		if c.token != "" {
-			// use Bearer Authentication if the powerflex array
-			// version >= 4.0
-			if ver >= 4.0 {
-				bearer := "Bearer " + c.token
-				req.Header.Set("Authorization", bearer)
-			} else {
-				req.SetBasicAuth("", c.token)
-			}
-		}
+	return req, nil
+}
 
-	} else {
-		if c.token != "" {
-			req.SetBasicAuth("", c.token)
+// setAuthorization adds the session token to req, as a Bearer token for
+// PowerFlex 4.0 and later and as basic auth for older versions. The token is
+// left out when withToken is false, but version is still validated.
+func (c *client) setAuthorization(req *http.Request, version string, withToken bool) error {
+	var major int
+	if version != "" {
+		var err error
+		if major, _, err = ParseVersion(version); err != nil {
+			return err
 		}
 	}
 
-	if c.showHTTP {
-		logRequest(ctx, req, log.DoLog)
+	token := c.GetToken()
+	if !withToken || token == "" {
+		return nil
 	}
 
-	// send the request
-	req = req.WithContext(ctx)
-	if res, err = c.http.Do(req); err != nil {
-		return nil, err
+	// use Bearer Authentication if the powerflex array
+	// version >= 4.0
+	if major >= 4 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else {
+		req.SetBasicAuth("", token)
 	}
+	return nil
+}
 
-	if c.showHTTP {
-		logResponse(ctx, res, log.DoLog)
-	}
+var versionRX = regexp.MustCompile(`^(\d+)\.(\d+)`)
 
-	return res, err
+// ParseVersion returns the major and minor numbers of a PowerFlex version,
+// such as "4.5" or "3.6.700.103".
+func ParseVersion(version string) (major, minor int, err error) {
+	m := versionRX.FindStringSubmatch(strings.TrimSpace(version))
+	if m == nil {
+		return 0, 0, fmt.Errorf("invalid PowerFlex version %q", version)
+	}
+	if major, err = strconv.Atoi(m[1]); err != nil {
+		return 0, 0, fmt.Errorf("invalid PowerFlex version %q", version)
+	}
+	if minor, err = strconv.Atoi(m[2]); err != nil {
+		return 0, 0, fmt.Errorf("invalid PowerFlex version %q", version)
+	}
+	return major, minor, nil
 }
 
 func (c *client) SetToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
 	c.token = token
 }
 
 func (c *client) GetToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
 	return c.token
 }
 
@@ -445,7 +624,6 @@ func (c *client) DoXMLRequest(
 ) (*http.Response, error) {
 	var (
 		err                error
-		req                *http.Request
 		res                *http.Response
 		ubf                = &bytes.Buffer{}
 		luri               = len(path)
@@ -470,58 +648,22 @@ func (c *client) DoXMLRequest(
 	if err != nil {
 		return nil, err
 	}
-	if body != nil {
-		xmlBody, err := xml.Marshal(body)
-		if err != nil {
-			log.DoLog(log.Log.Error, fmt.Sprintf("Error marshaling XML: %v", err))
-			return nil, err
-		}
-
-		// Create the HTTP request
-		req, err = http.NewRequest(method, u.String(), bytes.NewBuffer(xmlBody))
-		if err != nil {
-			log.DoLog(log.Log.Error, fmt.Sprintf("Error creating request: %v", err))
-			return nil, err
-		}
-	} else {
-		req, err = http.NewRequest(method, u.String(), nil)
-		if err != nil {
-			log.DoLog(log.Log.Error, fmt.Sprintf("Error creating request: %v", err))
-			return nil, err
-		}
-	}
 
-	req.Header.Set("Content-Type", "application/xml")
-	// add headers to the request
-	if version != "" {
-		ver, err := strconv.ParseFloat(version, 64)
-		if err != nil {
+	var payload []byte
+	if body != nil {
+		if payload, err = xml.Marshal(body); err != nil {
+			c.logger.Error(fmt.Sprintf("Error marshaling XML: %v", err))
 			return nil, err
 		}
-
-		// set the auth token
-		if c.token != "" {
-			// use Bearer Authentication if the powerflex array
-			// version >= 4.0
-			if ver >= 4.0 {
-				bearer := "Bearer " + c.token
-				req.Header.Set("Authorization", bearer)
-			} else {
-				req.SetBasicAuth("", c.token)
-			}
-		}
-
-	} else {
-		if c.token != "" {
-			req.SetBasicAuth("", c.token)
-		}
 	}
 
-	// send the request
-	req = req.WithContext(ctx)
-	if res, err = c.http.Do(req); err != nil {
+	res, err = c.sender().Send(ctx, func() (*http.Request, error) {
+		return c.newRequest(ctx, method, u.String(), nil, "application/xml", payload, nil, version)
+	}, true)
+	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
 
 	// parse the response
 	switch {
@@ -533,7 +675,7 @@ func (c *client) DoXMLRequest(
 		}
 		dec := json.NewDecoder(res.Body)
 		if err = dec.Decode(resp); err != nil && err != io.EOF {
-			log.DoLog(log.Log.Error, fmt.Sprintf("Error: %s Unable to decode response into %+v", err.Error(), resp))
+			c.logger.Error(fmt.Sprintf("Error: %s Unable to decode response into %+v", err.Error(), resp))
 			return nil, err
 		}
 	default:
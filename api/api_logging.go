@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httputil"
 	"strings"
@@ -32,8 +33,12 @@ func isBinOctetBody(h http.Header) bool {
 func logRequest(
 	_ context.Context,
 	req *http.Request,
-	lf func(func(msg string, args ...any), string),
+	logger log.Logger,
 ) {
+	if !logger.Enabled(slog.LevelDebug) {
+		return
+	}
+
 	w := &bytes.Buffer{}
 
 	fmt.Fprintln(w)
@@ -49,16 +54,18 @@ func logRequest(
 	if err := WriteIndented(w, buf); err != nil {
 		fmt.Printf("WriteIndented returned error: %s", err.Error())
 	}
-	if lf != nil {
-		lf(log.Log.Debug, w.String())
-	}
+	logger.Debug(w.String())
 }
 
 func logResponse(
 	_ context.Context,
 	res *http.Response,
-	lf func(func(msg string, args ...any), string),
+	logger log.Logger,
 ) {
+	if !logger.Enabled(slog.LevelDebug) {
+		return
+	}
+
 	w := &bytes.Buffer{}
 
 	fmt.Fprintln(w)
@@ -66,27 +73,48 @@ func logResponse(
 	fmt.Fprint(w, "GOSCALEIO HTTP RESPONSE")
 	fmt.Fprintln(w, " -------------------------")
 
-	buf, err := httputil.DumpResponse(res, !isBinOctetBody(res.Header))
+	buf, err := dumpResponse(res, !isBinOctetBody(res.Header))
 	if err != nil {
 		return
 	}
 
-	bw := &bytes.Buffer{}
-
 	if err := WriteIndented(w, buf); err != nil {
 		fmt.Printf("WriteIndented returned error: %s", err.Error())
 	}
+	logger.Debug(w.String())
+}
 
-	scanner := bufio.NewScanner(bw)
-	for {
-		if !scanner.Scan() {
-			break
-		}
-		fmt.Fprintln(w, scanner.Text())
+// dumpResponse is httputil.DumpResponse with the credentials, tokens and
+// cookies of the headers and the body redacted. res.Body is left unread.
+func dumpResponse(res *http.Response, body bool) ([]byte, error) {
+	dump := *res
+	dump.Header = redactHeader(res.Header)
+	if !body || res.Body == nil {
+		return httputil.DumpResponse(&dump, false)
+	}
+
+	var (
+		err  error
+		save io.ReadCloser
+	)
+	save, res.Body, err = drainBody(res.Body)
+	if err != nil {
+		return nil, err
 	}
-	if lf != nil {
-		lf(log.Log.Debug, w.String())
+	b, err := io.ReadAll(save)
+	if err != nil {
+		return nil, err
 	}
+
+	path := ""
+	if res.Request != nil {
+		path = res.Request.URL.Path
+	}
+	b = redactBody(path, b)
+	dump.Body = io.NopCloser(bytes.NewReader(b))
+	dump.ContentLength = int64(len(b))
+	dump.TransferEncoding = nil
+	return httputil.DumpResponse(&dump, true)
 }
 
 // WriteIndentedN indents all lines n spaces.
@@ -125,7 +153,6 @@ var reqWriteExcludeHeaderDump = map[string]bool{
 	"Host":              true, // not in Header map anyway
 	"Transfer-Encoding": true,
 	"Trailer":           true,
-	"Authorization":     true,
 }
 
 func drainBody(b io.ReadCloser) (r1, r2 io.ReadCloser, err error) {
@@ -143,6 +170,8 @@ func drainBody(b io.ReadCloser) (r1, r2 io.ReadCloser, err error) {
 	return io.NopCloser(&buf), io.NopCloser(bytes.NewReader(buf.Bytes())), nil
 }
 
+// dumpRequest is httputil.DumpRequest with the credentials, tokens and
+// cookies of the headers and the body redacted.
 func dumpRequest(req *http.Request, body bool) ([]byte, error) {
 	var err error
 	save := req.Body
@@ -194,7 +223,7 @@ func dumpRequest(req *http.Request, body bool) ([]byte, error) {
 		fmt.Fprintf(&b, "Connection: close\r\n")
 	}
 
-	err = req.Header.WriteSubset(&b, reqWriteExcludeHeaderDump)
+	err = redactHeader(req.Header).WriteSubset(&b, reqWriteExcludeHeaderDump)
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +237,10 @@ func dumpRequest(req *http.Request, body bool) ([]byte, error) {
 		if chunked {
 			dest = httputil.NewChunkedWriter(dest)
 		}
-		_, err = io.Copy(dest, req.Body)
+		var payload []byte
+		if payload, err = io.ReadAll(req.Body); err == nil {
+			_, err = dest.Write(redactBody(req.URL.Path, payload))
+		}
 		if chunked {
 			if err := dest.(io.Closer).Close(); err != nil {
 				fmt.Printf("io.Closer.Close() returned error: %s", err.Error())
@@ -19,9 +19,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/dell/goscaleio/log"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestIsBinOctetBody(t *testing.T) {
@@ -188,14 +192,22 @@ func TestWriteIndentedN(t *testing.T) {
 	}
 }
 
-func TestLogResponse(_ *testing.T) {
+// debugLogger returns a Logger that writes every message to w.
+func debugLogger(w io.Writer) log.Logger {
+	return log.NewLogger(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})))
+}
+
+func TestLogResponse(t *testing.T) {
+	w := &bytes.Buffer{}
+	logger := debugLogger(w)
+
 	// Test case: Logging a successful response
 	res := &http.Response{
 		StatusCode: http.StatusOK,
 		Header:     http.Header{"Content-Type": []string{"application/json"}},
 		Body:       io.NopCloser(strings.NewReader(`{"message": "success"}`)),
 	}
-	logResponse(context.Background(), res, nil)
+	logResponse(context.Background(), res, logger)
 
 	// Test case: Logging a failed response
 	res = &http.Response{
@@ -203,7 +215,7 @@ func TestLogResponse(_ *testing.T) {
 		Header:     http.Header{"Content-Type": []string{"text/plain"}},
 		Body:       io.NopCloser(strings.NewReader("Internal server error")),
 	}
-	logResponse(context.Background(), res, nil)
+	logResponse(context.Background(), res, logger)
 
 	// Test case: Logging a response with binary content
 	res = &http.Response{
@@ -211,7 +223,7 @@ func TestLogResponse(_ *testing.T) {
 		Header:     http.Header{"Content-Type": []string{"application/octet-stream"}},
 		Body:       io.NopCloser(bytes.NewReader([]byte{0x01, 0x02, 0x03})),
 	}
-	logResponse(context.Background(), res, nil)
+	logResponse(context.Background(), res, logger)
 
 	// Test case: Failed response with binary content and error body
 	res = &http.Response{
@@ -219,19 +231,16 @@ func TestLogResponse(_ *testing.T) {
 		Header:     http.Header{"Content-Type": []string{"text/plain"}},
 		Body:       io.NopCloser(NewErrorReader(fmt.Errorf("simulated error while reading body"))),
 	}
-	logResponse(context.Background(), res, nil)
-}
+	logResponse(context.Background(), res, logger)
+	assert.Contains(t, w.String(), "GOSCALEIO HTTP RESPONSE")
+	assert.Contains(t, w.String(), "success")
 
-func logChecker(msg string) func(func(msg string, args ...any), string) {
-	return func(lf func(msg string, args ...any), message string) {
-		if !strings.Contains(msg, "GOSCALEIO HTTP REQUEST") {
-			// If the message does not contain the expected string, call the logging function with an error message
-			lf(fmt.Sprintf("Expected request log, got %s", msg))
-		}
-
-		// You can log the original message if needed
-		lf(message)
+	// the zero Logger drops the dump unless debug logging is on
+	res = &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"message": "success"}`)),
 	}
+	logResponse(context.Background(), res, log.Logger{})
 }
 
 func TestLogRequest(t *testing.T) {
@@ -240,8 +249,10 @@ func TestLogRequest(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	logFunc := logChecker("GOSCALEIO HTTP REQUEST")
-	logRequest(context.Background(), req, logFunc)
+	w := &bytes.Buffer{}
+	logger := debugLogger(w)
+	logRequest(context.Background(), req, logger)
+	assert.Contains(t, w.String(), "GOSCALEIO HTTP REQUEST")
 
 	// Test case: Error in dumpRequest
 	req, err = http.NewRequest("GET", "https://example.com/instances", NewErrorReader(errors.New("simulated error while reading request body")))
@@ -253,7 +264,7 @@ func TestLogRequest(t *testing.T) {
 		t.Fatalf("Expected error when reading request body, got nil")
 	}
 
-	logRequest(context.Background(), req, logFunc)
+	logRequest(context.Background(), req, logger)
 
 	// Test case: Error in WriteIndented
 	req, err = http.NewRequest("GET", "https://example.com/instances", nil)
@@ -263,7 +274,7 @@ func TestLogRequest(t *testing.T) {
 
 	req.Header.Set(HeaderKeyContentType, "application/json")
 
-	logRequest(context.Background(), req, logFunc)
+	logRequest(context.Background(), req, logger)
 }
 
 // errorReader is a custom io.Reader that always returns an error when Read is called.
@@ -643,7 +643,7 @@ func TestDoXMLRequest(t *testing.T) {
 			body:        nil,
 			resp:        "",
 			version:     "invalid_version",
-			expectedErr: fmt.Errorf("strconv.ParseFloat: parsing \"invalid_version\": invalid syntax"),
+			expectedErr: fmt.Errorf("invalid PowerFlex version \"invalid_version\""),
 		},
 		"Token with version 4": {
 			method:       http.MethodGet,
@@ -787,7 +787,7 @@ func TestDoWithHeaders(t *testing.T) {
 			method:      http.MethodGet,
 			path:        "api/test",
 			version:     "invalid_version",
-			expectedErr: fmt.Errorf("strconv.ParseFloat: parsing \"invalid_version\": invalid syntax"),
+			expectedErr: fmt.Errorf("invalid PowerFlex version \"invalid_version\""),
 		},
 		"read close error": {
 			method: http.MethodGet,
@@ -880,6 +880,55 @@ func TestDoWithHeaders(t *testing.T) {
 	}
 }
 
+func TestSetAuthorization(t *testing.T) {
+	tests := map[string]struct {
+		version  string
+		expected string
+	}{
+		"no version":    {"", "Basic OnRva2Vu"},
+		"3.6":           {"3.6", "Basic OnRva2Vu"},
+		"3.6 full":      {"3.6.700.103", "Basic OnRva2Vu"},
+		"4.0":           {"4.0", "Bearer token"},
+		"4.10":          {"4.10", "Bearer token"},
+		"4.5 full":      {"4.5.0", "Bearer token"},
+		"major above 9": {"10.1", "Bearer token"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var auth string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				w.Write([]byte(`{}`))
+			}))
+			defer ts.Close()
+
+			c, err := New(context.Background(), ts.URL, ClientOptions{}, false)
+			assert.NoError(t, err)
+			c.SetToken("token")
+
+			var resp interface{}
+			assert.NoError(t, c.DoWithHeaders(context.Background(), http.MethodGet, "/api/test", nil, nil, &resp, tt.version))
+			assert.Equal(t, tt.expected, auth)
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	major, minor, err := ParseVersion("4.10")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, major)
+	assert.Equal(t, 10, minor)
+
+	major, minor, err = ParseVersion(" 3.6.700.103 ")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, major)
+	assert.Equal(t, 6, minor)
+
+	_, _, err = ParseVersion("R4_5")
+	assert.EqualError(t, err, `invalid PowerFlex version "R4_5"`)
+}
+
 type SamplePayload struct {
 	Message string `json:"message"`
 }
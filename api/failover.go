@@ -0,0 +1,339 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dell/goscaleio/log"
+)
+
+// healthCheckTimeout bounds the health check of an endpoint.
+const healthCheckTimeout = 5 * time.Second
+
+// EndpointHealth is the result of the health check of an endpoint.
+type EndpointHealth struct {
+	Endpoint string
+	Active   bool
+	// Err is nil when the endpoint answered.
+	Err error
+}
+
+// Failover is a Client for a system reachable at several endpoints, such as
+// the management IPs of its MDMs. Requests are sent to the active endpoint.
+// When it cannot be reached, the next endpoint that passes a health check
+// becomes active and the request is sent to it again, provided it cannot have
+// reached the array or is idempotent. The token is shared by the endpoints.
+type Failover struct {
+	opts   ClientOptions
+	debug  bool
+	logger log.Logger
+
+	// switchMu serializes the changes of the active endpoint, so that the
+	// callers that find it down together switch once.
+	switchMu sync.Mutex
+
+	mu      sync.RWMutex // guards the fields below
+	clients []*client
+	active  int
+}
+
+// NewFailover returns a Client for the given endpoints of a system, the first
+// one being active.
+func NewFailover(ctx context.Context, endpoints []string, opts ClientOptions, debug bool) (*Failover, error) {
+	f := &Failover{opts: opts, debug: debug, logger: log.NewLogger(opts.Logger)}
+	if err := f.Add(ctx, endpoints...); err != nil {
+		return nil, err
+	}
+	if len(f.clients) == 0 {
+		return nil, errNewClient
+	}
+	return f, nil
+}
+
+// Add adds endpoints that requests may fail over to. Empty and known
+// endpoints are ignored.
+func (f *Failover) Add(ctx context.Context, endpoints ...string) error {
+	for _, endpoint := range endpoints {
+		if endpoint == "" || f.has(endpoint) {
+			continue
+		}
+		c, err := New(ctx, endpoint, f.opts, f.debug)
+		if err != nil {
+			return fmt.Errorf("endpoint %s: %w", endpoint, err)
+		}
+
+		f.mu.Lock()
+		if len(f.clients) > 0 {
+			c.SetToken(f.clients[f.active].GetToken())
+		}
+		f.clients = append(f.clients, c.(*client))
+		f.mu.Unlock()
+	}
+	return nil
+}
+
+func (f *Failover) has(endpoint string) bool {
+	key := endpointKey(strings.Replace(endpoint, "/api", "", 1))
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, c := range f.clients {
+		if endpointKey(c.host) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Endpoints returns the endpoints of the system, in the order they are tried.
+func (f *Failover) Endpoints() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	endpoints := make([]string, len(f.clients))
+	for i, c := range f.clients {
+		endpoints[i] = c.host
+	}
+	return endpoints
+}
+
+// Active returns the endpoint requests are sent to.
+func (f *Failover) Active() string {
+	c, _ := f.current()
+	return c.host
+}
+
+func (f *Failover) current() (*client, int) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.clients[f.active], f.active
+}
+
+// Check health checks every endpoint. When the active endpoint fails the
+// check, the first healthy one becomes active.
+func (f *Failover) Check(ctx context.Context) []EndpointHealth {
+	f.mu.RLock()
+	clients := f.clients
+	active := f.active
+	f.mu.RUnlock()
+
+	health := make([]EndpointHealth, len(clients))
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			health[i] = EndpointHealth{Endpoint: c.host, Err: c.ping(ctx)}
+		}()
+	}
+	wg.Wait()
+
+	if health[active].Err != nil && ctx.Err() == nil {
+		for i := range health {
+			if health[i].Err == nil {
+				f.activate(active, i)
+				active = i
+				break
+			}
+		}
+	}
+	health[active].Active = true
+	return health
+}
+
+// failover makes the next healthy endpoint after the one at index from
+// active, unless another caller already did. It returns false when no other
+// endpoint is healthy.
+func (f *Failover) failover(ctx context.Context, from int) (*client, bool) {
+	f.switchMu.Lock()
+	defer f.switchMu.Unlock()
+
+	f.mu.RLock()
+	clients := f.clients
+	active := f.active
+	f.mu.RUnlock()
+	if active != from {
+		return clients[active], true
+	}
+
+	for n := 1; n < len(clients); n++ {
+		i := (from + n) % len(clients)
+		if err := clients[i].ping(ctx); err != nil {
+			f.logger.Debug(fmt.Sprintf("endpoint %s is unhealthy: %s", clients[i].host, err))
+			continue
+		}
+		f.activate(from, i)
+		return clients[i], true
+	}
+	return nil, false
+}
+
+func (f *Failover) activate(from, to int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active != from {
+		return
+	}
+	f.active = to
+	f.logger.Info(fmt.Sprintf("endpoint %s is unreachable, switching to %s", f.clients[from].host, f.clients[to].host))
+}
+
+// call sends a request with send to the active endpoint, and to the next
+// healthy one when the active one cannot be reached. replayable is false when
+// the request body cannot be read a second time.
+func (f *Failover) call(ctx context.Context, method, path string, replayable bool, send func(c *client) error) error {
+	c, i := f.current()
+	err := send(c)
+	if err == nil || !unreachable(ctx, err) {
+		return err
+	}
+
+	next, ok := f.failover(ctx, i)
+	if !ok || !replayable || !(notSent(err) || IsIdempotentRequest(method, path)) {
+		return err
+	}
+	return send(next)
+}
+
+// unreachable reports whether err means the endpoint could not be reached,
+// rather than the array rejecting the request or the caller giving up.
+func unreachable(ctx context.Context, err error) bool {
+	var urlErr *url.Error
+	return ctx.Err() == nil && errors.As(err, &urlErr)
+}
+
+// notSent reports whether a request failed before reaching the array.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	return errors.Is(err, syscall.ECONNREFUSED) || (errors.As(err, &opErr) && opErr.Op == "dial")
+}
+
+// ping sends a GET of /api/version, which every endpoint answers, even
+// without a token.
+func (c *client) ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(c.host, "/")+"/api/version", nil)
+	if err != nil {
+		return err
+	}
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("health check failed: %s", res.Status)
+	}
+	return nil
+}
+
+// Get sends a GET request, see Client.
+func (f *Failover) Get(ctx context.Context, path string, headers map[string]string, resp interface{}) error {
+	return f.DoWithHeaders(ctx, http.MethodGet, path, headers, nil, resp, "")
+}
+
+// Post sends a POST request, see Client.
+func (f *Failover) Post(ctx context.Context, path string, headers map[string]string, body, resp interface{}) error {
+	return f.DoWithHeaders(ctx, http.MethodPost, path, headers, body, resp, "")
+}
+
+// Put sends a PUT request, see Client.
+func (f *Failover) Put(ctx context.Context, path string, headers map[string]string, body, resp interface{}) error {
+	return f.DoWithHeaders(ctx, http.MethodPut, path, headers, body, resp, "")
+}
+
+// Delete sends a DELETE request, see Client.
+func (f *Failover) Delete(ctx context.Context, path string, headers map[string]string, resp interface{}) error {
+	return f.DoWithHeaders(ctx, http.MethodDelete, path, headers, nil, resp, "")
+}
+
+// Do sends a request, see Client.
+func (f *Failover) Do(ctx context.Context, method, path string, body, resp interface{}) error {
+	return f.DoWithHeaders(ctx, method, path, nil, body, resp, "")
+}
+
+// DoWithHeaders sends a request with headers, see Client.
+func (f *Failover) DoWithHeaders(
+	ctx context.Context,
+	method, path string,
+	headers map[string]string,
+	body, resp interface{}, version string,
+) error {
+	_, stream := body.(io.ReadCloser)
+	return f.call(ctx, method, path, !stream, func(c *client) error {
+		return c.DoWithHeaders(ctx, method, path, headers, body, resp, version)
+	})
+}
+
+// DoAndGetResponseBody sends a request and returns the response with its
+// body unread, see Client.
+func (f *Failover) DoAndGetResponseBody(
+	ctx context.Context,
+	method, path string,
+	headers map[string]string,
+	body interface{}, version string,
+) (res *http.Response, err error) {
+	_, stream := body.(io.ReadCloser)
+	err = f.call(ctx, method, path, !stream, func(c *client) error {
+		res, err = c.DoAndGetResponseBody(ctx, method, path, headers, body, version)
+		return err
+	})
+	return res, err
+}
+
+// DoXMLRequest sends a request with an XML body, see Client.
+func (f *Failover) DoXMLRequest(
+	ctx context.Context,
+	method, path, version string,
+	body, resp interface{},
+) (res *http.Response, err error) {
+	err = f.call(ctx, method, path, true, func(c *client) error {
+		res, err = c.DoXMLRequest(ctx, method, path, version, body, resp)
+		return err
+	})
+	return res, err
+}
+
+// SetToken sets the token of every endpoint.
+func (f *Failover) SetToken(token string) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, c := range f.clients {
+		c.SetToken(token)
+	}
+}
+
+// GetToken returns the token of the active endpoint.
+func (f *Failover) GetToken() string {
+	c, _ := f.current()
+	return c.GetToken()
+}
+
+// ParseJSONError parses the JSON error in r, see Client.
+func (f *Failover) ParseJSONError(r *http.Response) error {
+	c, _ := f.current()
+	return c.ParseJSONError(r)
+}
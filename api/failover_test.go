@@ -0,0 +1,129 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newDownServer returns the URL of a server that is no longer listening.
+func newDownServer() string {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+	return ts.URL
+}
+
+func TestFailover(t *testing.T) {
+	var requests int32
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/version" {
+			atomic.AddInt32(&requests, 1)
+			_, token, _ := r.BasicAuth()
+			assert.Equal(t, "token", token)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer up.Close()
+	down := newDownServer()
+
+	f, err := NewFailover(context.Background(), []string{down, up.URL + "/api", down, ""}, ClientOptions{}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{down, up.URL}, f.Endpoints())
+	assert.Equal(t, down, f.Active())
+	f.SetToken("token")
+
+	// the callers that find the endpoint down together switch once
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, f.Post(context.Background(), "/api/types/Volume/instances", nil, map[string]string{}, nil))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, up.URL, f.Active())
+	assert.Equal(t, int32(10), atomic.LoadInt32(&requests))
+	assert.Equal(t, "token", f.GetToken())
+
+	// with every endpoint down, the error is returned
+	up.Close()
+	err = f.Get(context.Background(), "/api/types/Volume/instances", nil, nil)
+	assert.Error(t, err)
+	assert.Equal(t, up.URL, f.Active())
+
+	_, err = NewFailover(context.Background(), nil, ClientOptions{}, false)
+	assert.Error(t, err)
+}
+
+func TestFailoverNotReplayed(t *testing.T) {
+	var posts int32
+	// the connection is dropped once the request has been read
+	dropping := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/version" {
+			return
+		}
+		conn, _, err := w.(http.Hijacker).Hijack()
+		assert.NoError(t, err)
+		conn.Close()
+	}))
+	defer dropping.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			atomic.AddInt32(&posts, 1)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer up.Close()
+
+	f, err := NewFailover(context.Background(), []string{dropping.URL, up.URL}, ClientOptions{}, false)
+	assert.NoError(t, err)
+
+	// the mapping may have reached the array, so it is not sent again
+	err = f.Post(context.Background(), "/api/instances/Volume::1/action/addMappedSdc", nil, map[string]string{}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&posts))
+	assert.Equal(t, up.URL, f.Active())
+}
+
+func TestFailoverCheck(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	// a 401 is an answer
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer up.Close()
+	down := newDownServer()
+
+	f, err := NewFailover(context.Background(), []string{down, failing.URL}, ClientOptions{}, false)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Add(context.Background(), up.URL, failing.URL))
+
+	health := f.Check(context.Background())
+	assert.Len(t, health, 3)
+	assert.Error(t, health[0].Err)
+	assert.Error(t, health[1].Err)
+	assert.NoError(t, health[2].Err)
+	assert.True(t, health[2].Active)
+	assert.Equal(t, up.URL, f.Active())
+}
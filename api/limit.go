@@ -0,0 +1,177 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Limits caps the requests sent to an array endpoint. Read requests and
+// mutating requests have separate budgets, so that a burst of mappings does
+// not hold up queries.
+type Limits struct {
+	// Read is the budget of GET requests and of POSTs to query actions.
+	Read Limit
+
+	// Write is the budget of the other requests.
+	Write Limit
+}
+
+// Limit is the budget of one kind of request. Its zero value sets no limit.
+type Limit struct {
+	// Rate is the number of requests per second, on average. Requests are
+	// not rate limited when it is zero.
+	Rate float64
+
+	// Burst is the number of requests that may be sent at once beyond Rate.
+	// It is 1 when zero.
+	Burst int
+
+	// MaxInFlight caps the number of requests waiting for their response,
+	// which is in flight until its body is closed. The responses streamed
+	// by iterators such as System.AllSds leave the cap once they arrive, so
+	// that the loop over them may send requests of its own. Requests are not
+	// capped when it is zero.
+	MaxInFlight int
+}
+
+// Limiter enforces Limits for an endpoint. Its methods may be called on a
+// nil Limiter, which sets no limit.
+type Limiter struct {
+	read, write *budget
+}
+
+type budget struct {
+	rate  *rate.Limiter // nil when not rate limited
+	slots chan struct{} // nil when not capped
+}
+
+func newBudget(l Limit) *budget {
+	b := &budget{}
+	if l.Rate > 0 {
+		b.rate = rate.NewLimiter(rate.Limit(l.Rate), max(l.Burst, 1))
+	}
+	if l.MaxInFlight > 0 {
+		b.slots = make(chan struct{}, l.MaxInFlight)
+	}
+	return b
+}
+
+var limiters = struct {
+	sync.Mutex
+	byEndpoint map[string]*Limiter
+}{byEndpoint: map[string]*Limiter{}}
+
+// LimiterFor returns the Limiter shared by the clients of endpoint. It is
+// created with the limits of the first client of the endpoint; the limits of
+// the next ones are ignored. It returns nil when limits is nil.
+func LimiterFor(endpoint string, limits *Limits) *Limiter {
+	if limits == nil {
+		return nil
+	}
+
+	key := endpointKey(endpoint)
+
+	limiters.Lock()
+	defer limiters.Unlock()
+
+	l, ok := limiters.byEndpoint[key]
+	if !ok {
+		l = &Limiter{read: newBudget(limits.Read), write: newBudget(limits.Write)}
+		limiters.byEndpoint[key] = l
+	}
+	return l
+}
+
+// endpointKey identifies an endpoint by its scheme and host, whatever the
+// case of the host and the path.
+func endpointKey(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		return u.Scheme + "://" + strings.ToLower(u.Host)
+	}
+	return endpoint
+}
+
+// Acquire waits until a request may be sent, or until ctx is done. The
+// returned function must be called once the request is no longer in flight.
+func (l *Limiter) Acquire(ctx context.Context, method, path string) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	b := l.write
+	if isReadRequest(method, path) {
+		b = l.read
+	}
+
+	if b.slots != nil {
+		select {
+		case b.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release = sync.OnceFunc(func() {
+		if b.slots != nil {
+			<-b.slots
+		}
+	})
+
+	if b.rate != nil {
+		if err := b.rate.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// isReadRequest reports whether a request leaves the array unchanged: GET,
+// HEAD and OPTIONS requests, and POSTs to query actions such as
+// action/queryIdByKey or querySelectedStatistics.
+func isReadRequest(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return strings.HasPrefix(path[strings.LastIndex(path, "/")+1:], "query")
+	}
+	return false
+}
+
+// ReleaseSlot releases the slot of the request of res before its body is
+// closed, for responses read while other requests are sent, such as the ones
+// streamed by iterators. Closing the body afterwards does not release it
+// again.
+func ReleaseSlot(res *http.Response) {
+	if b, ok := res.Body.(*releaseBody); ok {
+		b.release()
+	}
+}
+
+// releaseBody releases the slot of a request when its body is closed.
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
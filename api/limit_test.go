@@ -0,0 +1,165 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsReadRequest(t *testing.T) {
+	cases := []struct {
+		method, path string
+		read         bool
+	}{
+		{http.MethodGet, "/api/types/Volume/instances", true},
+		{http.MethodHead, "/api/version", true},
+		{http.MethodPost, "/api/types/Volume/instances/action/queryIdByKey", true},
+		{http.MethodPost, "/api/types/Sdc/instances/action/queryBySelectedIds", true},
+		{http.MethodPost, "/api/instances/querySelectedStatistics", true},
+		{http.MethodPost, "/api/instances/Volume::1/action/addMappedSdc", false},
+		{http.MethodPost, "/api/types/Volume/instances", false},
+		{http.MethodPut, "/rest/v1/volumes/1", false},
+		{http.MethodDelete, "/rest/v1/volumes/1", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.read, isReadRequest(c.method, c.path), "%s %s", c.method, c.path)
+	}
+}
+
+func TestLimiterFor(t *testing.T) {
+	assert.Nil(t, LimiterFor("https://10.0.0.1", nil))
+
+	l := LimiterFor("https://array.test:443/api", &Limits{Write: Limit{MaxInFlight: 1}})
+	assert.Same(t, l, LimiterFor("https://ARRAY.test:443", &Limits{}))
+	assert.NotSame(t, l, LimiterFor("https://other.test:443", &Limits{}))
+
+	// a nil Limiter sets no limit
+	var none *Limiter
+	release, err := none.Acquire(context.Background(), http.MethodPost, "/api/types/Volume/instances")
+	assert.NoError(t, err)
+	release()
+}
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	l := LimiterFor("https://max-in-flight.test", &Limits{
+		Read:  Limit{MaxInFlight: 2},
+		Write: Limit{MaxInFlight: 1},
+	})
+	mapping := "/api/instances/Volume::1/action/addMappedSdc"
+
+	release, err := l.Acquire(context.Background(), http.MethodPost, mapping)
+	assert.NoError(t, err)
+
+	// the write budget is used up, the read one is not
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx, http.MethodPost, mapping)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	releaseRead, err := l.Acquire(context.Background(), http.MethodGet, "/api/types/Volume/instances")
+	assert.NoError(t, err)
+	releaseRead()
+
+	release()
+	release() // releasing twice frees a single slot
+	release, err = l.Acquire(context.Background(), http.MethodPost, mapping)
+	assert.NoError(t, err)
+	release()
+}
+
+func TestReleaseSlot(t *testing.T) {
+	l := LimiterFor("https://release-slot.test", &Limits{Read: Limit{MaxInFlight: 1}})
+	path := "/api/types/Sds/instances"
+
+	release, err := l.Acquire(context.Background(), http.MethodGet, path)
+	assert.NoError(t, err)
+	res := &http.Response{Body: &releaseBody{ReadCloser: io.NopCloser(strings.NewReader("[]")), release: release}}
+
+	// the slot is free while the body is still open, and closing the body
+	// does not free another one
+	ReleaseSlot(res)
+	release, err = l.Acquire(context.Background(), http.MethodGet, path)
+	assert.NoError(t, err)
+	assert.NoError(t, res.Body.Close())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx, http.MethodGet, path)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	release()
+
+	// bodies without a slot are left alone
+	ReleaseSlot(&http.Response{Body: http.NoBody})
+}
+
+func TestLimiterRate(t *testing.T) {
+	l := LimiterFor("https://rate.test", &Limits{Write: Limit{Rate: 0.001, MaxInFlight: 1}})
+	path := "/api/types/Volume/instances"
+
+	release, err := l.Acquire(context.Background(), http.MethodPost, path)
+	assert.NoError(t, err)
+	release()
+
+	// the next token is 1000s away, beyond the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = l.Acquire(ctx, http.MethodPost, path)
+	assert.Error(t, err)
+
+	// the slot taken while waiting was given back
+	assert.Empty(t, l.write.slots)
+}
+
+func TestSenderLimits(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	limits := &Limits{Write: Limit{MaxInFlight: 2}}
+	var clients []Client
+	for i := 0; i < 2; i++ {
+		c, err := New(context.Background(), ts.URL, ClientOptions{Limits: limits}, false)
+		assert.NoError(t, err)
+		clients = append(clients, c)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(c Client) {
+			defer wg.Done()
+			assert.NoError(t, c.Post(context.Background(), "/api/instances/Volume::1/action/addMappedSdc", nil, map[string]string{}, nil))
+		}(clients[i%2])
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), maxInFlight.Load())
+}
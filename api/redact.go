@@ -0,0 +1,95 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces the credentials, tokens and cookies in the HTTP dumps
+// logged when ShowHTTP is set.
+const Redacted = "REDACTED"
+
+// redactedHeaders are the headers whose values are masked.
+var redactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Auth-Token",
+}
+
+// redactHeader returns a copy of h with the values of credential and cookie
+// headers masked.
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, key := range redactedHeaders {
+		if values, ok := h[key]; ok {
+			for i := range values {
+				values[i] = Redacted
+			}
+		}
+	}
+	return h
+}
+
+// redactBody returns body with the values of the JSON fields whose names
+// contain "password", "token" or "secret" masked, and the session token
+// returned by a login to the given path masked altogether. Bodies that are
+// not JSON are returned unchanged.
+func redactBody(path string, body []byte) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '"' && strings.HasSuffix(path, "/login") {
+		return []byte(`"` + Redacted + `"`)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || !redactValue(v) {
+		return body
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+func redactValue(v any) bool {
+	redacted := false
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if _, ok := value.(string); ok && isSecretField(key) {
+				v[key] = Redacted
+				redacted = true
+				continue
+			}
+			redacted = redactValue(value) || redacted
+		}
+	case []any:
+		for _, value := range v {
+			redacted = redactValue(value) || redacted
+		}
+	}
+	return redacted
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "password") || strings.Contains(name, "token") || strings.Contains(name, "secret")
+}
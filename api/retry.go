@@ -0,0 +1,201 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how the API client retries requests that fail with a
+// transient error, such as a timeout, a dropped connection or a 503 returned
+// while the MDM cluster switches ownership.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+
+	// Multiplier is applied to the delay after every attempt. Values below 1
+	// are treated as 2.
+	Multiplier float64
+
+	// Jitter is the fraction of every delay, between 0 and 1, that is
+	// randomized to spread out retries from concurrent callers.
+	Jitter float64
+
+	// RetryableStatusCodes lists the HTTP status codes that are retried.
+	RetryableStatusCodes []int
+
+	// RetryNonIdempotent allows requests rejected by IsIdempotent to be
+	// retried after a failure that may have reached the array.
+	RetryNonIdempotent bool
+
+	// IsIdempotent reports whether a request may be replayed safely.
+	// IsIdempotentRequest is used when it is nil.
+	IsIdempotent func(method, path string) bool
+}
+
+// DefaultRetryPolicy returns a policy that makes up to four attempts with
+// exponential backoff from 500ms up to 8s, retrying 429, 502, 503 and 504.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     8 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// IsIdempotentRequest reports whether replaying the request cannot change the
+// state of the array. GET, HEAD, PUT, DELETE and OPTIONS requests are
+// idempotent, as are POSTs to the read-only query actions. Other POSTs, such
+// as action/addMappedSdc or instance creation, are not.
+func IsIdempotentRequest(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		if i := strings.LastIndex(path, "/action/"); i >= 0 {
+			return strings.HasPrefix(path[i+len("/action/"):], "query")
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) enabled() bool {
+	return p != nil && p.MaxAttempts > 1
+}
+
+// shouldRetry reports whether a request that produced res or err on the given
+// attempt should be sent again. replayable is false when the request body
+// cannot be read a second time.
+func (p *RetryPolicy) shouldRetry(
+	ctx context.Context,
+	attempt int,
+	req *http.Request,
+	replayable bool,
+	res *http.Response,
+	err error,
+) bool {
+	if !p.enabled() || attempt >= p.MaxAttempts || !replayable || ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		// A refused connection never reached the array, so even a
+		// non-idempotent request can be sent again.
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return true
+		}
+		return isTransientError(err) && p.idempotent(req)
+	}
+
+	if res == nil || !slices.Contains(p.RetryableStatusCodes, res.StatusCode) {
+		return false
+	}
+	// The array rejects throttled requests before acting on them.
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return p.idempotent(req)
+}
+
+func (p *RetryPolicy) idempotent(req *http.Request) bool {
+	if p.RetryNonIdempotent {
+		return true
+	}
+	isIdempotent := p.IsIdempotent
+	if isIdempotent == nil {
+		isIdempotent = IsIdempotentRequest
+	}
+	return isIdempotent(req.Method, req.URL.Path)
+}
+
+// backoff returns the delay before the attempt following the given one. A
+// Retry-After header on res takes precedence, capped at MaxBackoff.
+func (p *RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if s, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && s >= 0 {
+			return p.capBackoff(time.Duration(s) * time.Second)
+		}
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+	}
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		delay = delay*(1-jitter) + delay*jitter*rand.Float64() // #nosec G404
+	}
+	return p.capBackoff(time.Duration(delay))
+}
+
+func (p *RetryPolicy) capBackoff(d time.Duration) time.Duration {
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return d
+}
+
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// The caller's context is checked separately; these come from the
+		// http.Client timeout.
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	types "github.com/dell/goscaleio/types/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.InitialBackoff = time.Millisecond
+	p.MaxBackoff = 5 * time.Millisecond
+	p.Jitter = 0
+	return p
+}
+
+func TestRetryPolicyDoAndGetResponseBody(t *testing.T) {
+	tests := map[string]struct {
+		method       string
+		path         string
+		body         interface{}
+		policy       *RetryPolicy
+		failures     int32
+		failStatus   int
+		wantAttempts int32
+		wantStatus   int
+	}{
+		"no policy": {
+			method:       http.MethodGet,
+			path:         "/api/types/Volume/instances",
+			failures:     1,
+			failStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 1,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+		"GET retried until success": {
+			method:       http.MethodGet,
+			path:         "/api/types/Volume/instances",
+			policy:       testRetryPolicy(),
+			failures:     2,
+			failStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 3,
+			wantStatus:   http.StatusOK,
+		},
+		"GET gives up after max attempts": {
+			method:       http.MethodGet,
+			path:         "/api/types/Volume/instances",
+			policy:       testRetryPolicy(),
+			failures:     10,
+			failStatus:   http.StatusBadGateway,
+			wantAttempts: 4,
+			wantStatus:   http.StatusBadGateway,
+		},
+		"status not retryable": {
+			method:       http.MethodGet,
+			path:         "/api/types/Volume/instances",
+			policy:       testRetryPolicy(),
+			failures:     1,
+			failStatus:   http.StatusInternalServerError,
+			wantAttempts: 1,
+			wantStatus:   http.StatusInternalServerError,
+		},
+		"query action retried": {
+			method:       http.MethodPost,
+			path:         "/api/types/Volume/instances/action/queryIdByKey",
+			body:         map[string]string{"name": "vol"},
+			policy:       testRetryPolicy(),
+			failures:     1,
+			failStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 2,
+			wantStatus:   http.StatusOK,
+		},
+		"mutating action not retried": {
+			method:       http.MethodPost,
+			path:         "/api/instances/Volume::1/action/addMappedSdc",
+			body:         map[string]string{"sdcId": "1"},
+			policy:       testRetryPolicy(),
+			failures:     1,
+			failStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 1,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+		"mutating action retried on 429": {
+			method:       http.MethodPost,
+			path:         "/api/instances/Volume::1/action/addMappedSdc",
+			body:         map[string]string{"sdcId": "1"},
+			policy:       testRetryPolicy(),
+			failures:     1,
+			failStatus:   http.StatusTooManyRequests,
+			wantAttempts: 2,
+			wantStatus:   http.StatusOK,
+		},
+		"stream body not retried": {
+			method:       http.MethodPut,
+			path:         "/api/upload",
+			body:         io.NopCloser(strings.NewReader("data")),
+			policy:       testRetryPolicy(),
+			failures:     1,
+			failStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 1,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+			var bodies []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(b))
+				if atomic.AddInt32(&attempts, 1) <= tt.failures {
+					w.WriteHeader(tt.failStatus)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer ts.Close()
+
+			c, err := New(context.Background(), ts.URL, ClientOptions{RetryPolicy: tt.policy}, false)
+			assert.NoError(t, err)
+
+			res, err := c.DoAndGetResponseBody(context.Background(), tt.method, tt.path, nil, tt.body, "")
+			assert.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, res.StatusCode)
+			assert.Equal(t, tt.wantAttempts, atomic.LoadInt32(&attempts))
+			for _, b := range bodies {
+				assert.Equal(t, bodies[0], b)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDoXMLRequest(t *testing.T) {
+	var attempts int32
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/xml", r.Header.Get("Content-Type"))
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer ts.Close()
+
+	c, err := New(context.Background(), ts.URL, ClientOptions{RetryPolicy: testRetryPolicy()}, false)
+	assert.NoError(t, err)
+
+	body := types.SwitchCredentialWrapper{IomCredential: types.IomCredential{Username: "admin"}}
+	var resp struct {
+		ID string `json:"id"`
+	}
+	_, err = c.DoXMLRequest(context.Background(), http.MethodPost, "/api/v1/Credential", "4.0", body, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", resp.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	assert.Equal(t, bodies[0], bodies[1])
+	assert.Contains(t, bodies[0], "admin")
+}
+
+func TestRetryPolicyContextCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	policy := testRetryPolicy()
+	policy.InitialBackoff = time.Minute
+	policy.MaxBackoff = time.Minute
+	c, err := New(context.Background(), ts.URL, ClientOptions{RetryPolicy: policy}, false)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = c.DoAndGetResponseBody(ctx, http.MethodGet, "/api/version", nil, nil, "")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+	assert.Equal(t, 100*time.Millisecond, p.backoff(1, nil))
+	assert.Equal(t, 200*time.Millisecond, p.backoff(2, nil))
+	assert.Equal(t, 400*time.Millisecond, p.backoff(3, nil))
+	assert.Equal(t, time.Second, p.backoff(5, nil))
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"30"}}}
+	assert.Equal(t, time.Second, p.backoff(1, res))
+
+	p.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		d := p.backoff(2, nil)
+		assert.GreaterOrEqual(t, d, 100*time.Millisecond)
+		assert.LessOrEqual(t, d, 200*time.Millisecond)
+	}
+}
+
+func TestIsIdempotentRequest(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{http.MethodGet, "/api/types/Volume/instances", true},
+		{http.MethodDelete, "/api/instances/Volume::1", true},
+		{http.MethodPost, "/api/types/Volume/instances", false},
+		{http.MethodPost, "/api/instances/Volume::1/action/removeVolume", false},
+		{http.MethodPost, "/api/types/Volume/instances/action/queryIdByKey", true},
+		{http.MethodPost, "/api/types/VTree/instances/action/queryBySelectedIds", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, IsIdempotentRequest(tt.method, tt.path), "%s %s", tt.method, tt.path)
+	}
+}
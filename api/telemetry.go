@@ -0,0 +1,265 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attributes of the spans and metrics, besides the semantic conventions of
+// OpenTelemetry for HTTP clients.
+const (
+	// AttrMethod is the name of the public method of a call.
+	AttrMethod = attribute.Key("goscaleio.method")
+	// AttrObjectType is the type of the object a request refers to, such as
+	// Volume.
+	AttrObjectType = attribute.Key("goscaleio.object.type")
+	// AttrObjectID is the ID of the object a request refers to.
+	AttrObjectID = attribute.Key("goscaleio.object.id")
+	// AttrRetryCount is the number of requests of a call that were retries.
+	AttrRetryCount = attribute.Key("goscaleio.retry.count")
+)
+
+const instrumentationName = "github.com/dell/goscaleio"
+
+// Telemetry creates the OpenTelemetry spans and metrics of the clients: a
+// span for every call of a public method, and a child span for every HTTP
+// request sent, retries included.
+type Telemetry struct {
+	tracer          trace.Tracer
+	propagator      propagation.TextMapPropagator
+	callDuration    metric.Float64Histogram
+	requestDuration metric.Float64Histogram
+}
+
+// NewTelemetry returns the Telemetry set up by the TracerProvider,
+// MeterProvider and Propagator of opts, or by the global ones of otel for
+// those that are nil. Nothing is recorded until the application sets up
+// OpenTelemetry.
+func NewTelemetry(opts ClientOptions) *Telemetry {
+	tp, mp, propagator := opts.TracerProvider, opts.MeterProvider, opts.Propagator
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+
+	meter := mp.Meter(instrumentationName)
+	t := &Telemetry{
+		tracer:     tp.Tracer(instrumentationName),
+		propagator: propagator,
+	}
+	var err error
+	if t.callDuration, err = meter.Float64Histogram("goscaleio.call.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of the calls of the public methods of the clients.")); err != nil {
+		otel.Handle(err)
+	}
+	if t.requestDuration, err = meter.Float64Histogram("http.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of HTTP client requests.")); err != nil {
+		otel.Handle(err)
+	}
+	return t
+}
+
+var defaultTelemetry = sync.OnceValue(func() *Telemetry {
+	return NewTelemetry(ClientOptions{})
+})
+
+func (t *Telemetry) orDefault() *Telemetry {
+	if t == nil {
+		return defaultTelemetry()
+	}
+	return t
+}
+
+type callKey struct{}
+
+// Call is the span of a call of a public method. Its methods may be called
+// on a nil Call, and do nothing.
+type Call struct {
+	t     *Telemetry
+	span  trace.Span
+	name  string
+	start time.Time
+
+	mu      sync.Mutex // guards the fields below
+	err     error
+	retries int
+	object  bool
+}
+
+// StartCall starts the span of a call of the public method name, which End
+// must end. The returned context holds the span, for the requests and the
+// calls made on behalf of this one.
+func (t *Telemetry) StartCall(ctx context.Context, name string) (context.Context, *Call) {
+	t = t.orDefault()
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(AttrMethod.String(name)))
+	c := &Call{t: t, span: span, name: name, start: time.Now()}
+	return context.WithValue(ctx, callKey{}, c), c
+}
+
+// CallFromContext returns the innermost call in ctx, or nil.
+func CallFromContext(ctx context.Context) *Call {
+	c, _ := ctx.Value(callKey{}).(*Call)
+	return c
+}
+
+// SetError records the error returned by the call.
+func (c *Call) SetError(err error) {
+	if c == nil || err == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// End ends the span of the call and records its duration.
+func (c *Call) End() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	err, retries := c.err, c.retries
+	c.mu.Unlock()
+
+	attrs := []attribute.KeyValue{AttrMethod.String(c.name)}
+	if err != nil {
+		c.span.RecordError(err)
+		c.span.SetStatus(codes.Error, err.Error())
+		attrs = append(attrs, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+	}
+	c.span.SetAttributes(AttrRetryCount.Int(retries))
+	c.span.End()
+	c.t.callDuration.Record(context.Background(), time.Since(c.start).Seconds(), metric.WithAttributes(attrs...))
+}
+
+// request records the object of a request sent by the call, and whether it
+// was a retry.
+func (c *Call) request(objectType, objectID string, retry bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if retry {
+		c.retries++
+	}
+	if objectType != "" && !c.object {
+		c.object = true
+		c.span.SetAttributes(objectAttributes(objectType, objectID)...)
+	}
+}
+
+// startRequest starts the span of the given attempt to send req. It returns
+// req with the span in its context and in its headers, and the function that
+// ends the span with the outcome of the attempt.
+func (t *Telemetry) startRequest(req *http.Request, attempt int) (*http.Request, func(*http.Response, error)) {
+	t = t.orDefault()
+	start := time.Now()
+	objectType, objectID := objectOf(req.URL.Path)
+	CallFromContext(req.Context()).request(objectType, objectID, attempt > 1)
+
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if port, err := strconv.Atoi(req.URL.Port()); err == nil {
+		attrs = append(attrs, semconv.ServerPort(port))
+	}
+	attrs = append(attrs, objectAttributes(objectType, "")...)
+	spanAttrs := append([]attribute.KeyValue{semconv.URLFull(req.URL.String())}, attrs...)
+	if objectID != "" {
+		spanAttrs = append(spanAttrs, AttrObjectID.String(objectID))
+	}
+	if attempt > 1 {
+		spanAttrs = append(spanAttrs, semconv.HTTPRequestResendCount(attempt-1))
+	}
+
+	ctx, span := t.tracer.Start(req.Context(), req.Method,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(spanAttrs...))
+	req = req.WithContext(ctx)
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return req, func(res *http.Response, err error) {
+		switch {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			attrs = append(attrs, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+		case res != nil:
+			attrs = append(attrs, semconv.HTTPResponseStatusCode(res.StatusCode))
+			if res.StatusCode >= 400 {
+				span.SetStatus(codes.Error, res.Status)
+				attrs = append(attrs, semconv.ErrorTypeKey.String(strconv.Itoa(res.StatusCode)))
+			}
+		}
+		span.SetAttributes(attrs...)
+		span.End()
+		t.requestDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+	}
+}
+
+func objectAttributes(objectType, objectID string) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if objectType != "" {
+		attrs = append(attrs, AttrObjectType.String(objectType))
+	}
+	if objectID != "" {
+		attrs = append(attrs, AttrObjectID.String(objectID))
+	}
+	return attrs
+}
+
+// objectOf returns the type and ID of the object a request path refers to,
+// as in /api/instances/Volume::<id>/action/... or /api/types/Volume/instances.
+func objectOf(path string) (objectType, objectID string) {
+	if i := strings.Index(path, "/api/"); i >= 0 {
+		path = path[i:]
+	}
+	if rest, ok := strings.CutPrefix(path, "/api/instances/"); ok {
+		instance, _, _ := strings.Cut(rest, "/")
+		objectType, objectID, _ = strings.Cut(instance, "::")
+		if objectID == "" {
+			// e.g. /api/instances/querySelectedStatistics
+			return "", ""
+		}
+		return objectType, objectID
+	}
+	if rest, ok := strings.CutPrefix(path, "/api/types/"); ok {
+		objectType, _, _ = strings.Cut(rest, "/")
+		return objectType, ""
+	}
+	return "", ""
+}
@@ -0,0 +1,83 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestObjectOf(t *testing.T) {
+	cases := []struct {
+		path, objectType, objectID string
+	}{
+		{"/api/instances/Volume::abc", "Volume", "abc"},
+		{"/api/instances/Volume::abc/action/addMappedSdc", "Volume", "abc"},
+		{"/api/instances/System::1/relationships/Sdc", "System", "1"},
+		{"/api/types/StoragePool/instances", "StoragePool", ""},
+		{"/api/types/Volume/instances/action/queryIdByKey", "Volume", ""},
+		{"/prefix/api/instances/Sds::s1", "Sds", "s1"},
+		{"/api/instances/querySelectedStatistics", "", ""},
+		{"/api/login", "", ""},
+		{"/im/types/Configuration/instances", "", ""},
+	}
+	for _, c := range cases {
+		objectType, objectID := objectOf(c.path)
+		assert.Equal(t, c.objectType, objectType, c.path)
+		assert.Equal(t, c.objectID, objectID, c.path)
+	}
+}
+
+func TestSenderTelemetry(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	telemetry := NewTelemetry(ClientOptions{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	})
+
+	ctx, call := telemetry.StartCall(context.Background(), "GetSystems")
+	sender := &Sender{HTTP: &http.Client{}, Telemetry: telemetry}
+	_, err := sender.Send(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/types/System/instances", nil)
+	}, true)
+	assert.Error(t, err)
+	call.SetError(err)
+	call.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, "GetSystems", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+
+	// a nil Call and a nil Telemetry are usable
+	var nilCall *Call
+	nilCall.SetError(errors.New("ignored"))
+	nilCall.End()
+	var nilTelemetry *Telemetry
+	_, call = nilTelemetry.StartCall(context.Background(), "GetSystems")
+	call.End()
+}
@@ -0,0 +1,113 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var errPinnedCert = errors.New("server certificate does not match any pinned fingerprint")
+
+// NewTransport returns the http.RoundTripper described by the connection
+// options in opts. opts.Transport is returned unchanged when it is set;
+// otherwise an http.Transport is built from the TLS options. It is used by
+// New and by the gateway client so both apply the options the same way.
+func NewTransport(opts ClientOptions) (http.RoundTripper, error) {
+	if opts.Transport != nil {
+		return opts.Transport, nil
+	}
+
+	cfg := &tls.Config{} // #nosec G402 -- MinVersion is left to the caller's TLSConfig
+	if opts.TLSConfig != nil {
+		cfg = opts.TLSConfig.Clone()
+	}
+	if cfg.CipherSuites == nil {
+		cfg.CipherSuites = GetSecuredCipherSuites()
+	}
+	if opts.Insecure {
+		cfg.InsecureSkipVerify = true // #nosec G402
+	}
+
+	if (!opts.Insecure || opts.UseCerts) && cfg.RootCAs == nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, errSysCerts
+		}
+		cfg.RootCAs = pool
+	}
+
+	if opts.CACertFile != "" {
+		pem, err := os.ReadFile(filepath.Clean(opts.CACertFile))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA certificate file: %w", err)
+		}
+		// never add to a pool that belongs to the caller's TLSConfig
+		pool := x509.NewCertPool()
+		if cfg.RootCAs != nil {
+			pool = cfg.RootCAs.Clone()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA certificate file %s", opts.CACertFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if len(opts.PinnedCertSHA256) > 0 {
+		pinned, err := parseFingerprints(opts.PinnedCertSHA256)
+		if err != nil {
+			return nil, err
+		}
+		next := cfg.VerifyConnection
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errPinnedCert
+			}
+			sum := sha256.Sum256(cs.PeerCertificates[0].Raw)
+			if !pinned[hex.EncodeToString(sum[:])] {
+				return errPinnedCert
+			}
+			if next != nil {
+				return next(cs)
+			}
+			return nil
+		}
+	}
+
+	return &http.Transport{
+		Proxy:           opts.Proxy,
+		TLSClientConfig: cfg,
+	}, nil
+}
+
+// parseFingerprints normalizes SHA-256 fingerprints written as hex, with or
+// without colons, to lower case hex.
+func parseFingerprints(fingerprints []string) (map[string]bool, error) {
+	pinned := make(map[string]bool, len(fingerprints))
+	for _, f := range fingerprints {
+		f = strings.ToLower(strings.ReplaceAll(f, ":", ""))
+		if b, err := hex.DecodeString(f); err != nil || len(b) != sha256.Size {
+			return nil, fmt.Errorf("invalid SHA-256 fingerprint %q", f)
+		}
+		pinned[f] = true
+	}
+	return pinned, nil
+}
@@ -0,0 +1,127 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCACert writes the certificate of ts to a PEM file and returns its path.
+func writeCACert(t *testing.T, ts *httptest.Server) string {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestNewTransportTLS(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	sum := sha256.Sum256(ts.Certificate().Raw)
+	fingerprint := hex.EncodeToString(sum[:])
+	other := strings.Repeat("00", sha256.Size)
+
+	tests := map[string]struct {
+		opts    ClientOptions
+		wantErr string
+	}{
+		"untrusted CA": {
+			opts:    ClientOptions{},
+			wantErr: "certificate",
+		},
+		"CA file": {
+			opts: ClientOptions{CACertFile: writeCACert(t, ts)},
+		},
+		"CA file with minimum TLS version": {
+			opts: ClientOptions{
+				CACertFile: writeCACert(t, ts),
+				TLSConfig:  &tls.Config{MinVersion: tls.VersionTLS12},
+			},
+		},
+		"pinned certificate": {
+			opts: ClientOptions{Insecure: true, PinnedCertSHA256: []string{fingerprint}},
+		},
+		"pinned certificate with colons": {
+			opts: ClientOptions{
+				CACertFile:       writeCACert(t, ts),
+				PinnedCertSHA256: []string{strings.ToUpper(fingerprint[:2]) + ":" + fingerprint[2:]},
+			},
+		},
+		"pinned certificate mismatch": {
+			opts:    ClientOptions{Insecure: true, PinnedCertSHA256: []string{other}},
+			wantErr: errPinnedCert.Error(),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, err := New(context.Background(), ts.URL, tt.opts, false)
+			assert.NoError(t, err)
+
+			res, err := c.DoAndGetResponseBody(context.Background(), http.MethodGet, "/api/version", nil, nil, "")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			res.Body.Close()
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+		})
+	}
+}
+
+func TestNewTransportOptions(t *testing.T) {
+	custom := http.DefaultTransport
+	rt, err := NewTransport(ClientOptions{Transport: custom, CACertFile: "/does/not/exist"})
+	assert.NoError(t, err)
+	assert.Equal(t, custom, rt)
+
+	_, err = NewTransport(ClientOptions{CACertFile: "/does/not/exist"})
+	assert.ErrorContains(t, err, "unable to read CA certificate file")
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	assert.NoError(t, os.WriteFile(empty, nil, 0o600))
+	_, err = NewTransport(ClientOptions{CACertFile: empty})
+	assert.ErrorContains(t, err, "no certificates found")
+
+	_, err = NewTransport(ClientOptions{PinnedCertSHA256: []string{"abc"}})
+	assert.ErrorContains(t, err, "invalid SHA-256 fingerprint")
+
+	proxy, _ := url.Parse("http://proxy.example.com:3128")
+	base := &tls.Config{MinVersion: tls.VersionTLS13}
+	rt, err = NewTransport(ClientOptions{TLSConfig: base, Insecure: true, Proxy: http.ProxyURL(proxy)})
+	assert.NoError(t, err)
+	tr := rt.(*http.Transport)
+	assert.Equal(t, uint16(tls.VersionTLS13), tr.TLSClientConfig.MinVersion)
+	assert.True(t, tr.TLSClientConfig.InsecureSkipVerify)
+	assert.False(t, base.InsecureSkipVerify, "caller's TLSConfig must not be modified")
+	got, err := tr.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "array"}})
+	assert.NoError(t, err)
+	assert.Equal(t, proxy, got)
+}
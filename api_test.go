@@ -13,10 +13,13 @@
 package goscaleio
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"math"
 	"net/http"
 	"net/http/httptest"
@@ -24,9 +27,12 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/dell/goscaleio/api"
+	"github.com/dell/goscaleio/log"
 	v1 "github.com/dell/goscaleio/types/v1"
 	"github.com/stretchr/testify/assert"
 )
@@ -215,7 +221,7 @@ func TestClientVersion(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, "", ver)
 
-		err = client.updateVersion()
+		err = client.updateVersion(context.Background())
 		assert.Error(t, err)
 	})
 
@@ -332,6 +338,30 @@ func TestClientLogin(t *testing.T) {
 	})
 }
 
+func TestClientLoggerRedactsCredentials(t *testing.T) {
+	server := newGoScaleioTestServer()
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := NewClientWithOptions(server.URL, "", api.ClientOptions{
+		ShowHTTP: true,
+		Logger:   logger,
+	})
+	assert.NoError(t, err)
+
+	_, err = client.Authenticate(&ConfigConnect{
+		Endpoint: server.URL,
+		Username: "ScaleIOUser",
+		Password: "password",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "GOSCALEIO HTTP RESPONSE")
+	assert.Contains(t, buf.String(), "Authorization: REDACTED")
+	assert.NotContains(t, buf.String(), "012345678901234567890123456789")
+	assert.NotContains(t, buf.String(), base64.StdEncoding.EncodeToString([]byte("ScaleIOUser:password")))
+}
+
 type stubTypeWithMetaData struct{}
 
 func (s stubTypeWithMetaData) MetaData() http.Header {
@@ -375,13 +405,58 @@ func Test_addMetaData(t *testing.T) {
 	}
 }
 
-func Test_updateHeaders(_ *testing.T) {
+func Test_versionedMediaType(t *testing.T) {
+	m := newVersionedMediaType("3.5")
 	var wg sync.WaitGroup
 	for i := 0; i < 3; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			updateHeaders("3.5")
+			m.set("3.5")
+			_ = m.get()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, "application/json;version=3.5", m.get())
+
+	var unset *versionedMediaType
+	assert.Equal(t, "application/json", unset.get())
+}
+
+func TestClientHeadersAreIsolated(t *testing.T) {
+	newServer := func(version string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			want := "application/json;version=" + version
+			if got := r.Header.Get("Accept"); got != want {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, `{"message":"Accept %s, want %s","httpStatusCode":400,"errorCode":0}`, got, want)
+				return
+			}
+			w.Write([]byte(`[]`))
+		}))
+	}
+	ts36 := newServer("3.6")
+	defer ts36.Close()
+	ts45 := newServer("4.5")
+	defer ts45.Close()
+
+	c36, err := NewClientWithArgs(ts36.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	c45, err := NewClientWithArgs(ts45.URL, "4.5", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := c36.GetSystemsCtx(context.Background())
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := c45.GetSystemsCtx(context.Background())
+			assert.NoError(t, err)
 		}()
 	}
 	wg.Wait()
@@ -433,7 +508,7 @@ func TestGetJSONWithRetry(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		c.getJSONWithRetry(http.MethodPost, "/testing", wantBody, nil)
+		c.getJSONWithRetry(context.Background(), http.MethodPost, "/testing", wantBody, nil)
 
 		// Assert the call order was as expected.
 		wantPaths := []string{"POST /testing", "GET /api/login", "POST /testing"}
@@ -523,6 +598,68 @@ func TestWithFields(t *testing.T) {
 	}
 }
 
+func TestGetVersionReauthReleasesSlot(t *testing.T) {
+	var versions int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			w.Write([]byte(`"token"`))
+		case "/api/version":
+			// the first request and both requests of the third call are
+			// rejected
+			if n := atomic.AddInt32(&versions, 1); n == 1 || n == 4 || n == 5 {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"Unauthorized","httpStatusCode":401,"errorCode":0}`))
+				return
+			}
+			w.Write([]byte(`"3.6"`))
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithOptions(ts.URL, "3.6", api.ClientOptions{
+		Insecure: true,
+		Limits:   &api.Limits{Read: api.Limit{MaxInFlight: 1}},
+	})
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ver, err := client.GetVersionCtx(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.6", ver)
+	_, err = client.GetVersionCtx(ctx)
+	assert.NoError(t, err)
+
+	// a retried request rejected again is reported
+	_, err = client.GetVersionCtx(ctx)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	_, err = client.GetVersionCtx(ctx)
+	assert.NoError(t, err)
+}
+
+func TestNewClientDebugLogging(t *testing.T) {
+	defer func(enabled bool) { debug = enabled }(debug)
+	debug = true
+
+	client, err := NewClientWithOptions("/testing", "3.5", api.ClientOptions{})
+	assert.NoError(t, err)
+	assert.True(t, client.logger.Enabled(slog.LevelDebug))
+
+	// the other clients keep the default level
+	assert.False(t, log.NewLogger(nil).Enabled(slog.LevelDebug))
+	var buf bytes.Buffer
+	client, err = NewClientWithOptions("/testing", "3.5", api.ClientOptions{
+		Logger: slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})),
+	})
+	assert.NoError(t, err)
+	assert.False(t, client.logger.Enabled(slog.LevelDebug))
+}
+
 func TestNewClientWithArgs(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -633,7 +770,7 @@ func TestGetStringWithRetry(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			_, err = client.getStringWithRetry(http.MethodPost, tt.URL, nil)
+			_, err = client.getStringWithRetry(context.Background(), http.MethodPost, tt.URL, nil)
 		})
 	}
 }
@@ -686,6 +823,59 @@ func TestWithContext(t *testing.T) {
 	}
 }
 
+func TestCtxVariants(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/instances/Volume::slow":
+			time.Sleep(200 * time.Millisecond)
+			fallthrough
+		case "/api/instances/Volume::fast":
+			resp.WriteHeader(http.StatusOK)
+			resp.Write([]byte(`{"id":"vol"}`))
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithArgs(server.URL, "3.6", math.MaxInt64, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("deadline applies to its own call only", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		var slowErr, fastErr error
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, slowErr = client.GetVolumeCtx(ctx, "", "slow", "", "", false)
+		}()
+		go func() {
+			defer wg.Done()
+			_, fastErr = client.GetVolumeCtx(context.Background(), "", "fast", "", "", false)
+		}()
+		wg.Wait()
+
+		assert.ErrorIs(t, slowErr, context.DeadlineExceeded)
+		assert.NoError(t, fastErr)
+	})
+
+	t.Run("WithContext applies to the next call only", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.WithContext(ctx).GetVolume("", "fast", "", "", false)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		_, err = client.GetVolume("", "fast", "", "", false)
+		assert.NoError(t, err)
+	})
+}
+
 type failingReadCloser struct{}
 
 func (r *failingReadCloser) Read(_ []byte) (n int, err error) {
@@ -0,0 +1,165 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultBatchWorkers = 4
+)
+
+// BatchOptions controls how getters such as System.GetVolumesByIDs split
+// long ID lists into requests.
+type BatchOptions struct {
+	// Size is the largest number of IDs sent in one request. It defaults
+	// to 100.
+	Size int
+	// Workers is the largest number of requests sent at the same time. It
+	// defaults to 4.
+	Workers int
+}
+
+// SetBatchOptions sets how the client splits long ID lists into requests.
+// Set it before sending requests.
+func (c *Client) SetBatchOptions(opts BatchOptions) {
+	c.batch = opts
+}
+
+func (c *Client) batchOptions() BatchOptions {
+	opts := c.batch
+	if opts.Size <= 0 {
+		opts.Size = defaultBatchSize
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = defaultBatchWorkers
+	}
+	return opts
+}
+
+// queryBySelectedIDs returns the objects of the given REST type with the
+// given IDs, fetched with queryBySelectedIds in batches.
+func queryBySelectedIDs[T any](ctx context.Context, c *Client, typeName string, ids []string) ([]T, error) {
+	path := fmt.Sprintf("/api/types/%s/instances/action/queryBySelectedIds", typeName)
+	opts := c.batchOptions()
+	return inBatches(ctx, ids, opts.Size, opts.Workers, func(ctx context.Context, batch []string) ([]T, error) {
+		var items []T
+		err := c.getJSONWithRetry(ctx, http.MethodPost, path, map[string][]string{"ids": batch}, &items)
+		return items, err
+	})
+}
+
+// statisticsByIDs returns the given statistics of the objects of the given
+// REST type with the given IDs, by object ID, fetched with
+// querySelectedStatistics in batches. IDs the array returns no statistics for
+// are left out.
+func statisticsByIDs[T any](ctx context.Context, c *Client, typeName string, properties, ids []string) (map[string]*T, error) {
+	path := "/api/instances/querySelectedStatistics"
+	opts := c.batchOptions()
+	batches, err := inBatches(ctx, ids, opts.Size, opts.Workers, func(ctx context.Context, batch []string) ([]map[string]T, error) {
+		body := map[string]interface{}{
+			"selectedStatisticsList": []map[string]interface{}{{
+				"type":       typeName,
+				"ids":        batch,
+				"properties": properties,
+			}},
+		}
+		var byType map[string]map[string]T
+		if err := c.getJSONWithRetry(ctx, http.MethodPost, path, body, &byType); err != nil {
+			return nil, err
+		}
+		return []map[string]T{byType[typeName]}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*T, len(ids))
+	for _, batch := range batches {
+		for id, stats := range batch {
+			byID[id] = &stats
+		}
+	}
+	return byID, nil
+}
+
+// inBatches splits ids into batches of at most size IDs and calls fetch for
+// each of them, with at most workers calls running at once. The results are
+// returned in the order of the batches. After the first error, the batches
+// not yet started are skipped and the context of the running ones is
+// cancelled.
+func inBatches[T any](ctx context.Context, ids []string, size, workers int,
+	fetch func(ctx context.Context, batch []string) ([]T, error),
+) ([]T, error) {
+	var batches [][]string
+	for start := 0; start < len(ids); start += size {
+		batches = append(batches, ids[start:min(start+size, len(ids))])
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		results  = make([][]T, len(batches))
+		next     = make(chan int)
+	)
+	for w := 0; w < min(workers, len(batches)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				items, err := fetch(ctx, batches[i])
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				results[i] = items
+			}
+		}()
+	}
+
+feed:
+	for i := range batches {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var all []T
+	for _, items := range results {
+		all = append(all, items...)
+	}
+	return all, nil
+}
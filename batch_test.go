@@ -0,0 +1,173 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	types "github.com/dell/goscaleio/types/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInBatches(t *testing.T) {
+	ids := []string{"1", "2", "3", "4", "5", "6", "7"}
+
+	var running, peak int32
+	var mu sync.Mutex
+	var batches [][]string
+	items, err := inBatches(context.Background(), ids, 2, 3, func(_ context.Context, batch []string) ([]string, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		batches = append(batches, batch)
+		mu.Unlock()
+		return batch, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ids, items)
+	assert.Len(t, batches, 4)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+
+	items, err = inBatches(context.Background(), nil, 2, 3, func(_ context.Context, batch []string) ([]string, error) {
+		t.Error("unexpected call")
+		return batch, nil
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestInBatchesError(t *testing.T) {
+	ids := make([]string, 100)
+	var calls int32
+	_, err := inBatches(context.Background(), ids, 1, 2, func(ctx context.Context, _ []string) ([]string, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return nil, errors.New("failed")
+		}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	assert.EqualError(t, err, "failed")
+	assert.Less(t, atomic.LoadInt32(&calls), int32(100))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = inBatches(ctx, ids, 1, 2, func(_ context.Context, batch []string) ([]string, error) {
+		return batch, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGetVolumesByIDs(t *testing.T) {
+	var requests, statsRequests [][]string
+	var mu sync.Mutex
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/types/Volume/instances/action/queryBySelectedIds":
+			var body map[string][]string
+			json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			requests = append(requests, body["ids"])
+			mu.Unlock()
+
+			var vols []types.Volume
+			for _, id := range body["ids"] {
+				vols = append(vols, types.Volume{ID: id})
+			}
+			json.NewEncoder(w).Encode(vols)
+		case r.URL.Path == "/api/instances/querySelectedStatistics":
+			var body struct {
+				SelectedStatisticsList []struct {
+					Type       string   `json:"type"`
+					IDs        []string `json:"ids"`
+					Properties []string `json:"properties"`
+				} `json:"selectedStatisticsList"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			selected := body.SelectedStatisticsList[0]
+			assert.NotEmpty(t, selected.Properties)
+			mu.Lock()
+			statsRequests = append(statsRequests, selected.IDs)
+			mu.Unlock()
+
+			stats := map[string]map[string]int{}
+			for _, id := range selected.IDs {
+				if id == "bad" {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"message":"Invalid ID","httpStatusCode":400,"errorCode":0}`))
+					return
+				}
+				if id != "missing" {
+					stats[id] = map[string]int{"numOfMappedSdcs": len(id), "numOfMappedVolumes": len(id), "numOfDevices": len(id)}
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{selected.Type: stats})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	client.SetBatchOptions(BatchOptions{Size: 2, Workers: 2})
+	s := NewSystem(client)
+
+	vols, err := s.GetVolumesByIDs([]string{"v1", "v2", "v3", "v4", "v5"})
+	assert.NoError(t, err)
+	assert.Len(t, requests, 3)
+	var ids []string
+	for _, v := range vols {
+		ids = append(ids, v.ID)
+	}
+	assert.Equal(t, []string{"v1", "v2", "v3", "v4", "v5"}, ids)
+
+	// the statistics are fetched in batches too, and unknown IDs left out
+	stats, err := s.GetVolumeStatisticsByIDs([]string{"a", "bb", "missing"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, [][]string{{"a", "bb"}, {"missing"}}, statsRequests)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, 1, stats["a"].NumOfMappedSdcs)
+	assert.Equal(t, 2, stats["bb"].NumOfMappedSdcs)
+
+	sdcStats, err := s.GetSdcStatisticsByIDs([]string{"a", "bb"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sdcStats["bb"].NumOfMappedVolumes)
+
+	sdsStats, err := s.GetSdsStatisticsByIDs([]string{"ccc"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, sdsStats["ccc"].NumOfDevices)
+
+	poolStats, err := s.GetStoragePoolStatisticsByIDs([]string{"a"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, poolStats["a"].NumOfDevices)
+
+	_, err = s.GetVolumeStatisticsByIDs([]string{"a", "bad"})
+	assert.EqualError(t, err, "Invalid ID")
+}
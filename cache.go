@@ -0,0 +1,249 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Object types cached by a Client, as passed to Invalidate.
+const (
+	CacheSystem           = "System"
+	CacheProtectionDomain = "ProtectionDomain"
+	CacheStoragePool      = "StoragePool"
+	CacheFaultSet         = "FaultSet"
+	CacheSystemLimits     = "SystemLimits"
+)
+
+// CacheOptions sets how long a Client keeps the slowly-changing topology
+// objects it reads. Objects of a type whose TTL is zero are not cached.
+//
+// Cached objects are dropped when the same client sends a request that
+// changes an object of their type, so a client sees its own changes. The
+// changes made by other clients are seen once the TTL has passed, or after
+// Invalidate.
+type CacheOptions struct {
+	System           time.Duration
+	ProtectionDomain time.Duration
+	StoragePool      time.Duration
+	FaultSet         time.Duration
+	SystemLimits     time.Duration
+}
+
+func (o CacheOptions) ttl(objectType string) time.Duration {
+	switch objectType {
+	case CacheSystem:
+		return o.System
+	case CacheProtectionDomain:
+		return o.ProtectionDomain
+	case CacheStoragePool:
+		return o.StoragePool
+	case CacheFaultSet:
+		return o.FaultSet
+	case CacheSystemLimits:
+		return o.SystemLimits
+	}
+	return 0
+}
+
+// SetCacheOptions enables the cache of topology objects, or disables it when
+// every TTL is zero. Set it before sending requests.
+func (c *Client) SetCacheOptions(opts CacheOptions) {
+	if opts == (CacheOptions{}) {
+		c.cache = nil
+		return
+	}
+	c.cache = newTopologyCache(opts)
+}
+
+// Invalidate drops the cached objects of the given types, or every cached
+// object when no type is given.
+func (c *Client) Invalidate(objectTypes ...string) {
+	c.cache.invalidate(objectTypes...)
+}
+
+// topologyCache holds the responses to the reads of topology objects. Its
+// methods may be called on a nil topologyCache, which caches nothing.
+type topologyCache struct {
+	opts CacheOptions
+	now  func() time.Time
+
+	mu      sync.Mutex // guards the fields below
+	entries map[string]cacheEntry
+	// generations counts the invalidations of each type, so that a response
+	// read while an object of its type changed is not cached.
+	generations map[string]uint64
+}
+
+type cacheEntry struct {
+	objectType string
+	body       json.RawMessage
+	expires    time.Time
+}
+
+func newTopologyCache(opts CacheOptions) *topologyCache {
+	return &topologyCache{
+		opts:        opts,
+		now:         time.Now,
+		entries:     map[string]cacheEntry{},
+		generations: map[string]uint64{},
+	}
+}
+
+// getJSON decodes the response to the request into resp, from the cache when
+// the request reads a cached type, or by calling send otherwise. A request
+// that changes an object invalidates the cached objects of its type once it
+// is sent.
+func (tc *topologyCache) getJSON(method, uri string, body, resp interface{}, send func(resp interface{}) error) error {
+	if tc == nil {
+		return send(resp)
+	}
+
+	objectType, read := cachedTypeOf(method, uri)
+	if !read {
+		defer tc.written(method, uri)
+		return send(resp)
+	}
+	ttl := tc.opts.ttl(objectType)
+	if ttl <= 0 {
+		return send(resp)
+	}
+
+	key := method + " " + uri
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return send(resp)
+		}
+		key += " " + string(b)
+	}
+
+	tc.mu.Lock()
+	entry, ok := tc.entries[key]
+	generation := tc.generations[objectType]
+	tc.mu.Unlock()
+	if ok && tc.now().Before(entry.expires) {
+		return decodeCached(entry.body, resp)
+	}
+
+	var raw json.RawMessage
+	if err := send(&raw); err != nil {
+		return err
+	}
+
+	tc.mu.Lock()
+	if tc.generations[objectType] == generation {
+		tc.entries[key] = cacheEntry{objectType: objectType, body: raw, expires: tc.now().Add(ttl)}
+	}
+	tc.mu.Unlock()
+
+	return decodeCached(raw, resp)
+}
+
+// written invalidates the cached objects of the type changed by a request
+// once it is sent.
+func (tc *topologyCache) written(method, uri string) {
+	if tc == nil {
+		return
+	}
+	if objectType, read := cachedTypeOf(method, uri); !read && objectType != "" {
+		tc.invalidate(objectType)
+	}
+}
+
+func (tc *topologyCache) invalidate(objectTypes ...string) {
+	if tc == nil {
+		return
+	}
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if len(objectTypes) == 0 {
+		objectTypes = []string{CacheSystem, CacheProtectionDomain, CacheStoragePool, CacheFaultSet, CacheSystemLimits}
+	}
+	for _, objectType := range objectTypes {
+		tc.generations[objectType]++
+	}
+	for key, entry := range tc.entries {
+		for _, objectType := range objectTypes {
+			if entry.objectType == objectType {
+				delete(tc.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// decodeCached decodes a cached response into resp. Every caller gets its
+// own copy, so that changing it leaves the cache untouched.
+func decodeCached(body json.RawMessage, resp interface{}) error {
+	if resp == nil || len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, resp)
+}
+
+// cachedTypeOf returns the cached type read or changed by a request, and
+// whether the request reads it. It returns an empty type for the requests
+// that neither read nor change a cached type.
+func cachedTypeOf(method, uri string) (objectType string, read bool) {
+	path, _, _ := strings.Cut(uri, "?")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "api" {
+		return "", false
+	}
+
+	if method == http.MethodPost && segments[len(segments)-1] == "querySystemLimits" {
+		return CacheSystemLimits, true
+	}
+
+	switch segments[1] {
+	case "types":
+		// /api/types/{type}/instances[/action/{action}]
+		objectType = segments[2]
+		if method == http.MethodGet && len(segments) == 4 {
+			return cachedType(objectType), true
+		}
+	case "instances":
+		// /api/instances/{type}::{id}[/relationships/{type}|/action/{action}]
+		objectType, _, _ = strings.Cut(segments[2], "::")
+		if method == http.MethodGet {
+			switch {
+			case len(segments) == 3:
+				return cachedType(objectType), true
+			case len(segments) == 5 && segments[3] == "relationships":
+				return cachedType(segments[4]), true
+			}
+			return "", true
+		}
+	default:
+		return "", false
+	}
+
+	if method == http.MethodGet || strings.HasPrefix(segments[len(segments)-1], "query") {
+		return "", true
+	}
+	return cachedType(objectType), false
+}
+
+func cachedType(objectType string) string {
+	switch objectType {
+	case CacheSystem, CacheProtectionDomain, CacheStoragePool, CacheFaultSet:
+		return objectType
+	}
+	return ""
+}
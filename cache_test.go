@@ -0,0 +1,171 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	types "github.com/dell/goscaleio/types/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedTypeOf(t *testing.T) {
+	cases := []struct {
+		method, uri string
+		objectType  string
+		read        bool
+	}{
+		{http.MethodGet, "api/types/System/instances", CacheSystem, true},
+		{http.MethodGet, "/api/instances/System::1", CacheSystem, true},
+		{http.MethodGet, "/api/instances/System::1/relationships/ProtectionDomain", CacheProtectionDomain, true},
+		{http.MethodGet, "/api/instances/ProtectionDomain::1/relationships/StoragePool", CacheStoragePool, true},
+		{http.MethodGet, "/api/types/StoragePool/instances", CacheStoragePool, true},
+		{http.MethodGet, "/api/instances/FaultSet::1", CacheFaultSet, true},
+		{http.MethodPost, "/api/instances/System/action/querySystemLimits", CacheSystemLimits, true},
+		{http.MethodGet, "/api/instances/StoragePool::1/relationships/Statistics", "", true},
+		{http.MethodGet, "/api/types/Volume/instances", "", true},
+		{http.MethodPost, "/api/types/StoragePool/instances/action/queryBySelectedIds", "", true},
+		{http.MethodPost, "/api/types/StoragePool/instances", CacheStoragePool, false},
+		{http.MethodPost, "/api/instances/ProtectionDomain::1/action/setSdsNetworkLimits", CacheProtectionDomain, false},
+		{http.MethodPost, "/api/instances/System::1/action/addStandbyMdm", CacheSystem, false},
+		{http.MethodDelete, "/api/instances/FaultSet::1", CacheFaultSet, false},
+		{http.MethodPost, "/api/types/Volume/instances", "", false},
+		{http.MethodGet, "/rest/v1/volumes", "", false},
+	}
+	for _, c := range cases {
+		objectType, read := cachedTypeOf(c.method, c.uri)
+		assert.Equal(t, c.objectType, objectType, "%s %s", c.method, c.uri)
+		assert.Equal(t, c.read, read, "%s %s", c.method, c.uri)
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var reads, limits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			w.Write([]byte(`"token"`))
+		case "/api/types/StoragePool/instances":
+			if r.Method == http.MethodPost {
+				w.Write([]byte(`{"id":"sp2"}`))
+				return
+			}
+			atomic.AddInt32(&reads, 1)
+			w.Write([]byte(`[{"id":"sp1","name":"pool","protectionDomainId":"pd1"}]`))
+		case "/api/instances/System/action/querySystemLimits":
+			atomic.AddInt32(&limits, 1)
+			w.Write([]byte(`{"systemLimitEntryList":[{"type":"volumeSizeGb","maxVal":"1024"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "4.5", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+	assert.NoError(t, err)
+
+	now := time.Now()
+	client.SetCacheOptions(CacheOptions{StoragePool: time.Minute, SystemLimits: time.Hour})
+	client.cache.now = func() time.Time { return now }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pool, err := client.FindStoragePoolCtx(context.Background(), "", "pool", "", "pd1")
+			assert.NoError(t, err)
+			assert.Equal(t, "sp1", pool.ID)
+		}()
+	}
+	wg.Wait()
+	pool, err := client.FindStoragePool("sp1", "", "", "")
+	assert.NoError(t, err)
+	hits := atomic.LoadInt32(&reads)
+	assert.LessOrEqual(t, hits, int32(10))
+
+	// callers get their own copies
+	pool.Name = "changed"
+	pool, err = client.FindStoragePool("sp1", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "pool", pool.Name)
+	assert.Equal(t, hits, atomic.LoadInt32(&reads))
+
+	// a change made by the client invalidates the cached pools
+	_, err = NewProtectionDomainEx(client, &types.ProtectionDomain{ID: "pd1"}).CreateStoragePool(&types.StoragePoolParam{Name: "pool2"})
+	assert.NoError(t, err)
+	_, err = client.FindStoragePool("sp1", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, hits+1, atomic.LoadInt32(&reads))
+
+	// so does the end of the TTL
+	now = now.Add(time.Minute)
+	_, err = client.FindStoragePool("sp1", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, hits+2, atomic.LoadInt32(&reads))
+
+	// system limits have their own TTL
+	for i := 0; i < 3; i++ {
+		size, err := client.GetMaxVol()
+		assert.NoError(t, err)
+		assert.Equal(t, "1024", size)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&limits))
+
+	client.Invalidate(CacheSystemLimits)
+	_, err = client.GetMaxVol()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&limits))
+	_, err = client.FindStoragePool("sp1", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, hits+2, atomic.LoadInt32(&reads))
+
+	client.Invalidate()
+	_, err = client.FindStoragePool("sp1", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, hits+3, atomic.LoadInt32(&reads))
+
+	// errors are not cached, and a disabled cache sends every request
+	_, err = client.GetStoragePool("/api/instances/StoragePool::missing")
+	assert.Error(t, err)
+	client.SetCacheOptions(CacheOptions{})
+	_, err = client.FindStoragePool("sp1", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, hits+4, atomic.LoadInt32(&reads))
+	client.Invalidate()
+}
+
+func TestCacheSkipsStaleResponse(t *testing.T) {
+	tc := newTopologyCache(CacheOptions{StoragePool: time.Minute})
+	uri := "/api/types/StoragePool/instances"
+
+	// the pools change while they are read
+	var pools []types.StoragePool
+	err := tc.getJSON(http.MethodGet, uri, nil, &pools, func(resp interface{}) error {
+		tc.invalidate(CacheStoragePool)
+		return decodeCached([]byte(`[{"id":"sp1"}]`), resp)
+	})
+	assert.NoError(t, err)
+	assert.Len(t, pools, 1)
+	assert.Empty(t, tc.entries)
+}
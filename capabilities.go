@@ -0,0 +1,209 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dell/goscaleio/api"
+)
+
+// Feature is a feature of PowerFlex that only some versions support.
+type Feature int
+
+// Features whose support depends on the version of the array.
+const (
+	// FeatureSnapshotPolicies is the scheduling of snapshots by policies.
+	FeatureSnapshotPolicies Feature = iota + 1
+	// FeatureReplication is the replication of volumes to a peer system.
+	FeatureReplication
+	// FeatureNVMeTCP is the mapping of volumes to NVMe hosts over TCP.
+	FeatureNVMeTCP
+	// FeatureFileServices is the NAS servers, file systems and NFS exports.
+	FeatureFileServices
+	// FeatureManagementPlatform is the PowerFlex Manager platform: bearer
+	// token authentication and SSO users.
+	FeatureManagementPlatform
+)
+
+var features = map[Feature]struct {
+	name         string
+	major, minor int
+}{
+	FeatureSnapshotPolicies:   {"snapshot policies", 3, 5},
+	FeatureReplication:        {"replication", 3, 5},
+	FeatureNVMeTCP:            {"NVMe over TCP", 4, 0},
+	FeatureFileServices:       {"file services", 4, 0},
+	FeatureManagementPlatform: {"PowerFlex Manager platform", 4, 0},
+}
+
+func (f Feature) String() string {
+	if info, ok := features[f]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("Feature(%d)", int(f))
+}
+
+// Capabilities tells the features supported by a version of PowerFlex. The
+// zero value supports no feature.
+type Capabilities struct {
+	version      string
+	major, minor int
+}
+
+// NewCapabilities returns the capabilities of the given version of
+// PowerFlex, such as "4.5" or "3.6.700.103".
+func NewCapabilities(version string) (Capabilities, error) {
+	major, minor, err := api.ParseVersion(version)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	return Capabilities{version: version, major: major, minor: minor}, nil
+}
+
+// Version returns the version the capabilities were built from.
+func (c Capabilities) Version() string {
+	return c.version
+}
+
+// AtLeast reports whether the version is major.minor or later.
+func (c Capabilities) AtLeast(major, minor int) bool {
+	if c.version == "" {
+		return false
+	}
+	return c.major > major || (c.major == major && c.minor >= minor)
+}
+
+// Supports reports whether the version supports f.
+func (c Capabilities) Supports(f Feature) bool {
+	info, ok := features[f]
+	return ok && c.AtLeast(info.major, info.minor)
+}
+
+// require returns an error matching ErrVersionUnsupported when the version
+// does not support f.
+func (c Capabilities) require(f Feature) error {
+	if c.Supports(f) {
+		return nil
+	}
+	info := features[f]
+	return fmt.Errorf("%w: %s requires PowerFlex %d.%d or later, the array runs %s",
+		ErrVersionUnsupported, f, info.major, info.minor, c.version)
+}
+
+// Capabilities returns the capabilities of the array.
+func (c *Client) Capabilities() (Capabilities, error) {
+	return c.CapabilitiesCtx(c.callContext())
+}
+
+// CapabilitiesCtx returns the capabilities of the array. The version found
+// when logging in is used, and asked to the array otherwise.
+func (c *Client) CapabilitiesCtx(ctx context.Context) (Capabilities, error) {
+	ctx, span := c.startSpan(ctx, "Capabilities")
+	defer span.end()
+
+	version := c.version()
+	if version == "" {
+		var err error
+		if version, err = c.GetVersionCtx(ctx); err != nil {
+			return Capabilities{}, err
+		}
+	}
+	return NewCapabilities(version)
+}
+
+// require returns an error matching ErrVersionUnsupported when the array
+// does not support f. Nothing is checked while the version is unknown.
+func (c *Client) require(f Feature) error {
+	caps, err := NewCapabilities(c.version())
+	if err != nil {
+		return nil
+	}
+	return caps.require(f)
+}
+
+// requireFor checks that the array supports the feature uri belongs to, if
+// any, before a request is sent to it.
+func (c *Client) requireFor(uri string) error {
+	if f := featureOf(uri); f != 0 {
+		return c.require(f)
+	}
+	return nil
+}
+
+// Capabilities returns the capabilities of the gateway.
+func (gc *GatewayClient) Capabilities() (Capabilities, error) {
+	return gc.CapabilitiesCtx(context.Background())
+}
+
+// CapabilitiesCtx returns the capabilities of the gateway. The version found
+// when the client was created is used, and asked to the gateway otherwise.
+func (gc *GatewayClient) CapabilitiesCtx(ctx context.Context) (Capabilities, error) {
+	ctx, span := gc.startSpan(ctx, "Capabilities")
+	defer span.end()
+
+	version := gc.version
+	if version == "" {
+		var err error
+		if version, err = gc.GetVersionCtx(ctx); err != nil {
+			return Capabilities{}, err
+		}
+	}
+	return NewCapabilities(version)
+}
+
+// supports reports whether the gateway supports f. It is false while the
+// version is unknown.
+func (gc *GatewayClient) supports(f Feature) bool {
+	caps, _ := NewCapabilities(gc.version)
+	return caps.Supports(f)
+}
+
+// featureOf returns the feature the endpoint at uri belongs to, or zero for
+// the endpoints every version has.
+func featureOf(uri string) Feature {
+	path, _, _ := strings.Cut(uri, "?")
+	path = strings.Trim(path, "/")
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasPrefix(lower, "rest/v1/users"):
+		return FeatureManagementPlatform
+	case strings.HasPrefix(lower, "rest/v1/"):
+		return FeatureFileServices
+	}
+
+	// /api/types/{type}/..., /api/instances/{type}::{id}/... and
+	// .../relationships/{type}
+	segments := strings.Split(path, "/")
+	if len(segments) < 3 || segments[0] != "api" {
+		return 0
+	}
+	objectTypes := []string{segments[2]}
+	if i := len(segments) - 2; i > 2 && segments[i] == "relationships" {
+		objectTypes = append(objectTypes, segments[i+1])
+	}
+	for _, objectType := range objectTypes {
+		objectType, _, _ = strings.Cut(objectType, "::")
+		switch objectType {
+		case "SnapshotPolicy":
+			return FeatureSnapshotPolicies
+		case "ReplicationConsistencyGroup", "ReplicationPair", "PeerMdm":
+			return FeatureReplication
+		case "Host", "NvmeController":
+			return FeatureNVMeTCP
+		}
+	}
+	return 0
+}
@@ -0,0 +1,167 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	types "github.com/dell/goscaleio/types/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilities(t *testing.T) {
+	cases := []struct {
+		version  string
+		features []Feature
+	}{
+		{"3.0", nil},
+		{"3.5", []Feature{FeatureSnapshotPolicies, FeatureReplication}},
+		{"3.6.700.103", []Feature{FeatureSnapshotPolicies, FeatureReplication}},
+		{"4.0", []Feature{FeatureSnapshotPolicies, FeatureReplication, FeatureNVMeTCP, FeatureFileServices, FeatureManagementPlatform}},
+		{"4.5", []Feature{FeatureSnapshotPolicies, FeatureReplication, FeatureNVMeTCP, FeatureFileServices, FeatureManagementPlatform}},
+		{"10.0", []Feature{FeatureSnapshotPolicies, FeatureReplication, FeatureNVMeTCP, FeatureFileServices, FeatureManagementPlatform}},
+	}
+	all := []Feature{FeatureSnapshotPolicies, FeatureReplication, FeatureNVMeTCP, FeatureFileServices, FeatureManagementPlatform}
+	for _, c := range cases {
+		caps, err := NewCapabilities(c.version)
+		assert.NoError(t, err)
+		assert.Equal(t, c.version, caps.Version())
+		for _, f := range all {
+			assert.Equal(t, containsFeature(c.features, f), caps.Supports(f), "%s %s", c.version, f)
+		}
+	}
+
+	caps, _ := NewCapabilities("4.5")
+	assert.True(t, caps.AtLeast(4, 5))
+	assert.False(t, caps.AtLeast(4, 6))
+	assert.True(t, caps.AtLeast(3, 9))
+
+	_, err := NewCapabilities("")
+	assert.Error(t, err)
+	_, err = NewCapabilities("R4_5")
+	assert.Error(t, err)
+	assert.False(t, Capabilities{}.Supports(FeatureSnapshotPolicies))
+	assert.Equal(t, "Feature(0)", Feature(0).String())
+}
+
+func containsFeature(features []Feature, f Feature) bool {
+	for _, feature := range features {
+		if feature == f {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFeatureOf(t *testing.T) {
+	cases := []struct {
+		uri     string
+		feature Feature
+	}{
+		{"/api/types/SnapshotPolicy/instances", FeatureSnapshotPolicies},
+		{"/api/instances/SnapshotPolicy::1/action/renameSnapshotPolicy", FeatureSnapshotPolicies},
+		{"/api/instances/System::1/relationships/ReplicationConsistencyGroup", FeatureReplication},
+		{"/api/types/PeerMdm/instances", FeatureReplication},
+		{"/api/types/Host/instances", FeatureNVMeTCP},
+		{"api/instances/Host::1/relationships/NvmeController", FeatureNVMeTCP},
+		{"/rest/v1/file-systems?select=*", FeatureFileServices},
+		{"rest/v1/nas-servers", FeatureFileServices},
+		{"/rest/v1/users", FeatureManagementPlatform},
+		{"/api/types/Volume/instances", 0},
+		{"/api/instances/Sdc::1/action/removeSdc", 0},
+		{"/Api/V1/ManagedDevice", 0},
+		{"/api/version", 0},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.feature, featureOf(c.uri), c.uri)
+	}
+}
+
+func TestClientRequiresFeature(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/version" {
+			w.Write([]byte(`"3.6"`))
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	system := NewSystem(client)
+	system.System = &types.System{ID: "1"}
+
+	caps, err := client.Capabilities()
+	assert.NoError(t, err)
+	assert.Equal(t, "3.6", caps.Version())
+	assert.False(t, caps.Supports(FeatureFileServices))
+
+	// no request reaches the array
+	_, err = system.GetAllFileSystems()
+	assert.ErrorIs(t, err, ErrVersionUnsupported)
+	_, err = system.GetAllNvmeHosts()
+	assert.ErrorIs(t, err, ErrVersionUnsupported)
+	err = system.DeleteNvmeHost("1")
+	assert.ErrorIs(t, err, ErrVersionUnsupported)
+	_, err = system.CreateNvmeHost(types.NvmeHostParam{Name: "host"})
+	assert.ErrorIs(t, err, ErrVersionUnsupported)
+	_, err = client.CreateSSOUser(&types.SSOUserCreateParam{})
+	assert.ErrorContains(t, err, "PowerFlex Manager platform requires PowerFlex 4.0 or later, the array runs 3.6")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+
+	// the supported ones do
+	_, err = client.GetSnapshotPolicy("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// nothing is checked while the version is unknown
+	client.configConnect.Version = ""
+	_, err = system.GetAllNvmeHosts()
+	assert.NoError(t, err)
+	caps, err = client.Capabilities()
+	assert.NoError(t, err)
+	assert.Equal(t, "3.6", caps.Version())
+}
+
+func TestGatewayCapabilities(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`"4.5.0"`))
+	}))
+	defer ts.Close()
+
+	gc := &GatewayClient{http: ts.Client(), host: ts.URL}
+	caps, err := gc.Capabilities()
+	assert.NoError(t, err)
+	assert.Equal(t, "4.5", caps.Version())
+	assert.True(t, caps.Supports(FeatureManagementPlatform))
+
+	// 4.x gateways take the token whatever the minor version
+	gc.version = "4.5"
+	assert.True(t, gc.usesToken(gatewayRequest{auth: authByVersion}))
+	gc.version = "4.10"
+	assert.True(t, gc.usesToken(gatewayRequest{auth: authByVersion}))
+	gc.version = "4.0"
+	assert.True(t, gc.usesToken(gatewayRequest{auth: authByVersion}))
+	gc.version = "3.6"
+	assert.False(t, gc.usesToken(gatewayRequest{auth: authByVersion}))
+	gc.version = "3.5"
+	assert.False(t, gc.usesToken(gatewayRequest{auth: authByVersion}))
+	assert.True(t, gc.usesToken(gatewayRequest{auth: authToken}))
+}
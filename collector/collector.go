@@ -0,0 +1,424 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package collector exports the statistics of a PowerFlex system as
+// Prometheus metrics.
+//
+// Every scrape reads the statistics of the system, its protection domains,
+// storage pools, SDSs, SDCs and volumes with one querySelectedStatistics
+// request, and turns their BWC counters into IOPS, bandwidth and latency
+// gauges:
+//
+//	c, err := collector.New(client, system, collector.Options{
+//		Labels:     []string{collector.LabelID, collector.LabelName},
+//		MaxObjects: map[string]int{collector.Volume: 500},
+//	})
+//	prometheus.MustRegister(c)
+package collector
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/dell/goscaleio"
+	"github.com/dell/goscaleio/log"
+	types "github.com/dell/goscaleio/types/v1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Types of the objects the Collector scrapes.
+const (
+	System           = "System"
+	ProtectionDomain = "ProtectionDomain"
+	StoragePool      = "StoragePool"
+	Sds              = "Sds"
+	Sdc              = "Sdc"
+	Volume           = "Volume"
+)
+
+// Labels that identify an object.
+const (
+	LabelID   = "id"
+	LabelName = "name"
+)
+
+const (
+	namespace      = "powerflex"
+	defaultTimeout = 30 * time.Second
+)
+
+// Options configures a Collector.
+type Options struct {
+	// Objects are the types of the objects scraped; all of them when empty.
+	Objects []string
+
+	// Labels identify the object of a series, among LabelID and LabelName.
+	// LabelID is always added, as names need not be unique. LabelName costs
+	// one list request per type and scrape.
+	Labels []string
+
+	// ConstLabels are added to every series, e.g. to tell systems apart.
+	ConstLabels prometheus.Labels
+
+	// MaxObjects caps, by type, the number of objects with their own series.
+	// The objects with the most IOPS are kept, and the others counted by
+	// powerflex_collector_dropped_objects. Types not in the map are not
+	// capped.
+	MaxObjects map[string]int
+
+	// Timeout bounds a scrape; 30 seconds when zero.
+	Timeout time.Duration
+
+	// Logger receives the errors of the failed scrapes, as
+	// api.ClientOptions.Logger does the messages of a client.
+	Logger *slog.Logger
+}
+
+// gauge is a metric of every object of a type.
+type gauge struct {
+	name string
+	help string
+}
+
+// objectType describes how the statistics of a type are requested and
+// turned into gauges. The first two gauges of every type are its read and
+// write IOPS, which rank the objects when they are capped.
+type objectType struct {
+	name       string
+	prefix     string
+	properties []string
+	gauges     []gauge
+	values     func(*goscaleio.SelectedStatistics, *goscaleio.System) (map[string][]float64, error)
+	names      func(context.Context, *goscaleio.Client, *goscaleio.System) (map[string]string, error)
+}
+
+var ioGauges = []gauge{
+	{"read_iops", "Read operations per second."},
+	{"write_iops", "Write operations per second."},
+	{"read_bytes_per_second", "Bytes read per second."},
+	{"write_bytes_per_second", "Bytes written per second."},
+}
+
+var latencyGauges = []gauge{
+	{"read_latency_seconds", "Mean latency of the reads seen by the SDCs."},
+	{"write_latency_seconds", "Mean latency of the writes seen by the SDCs."},
+}
+
+var capacityGauges = []gauge{
+	{"capacity_max_bytes", "Total capacity."},
+	{"capacity_in_use_bytes", "Capacity in use."},
+	{"volumes", "Number of volumes."},
+}
+
+var (
+	userDataProperties = []string{"userDataReadBwc", "userDataWriteBwc", "userDataSdcReadLatency", "userDataSdcWriteLatency"}
+	capacityProperties = []string{"primaryReadBwc", "primaryWriteBwc", "maxCapacityInKb", "capacityInUseInKb", "numOfVolumes"}
+)
+
+var objectTypes = []objectType{
+	{
+		name: System, prefix: "system",
+		properties: capacityProperties,
+		gauges:     slices.Concat(ioGauges, capacityGauges),
+		values: func(stats *goscaleio.SelectedStatistics, system *goscaleio.System) (map[string][]float64, error) {
+			s, err := stats.System()
+			if err != nil {
+				return nil, err
+			}
+			return map[string][]float64{system.System.ID: capacityValues(*s)}, nil
+		},
+		names: func(_ context.Context, _ *goscaleio.Client, system *goscaleio.System) (map[string]string, error) {
+			return map[string]string{system.System.ID: system.System.Name}, nil
+		},
+	},
+	{
+		name: ProtectionDomain, prefix: "protection_domain",
+		properties: capacityProperties,
+		gauges:     slices.Concat(ioGauges, capacityGauges),
+		values:     statisticsValues(ProtectionDomain, capacityValues),
+		names: func(ctx context.Context, _ *goscaleio.Client, system *goscaleio.System) (map[string]string, error) {
+			pds, err := system.GetProtectionDomainCtx(ctx, "")
+			names := map[string]string{}
+			for _, pd := range pds {
+				names[pd.ID] = pd.Name
+			}
+			return names, err
+		},
+	},
+	{
+		name: StoragePool, prefix: "storage_pool",
+		properties: capacityProperties,
+		gauges:     slices.Concat(ioGauges, capacityGauges),
+		values:     statisticsValues(StoragePool, capacityValues),
+		names: func(ctx context.Context, _ *goscaleio.Client, system *goscaleio.System) (map[string]string, error) {
+			pools, err := system.GetAllStoragePoolsCtx(ctx)
+			names := map[string]string{}
+			for _, pool := range pools {
+				names[pool.ID] = pool.Name
+			}
+			return names, err
+		},
+	},
+	{
+		name: Sds, prefix: "sds",
+		properties: []string{"totalReadBwc", "totalWriteBwc"},
+		gauges:     ioGauges,
+		values: statisticsValues(Sds, func(s types.Statistics) []float64 {
+			return bwcValues(s.TotalReadBwc, s.TotalWriteBwc)
+		}),
+		names: func(ctx context.Context, _ *goscaleio.Client, system *goscaleio.System) (map[string]string, error) {
+			sdss, err := system.GetAllSdsCtx(ctx)
+			names := map[string]string{}
+			for _, sds := range sdss {
+				names[sds.ID] = sds.Name
+			}
+			return names, err
+		},
+	},
+	{
+		name: Sdc, prefix: "sdc",
+		properties: userDataProperties,
+		gauges:     slices.Concat(ioGauges, latencyGauges),
+		values: func(stats *goscaleio.SelectedStatistics, _ *goscaleio.System) (map[string][]float64, error) {
+			sdcs, err := stats.Sdcs()
+			values := map[string][]float64{}
+			for id, s := range sdcs {
+				values[id] = userDataValues(s.UserDataReadBwc, s.UserDataWriteBwc, s.UserDataSdcReadLatency, s.UserDataSdcWriteLatency)
+			}
+			return values, err
+		},
+		names: func(ctx context.Context, _ *goscaleio.Client, system *goscaleio.System) (map[string]string, error) {
+			sdcs, err := system.GetSdcCtx(ctx)
+			names := map[string]string{}
+			for _, sdc := range sdcs {
+				names[sdc.ID] = sdc.Name
+			}
+			return names, err
+		},
+	},
+	{
+		name: Volume, prefix: "volume",
+		properties: userDataProperties,
+		gauges:     slices.Concat(ioGauges, latencyGauges),
+		values: func(stats *goscaleio.SelectedStatistics, _ *goscaleio.System) (map[string][]float64, error) {
+			volumes, err := stats.Volumes()
+			values := map[string][]float64{}
+			for id, s := range volumes {
+				values[id] = userDataValues(s.UserDataReadBwc, s.UserDataWriteBwc, s.UserDataSdcReadLatency, s.UserDataSdcWriteLatency)
+			}
+			return values, err
+		},
+		names: func(ctx context.Context, client *goscaleio.Client, _ *goscaleio.System) (map[string]string, error) {
+			volumes, err := client.QueryVolumeCtx(ctx, goscaleio.Query().Fields("id", "name"))
+			names := map[string]string{}
+			for _, volume := range volumes {
+				names[volume.ID] = volume.Name
+			}
+			return names, err
+		},
+	},
+}
+
+// statisticsValues returns the values of the objects of a type whose
+// statistics decode to types.Statistics.
+func statisticsValues(objectType string, values func(types.Statistics) []float64) func(*goscaleio.SelectedStatistics, *goscaleio.System) (map[string][]float64, error) {
+	return func(stats *goscaleio.SelectedStatistics, _ *goscaleio.System) (map[string][]float64, error) {
+		byID := map[string]types.Statistics{}
+		if err := stats.Decode(objectType, &byID); err != nil {
+			return nil, err
+		}
+		result := map[string][]float64{}
+		for id, s := range byID {
+			result[id] = values(s)
+		}
+		return result, nil
+	}
+}
+
+func bwcValues(read, write types.BWC) []float64 {
+	return []float64{read.IOPS(), write.IOPS(), read.BytesPerSecond(), write.BytesPerSecond()}
+}
+
+func capacityValues(s types.Statistics) []float64 {
+	return append(bwcValues(s.PrimaryReadBwc, s.PrimaryWriteBwc),
+		float64(s.MaxCapacityInKb)*1024, float64(s.CapacityInUseInKb)*1024, float64(s.NumOfVolumes))
+}
+
+func userDataValues(read, write, readLatency, writeLatency types.BWC) []float64 {
+	return append(bwcValues(read, write),
+		readLatency.AverageLatency().Seconds(), writeLatency.AverageLatency().Seconds())
+}
+
+// Collector is a prometheus.Collector of the statistics of a system.
+type Collector struct {
+	client *goscaleio.Client
+	system *goscaleio.System
+	opts   Options
+	logger log.Logger
+
+	types    []objectType
+	descs    map[string][]*prometheus.Desc // by type, in the order of the gauges
+	up       *prometheus.Desc
+	duration *prometheus.Desc
+	dropped  *prometheus.Desc
+}
+
+// New returns a Collector of the statistics of system, read with client.
+func New(client *goscaleio.Client, system *goscaleio.System, opts Options) (*Collector, error) {
+	for _, label := range opts.Labels {
+		if label != LabelID && label != LabelName {
+			return nil, fmt.Errorf("unknown label %q", label)
+		}
+	}
+	if !slices.Contains(opts.Labels, LabelID) {
+		opts.Labels = append([]string{LabelID}, opts.Labels...)
+	}
+	for name, limit := range opts.MaxObjects {
+		if limit < 0 {
+			return nil, fmt.Errorf("negative MaxObjects %d for %s", limit, name)
+		}
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultTimeout
+	}
+
+	c := &Collector{
+		client: client,
+		system: system,
+		opts:   opts,
+		logger: log.NewLogger(opts.Logger),
+		descs:  map[string][]*prometheus.Desc{},
+		up: prometheus.NewDesc(namespace+"_up",
+			"Whether the last scrape of the statistics succeeded.", nil, opts.ConstLabels),
+		duration: prometheus.NewDesc(namespace+"_scrape_duration_seconds",
+			"Duration of the last scrape of the statistics.", nil, opts.ConstLabels),
+		dropped: prometheus.NewDesc(namespace+"_collector_dropped_objects",
+			"Number of objects left out by MaxObjects.", []string{"type"}, opts.ConstLabels),
+	}
+	for _, t := range objectTypes {
+		if len(opts.Objects) > 0 && !slices.Contains(opts.Objects, t.name) {
+			continue
+		}
+		c.types = append(c.types, t)
+		for _, g := range t.gauges {
+			c.descs[t.name] = append(c.descs[t.name], prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, t.prefix, g.name), g.help, opts.Labels, opts.ConstLabels))
+		}
+	}
+	for _, name := range opts.Objects {
+		if _, ok := c.descs[name]; !ok {
+			return nil, fmt.Errorf("unknown object type %q", name)
+		}
+	}
+	return c, nil
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.up
+	ch <- c.duration
+	ch <- c.dropped
+	for _, t := range c.types {
+		for _, desc := range c.descs[t.name] {
+			ch <- desc
+		}
+	}
+}
+
+// Collect implements prometheus.Collector. A failed scrape sets powerflex_up
+// to 0 and drops the metrics of the objects.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	start := time.Now()
+	metrics, err := c.collect()
+	for _, m := range metrics {
+		ch <- m
+	}
+	up := 1.0
+	if err != nil {
+		up = 0
+		c.logger.Error(fmt.Sprintf("scraping PowerFlex statistics: %v", err))
+	}
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, up)
+	ch <- prometheus.MustNewConstMetric(c.duration, prometheus.GaugeValue, time.Since(start).Seconds())
+}
+
+// collect returns the metrics of the objects, or none when a request fails.
+func (c *Collector) collect() ([]prometheus.Metric, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.Timeout)
+	defer cancel()
+
+	properties := map[string][]string{}
+	for _, t := range c.types {
+		properties[t.name] = t.properties
+	}
+	stats, err := c.system.QuerySelectedStatisticsCtx(ctx, nil, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics []prometheus.Metric
+	withNames := slices.Contains(c.opts.Labels, LabelName)
+	for _, t := range c.types {
+		values, err := t.values(stats, c.system)
+		if err != nil {
+			return nil, fmt.Errorf("%s statistics: %w", t.name, err)
+		}
+		var names map[string]string
+		if withNames {
+			if names, err = t.names(ctx, c.client, c.system); err != nil {
+				return nil, fmt.Errorf("%s names: %w", t.name, err)
+			}
+		}
+
+		ids := c.capped(t.name, values)
+		metrics = append(metrics, prometheus.MustNewConstMetric(c.dropped, prometheus.GaugeValue, float64(len(values)-len(ids)), t.name))
+		for _, id := range ids {
+			labels := make([]string, 0, len(c.opts.Labels))
+			for _, label := range c.opts.Labels {
+				if label == LabelID {
+					labels = append(labels, id)
+				} else {
+					labels = append(labels, names[id])
+				}
+			}
+			for i, desc := range c.descs[t.name] {
+				metrics = append(metrics, prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, values[id][i], labels...))
+			}
+		}
+	}
+	return metrics, nil
+}
+
+// capped returns the IDs of the objects that get their own series, the
+// busiest first.
+func (c *Collector) capped(objectType string, values map[string][]float64) []string {
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	iops := func(id string) float64 { return values[id][0] + values[id][1] }
+	slices.SortFunc(ids, func(a, b string) int {
+		if c := cmp.Compare(iops(b), iops(a)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+
+	if limit, ok := c.opts.MaxObjects[objectType]; ok && limit < len(ids) {
+		ids = ids[:limit]
+	}
+	return ids
+}
@@ -0,0 +1,223 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dell/goscaleio"
+	types "github.com/dell/goscaleio/types/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+const statistics = `{
+	"System":{
+		"primaryReadBwc":{"totalWeightInKb":40,"numOccured":10,"numSeconds":5},
+		"primaryWriteBwc":{"totalWeightInKb":0,"numOccured":0,"numSeconds":5},
+		"maxCapacityInKb":1048576,"capacityInUseInKb":524288,"numOfVolumes":2
+	},
+	"ProtectionDomain":{"pd1":{"maxCapacityInKb":1048576}},
+	"StoragePool":{"pool1":{"capacityInUseInKb":524288}},
+	"Sds":{"sds1":{"totalReadBwc":{"totalWeightInKb":40,"numOccured":10,"numSeconds":5}}},
+	"Sdc":{"sdc1":{"userDataWriteBwc":{"totalWeightInKb":8,"numOccured":2,"numSeconds":1}}},
+	"Volume":{
+		"v1":{
+			"userDataReadBwc":{"totalWeightInKb":20,"numOccured":10,"numSeconds":5},
+			"userDataSdcReadLatency":{"totalWeightInKb":5000,"numOccured":10,"numSeconds":5}
+		},
+		"v2":{"userDataReadBwc":{"totalWeightInKb":0,"numOccured":0,"numSeconds":5}}
+	}
+}`
+
+func newSystem(t *testing.T, handler http.HandlerFunc) (*goscaleio.Client, *goscaleio.System) {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	client, err := goscaleio.NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	system := goscaleio.NewSystem(client)
+	system.System = &types.System{
+		ID:   "sys1",
+		Name: "system",
+		Links: []*types.Link{{
+			Rel:  "/api/System/relationship/ProtectionDomain",
+			HREF: "/api/instances/System::sys1/relationships/ProtectionDomain",
+		}},
+	}
+	return client, system
+}
+
+func TestCollector(t *testing.T) {
+	client, system := newSystem(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/instances/querySelectedStatistics":
+			w.Write([]byte(statistics))
+		case "/api/instances/System::sys1/relationships/ProtectionDomain":
+			w.Write([]byte(`[{"id":"pd1","name":"pd"}]`))
+		case "/api/types/StoragePool/instances":
+			w.Write([]byte(`[{"id":"pool1","name":"pool"}]`))
+		case "/api/types/Sds/instances":
+			w.Write([]byte(`[{"id":"sds1","name":"sds"}]`))
+		case "/api/instances/System::sys1/relationships/Sdc":
+			w.Write([]byte(`[{"id":"sdc1","name":"sdc"}]`))
+		case "/api/types/Volume/instances":
+			w.Write([]byte(`[{"id":"v1","name":"data"},{"id":"v2","name":"logs"}]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	c, err := New(client, system, Options{
+		Labels:      []string{LabelID, LabelName},
+		ConstLabels: prometheus.Labels{"cluster": "east"},
+		MaxObjects:  map[string]int{Volume: 1},
+	})
+	assert.NoError(t, err)
+
+	expected := `
+# HELP powerflex_collector_dropped_objects Number of objects left out by MaxObjects.
+# TYPE powerflex_collector_dropped_objects gauge
+powerflex_collector_dropped_objects{cluster="east",type="ProtectionDomain"} 0
+powerflex_collector_dropped_objects{cluster="east",type="Sdc"} 0
+powerflex_collector_dropped_objects{cluster="east",type="Sds"} 0
+powerflex_collector_dropped_objects{cluster="east",type="StoragePool"} 0
+powerflex_collector_dropped_objects{cluster="east",type="System"} 0
+powerflex_collector_dropped_objects{cluster="east",type="Volume"} 1
+# HELP powerflex_protection_domain_capacity_max_bytes Total capacity.
+# TYPE powerflex_protection_domain_capacity_max_bytes gauge
+powerflex_protection_domain_capacity_max_bytes{cluster="east",id="pd1",name="pd"} 1.073741824e+09
+# HELP powerflex_sdc_write_bytes_per_second Bytes written per second.
+# TYPE powerflex_sdc_write_bytes_per_second gauge
+powerflex_sdc_write_bytes_per_second{cluster="east",id="sdc1",name="sdc"} 8192
+# HELP powerflex_sds_read_iops Read operations per second.
+# TYPE powerflex_sds_read_iops gauge
+powerflex_sds_read_iops{cluster="east",id="sds1",name="sds"} 2
+# HELP powerflex_storage_pool_capacity_in_use_bytes Capacity in use.
+# TYPE powerflex_storage_pool_capacity_in_use_bytes gauge
+powerflex_storage_pool_capacity_in_use_bytes{cluster="east",id="pool1",name="pool"} 5.36870912e+08
+# HELP powerflex_system_read_bytes_per_second Bytes read per second.
+# TYPE powerflex_system_read_bytes_per_second gauge
+powerflex_system_read_bytes_per_second{cluster="east",id="sys1",name="system"} 8192
+# HELP powerflex_system_volumes Number of volumes.
+# TYPE powerflex_system_volumes gauge
+powerflex_system_volumes{cluster="east",id="sys1",name="system"} 2
+# HELP powerflex_up Whether the last scrape of the statistics succeeded.
+# TYPE powerflex_up gauge
+powerflex_up{cluster="east"} 1
+# HELP powerflex_volume_read_iops Read operations per second.
+# TYPE powerflex_volume_read_iops gauge
+powerflex_volume_read_iops{cluster="east",id="v1",name="data"} 2
+# HELP powerflex_volume_read_latency_seconds Mean latency of the reads seen by the SDCs.
+# TYPE powerflex_volume_read_latency_seconds gauge
+powerflex_volume_read_latency_seconds{cluster="east",id="v1",name="data"} 0.0005
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"powerflex_collector_dropped_objects",
+		"powerflex_protection_domain_capacity_max_bytes",
+		"powerflex_sdc_write_bytes_per_second",
+		"powerflex_sds_read_iops",
+		"powerflex_storage_pool_capacity_in_use_bytes",
+		"powerflex_system_read_bytes_per_second",
+		"powerflex_system_volumes",
+		"powerflex_up",
+		"powerflex_volume_read_iops",
+		"powerflex_volume_read_latency_seconds"))
+	problems, err := testutil.CollectAndLint(c)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestCollectorObjects(t *testing.T) {
+	client, system := newSystem(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/instances/querySelectedStatistics", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"selectedStatisticsList":[{"type":"Volume","allIds":[],
+			"properties":["userDataReadBwc","userDataWriteBwc","userDataSdcReadLatency","userDataSdcWriteLatency"]}]}`,
+			string(body))
+		w.Write([]byte(statistics))
+	})
+
+	c, err := New(client, system, Options{Objects: []string{Volume}})
+	assert.NoError(t, err)
+
+	expected := `
+# HELP powerflex_volume_read_iops Read operations per second.
+# TYPE powerflex_volume_read_iops gauge
+powerflex_volume_read_iops{id="v1"} 2
+powerflex_volume_read_iops{id="v2"} 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected), "powerflex_volume_read_iops"))
+	// up, scrape duration, dropped volumes and 6 gauges of 2 volumes
+	assert.Equal(t, 15, testutil.CollectAndCount(c))
+}
+
+func TestCollectorErrors(t *testing.T) {
+	client, system := newSystem(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message":"internal error","httpStatusCode":500,"errorCode":0}`))
+	})
+
+	_, err := New(client, system, Options{Labels: []string{"ip"}})
+	assert.ErrorContains(t, err, `unknown label "ip"`)
+	_, err = New(client, system, Options{Objects: []string{"Device"}})
+	assert.ErrorContains(t, err, `unknown object type "Device"`)
+	_, err = New(client, system, Options{MaxObjects: map[string]int{Volume: -1}})
+	assert.ErrorContains(t, err, "negative MaxObjects -1 for Volume")
+
+	var logs bytes.Buffer
+	c, err := New(client, system, Options{Logger: slog.New(slog.NewTextHandler(&logs, nil))})
+	assert.NoError(t, err)
+	expected := `
+# HELP powerflex_up Whether the last scrape of the statistics succeeded.
+# TYPE powerflex_up gauge
+powerflex_up 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected), "powerflex_up"))
+	assert.Equal(t, 2, testutil.CollectAndCount(c))
+	assert.Contains(t, logs.String(), "scraping PowerFlex statistics: internal error")
+}
+
+func TestCollectorDuplicateNames(t *testing.T) {
+	client, system := newSystem(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/instances/querySelectedStatistics":
+			w.Write([]byte(statistics))
+		case "/api/types/Volume/instances":
+			w.Write([]byte(`[{"id":"v1","name":"data"},{"id":"v2","name":"data"}]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	// the ID keeps the series of volumes with the same name apart
+	c, err := New(client, system, Options{Objects: []string{Volume}, Labels: []string{LabelName}})
+	assert.NoError(t, err)
+
+	expected := `
+# HELP powerflex_volume_read_iops Read operations per second.
+# TYPE powerflex_volume_read_iops gauge
+powerflex_volume_read_iops{id="v1",name="data"} 2
+powerflex_volume_read_iops{id="v2",name="data"} 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected), "powerflex_volume_read_iops"))
+}
@@ -13,6 +13,7 @@
 package goscaleio
 
 import (
+	"context"
 	"net/http"
 
 	types "github.com/dell/goscaleio/types/v1"
@@ -20,9 +21,17 @@ import (
 
 // GetCompatibilityManagement Gets Compatibility Management
 func (s *System) GetCompatibilityManagement() (*types.CompatibilityManagement, error) {
+	return s.GetCompatibilityManagementCtx(s.client.callContext())
+}
+
+// GetCompatibilityManagementCtx Gets Compatibility Management
+func (s *System) GetCompatibilityManagementCtx(ctx context.Context) (*types.CompatibilityManagement, error) {
+	ctx, span := s.client.startSpan(ctx, "GetCompatibilityManagement")
+	defer span.end()
+
 	path := "/api/v1/Compatibility"
 	var compatibilityManagement types.CompatibilityManagement
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &compatibilityManagement)
 	if err != nil {
 		return nil, err
@@ -33,9 +42,17 @@ func (s *System) GetCompatibilityManagement() (*types.CompatibilityManagement, e
 
 // SetCompatibilityManagement Sets Compatibility Management
 func (s *System) SetCompatibilityManagement(compatibilityManagement *types.CompatibilityManagementPost) (*types.CompatibilityManagement, error) {
+	return s.SetCompatibilityManagementCtx(s.client.callContext(), compatibilityManagement)
+}
+
+// SetCompatibilityManagementCtx Sets Compatibility Management
+func (s *System) SetCompatibilityManagementCtx(ctx context.Context, compatibilityManagement *types.CompatibilityManagementPost) (*types.CompatibilityManagement, error) {
+	ctx, span := s.client.startSpan(ctx, "SetCompatibilityManagement")
+	defer span.end()
+
 	path := "/api/v1/Compatibility"
 	resp := types.CompatibilityManagement{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, compatibilityManagement, &resp)
 	if err != nil {
 		return nil, err
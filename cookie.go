@@ -0,0 +1,240 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// installationCookie is the cookie that pins installer requests to the
+// gateway pod that holds the uploaded packages.
+const installationCookie = "LEGACYGWCOOKIE"
+
+// CookieStore keeps the installation cookie (LEGACYGWCOOKIE) returned by a
+// gateway, so that the following requests reach the same gateway pod.
+type CookieStore interface {
+	// LoadCookie returns the cookie to send to host, or nil if there is none.
+	LoadCookie(host string) (*http.Cookie, error)
+	// StoreCookie saves the cookie returned by host.
+	StoreCookie(host string, cookie *http.Cookie) error
+}
+
+// MemoryCookieStore is a CookieStore that keeps the cookies in memory. It is
+// the default for new gateway clients.
+type MemoryCookieStore struct {
+	mu      sync.Mutex
+	cookies map[string]string // cookie value by host
+}
+
+// NewMemoryCookieStore returns an empty MemoryCookieStore.
+func NewMemoryCookieStore() *MemoryCookieStore {
+	return &MemoryCookieStore{cookies: make(map[string]string)}
+}
+
+// LoadCookie returns the cookie stored for host.
+func (s *MemoryCookieStore) LoadCookie(host string) (*http.Cookie, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.cookies[host]
+	if !ok {
+		return nil, nil
+	}
+	return &http.Cookie{Name: installationCookie, Value: value}, nil
+}
+
+// StoreCookie saves cookie for host, replacing any previous one. The
+// attributes of the cookie, such as its path, are not kept: the cookie is
+// sent with every request to host.
+func (s *MemoryCookieStore) StoreCookie(host string, cookie *http.Cookie) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cookies[host] = cookie.Value
+	return nil
+}
+
+// CookieConfig represents the YAML structure
+type CookieConfig struct {
+	Hosts []Host `yaml:"hosts"`
+}
+
+// Host represents individual hosts in the YAML structure
+type Host struct {
+	Name           string `yaml:"name"`
+	LegacyGWCookie string `yaml:"cookie"`
+}
+
+// FileCookieStore is a CookieStore that keeps the cookies in a YAML file, so
+// that they outlive the process.
+type FileCookieStore struct {
+	path string
+	mu   sync.Mutex // serializes access to the file within the process
+}
+
+// NewFileCookieStore returns a FileCookieStore that uses the file at path,
+// or ~/.cookie_config.yaml if path is empty.
+func NewFileCookieStore(path string) *FileCookieStore {
+	if path == "" {
+		path, _ = getConfigPath()
+	}
+	return &FileCookieStore{path: path}
+}
+
+// LoadCookie returns the cookie stored for host.
+func (s *FileCookieStore) LoadCookie(host string) (*http.Cookie, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range config.Hosts {
+		if h.Name == host {
+			return &http.Cookie{
+				Name:  installationCookie,
+				Value: strings.ReplaceAll(h.LegacyGWCookie, "_", "|"),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// StoreCookie saves cookie for host, replacing any previous one.
+func (s *FileCookieStore) StoreCookie(host string, cookie *http.Cookie) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	sanitizedCookie := strings.ReplaceAll(cookie.Value, "|", "_")
+
+	// Check if the host already exists, and update or add accordingly
+	found := false
+	for i, h := range config.Hosts {
+		if h.Name == host {
+			config.Hosts[i].LegacyGWCookie = sanitizedCookie
+			found = true
+			break
+		}
+	}
+
+	// If the host is not found, add a new host
+	if !found {
+		config.Hosts = append(config.Hosts, Host{Name: host, LegacyGWCookie: sanitizedCookie})
+	}
+
+	return s.write(config)
+}
+
+func (s *FileCookieStore) load() (*CookieConfig, error) {
+	data, err := os.ReadFile(filepath.Clean(s.path))
+	if os.IsNotExist(err) {
+		return &CookieConfig{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var config CookieConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// write replaces the file in one rename, so that another process never reads
+// a partially written file.
+func (s *FileCookieStore) write(config *CookieConfig) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// getConfigPath returns the path to the cookie configuration file in the user's home directory.
+func getConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "/home/.cookie_config.yaml", err
+	}
+
+	configPath := filepath.Join(homeDir, ".cookie_config.yaml")
+
+	return configPath, nil
+}
+
+// SetCookieStore sets where the gateway client keeps the installation
+// cookie. Set it before sending requests; a nil store disables the cookie.
+func (gc *GatewayClient) SetCookieStore(store CookieStore) {
+	gc.cookies = store
+}
+
+func (gc *GatewayClient) setCookie(header http.Header) error {
+	return setCookieFunc(gc.cookies, header, gc.host)
+}
+
+var setCookieFunc = func(store CookieStore, header http.Header, host string) error {
+	if store == nil {
+		return nil
+	}
+	cookie, err := store.LoadCookie(host)
+	if err != nil || cookie == nil {
+		return err
+	}
+	header.Set("Cookie", cookie.Name+"="+cookie.Value)
+	return nil
+}
+
+func (gc *GatewayClient) storeCookie(header http.Header) error {
+	return storeCookieFunc(gc.cookies, header, gc.host)
+}
+
+var storeCookieFunc = func(store CookieStore, header http.Header, host string) error {
+	if store == nil {
+		return nil
+	}
+	res := &http.Response{Header: header}
+	for _, c := range res.Cookies() {
+		if c.Name == installationCookie {
+			return store.StoreCookie(host, c)
+		}
+	}
+	return nil
+}
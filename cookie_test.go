@@ -0,0 +1,110 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCookieStores(t *testing.T) {
+	stores := map[string]CookieStore{
+		"memory": NewMemoryCookieStore(),
+		"file":   NewFileCookieStore(filepath.Join(t.TempDir(), "cookies.yaml")),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			c, err := store.LoadCookie("https://gw1")
+			assert.NoError(t, err)
+			assert.Nil(t, c)
+
+			assert.NoError(t, store.StoreCookie("https://gw1", &http.Cookie{Name: installationCookie, Value: "a|1"}))
+			assert.NoError(t, store.StoreCookie("https://gw2", &http.Cookie{Name: installationCookie, Value: "b|2"}))
+			assert.NoError(t, store.StoreCookie("https://gw1", &http.Cookie{Name: installationCookie, Value: "c|3"}))
+
+			c, err = store.LoadCookie("https://gw1")
+			assert.NoError(t, err)
+			assert.Equal(t, "c|3", c.Value)
+
+			c, err = store.LoadCookie("https://gw2")
+			assert.NoError(t, err)
+			assert.Equal(t, "b|2", c.Value)
+
+			// the attributes set by the gateway do not limit where the cookie
+			// is sent
+			assert.NoError(t, store.StoreCookie("http://gw3", &http.Cookie{Name: installationCookie, Value: "d|4", Path: "/im/types", Secure: true}))
+			c, err = store.LoadCookie("http://gw3")
+			assert.NoError(t, err)
+			if assert.NotNil(t, c) {
+				assert.Equal(t, installationCookie, c.Name)
+				assert.Equal(t, "d|4", c.Value)
+			}
+		})
+	}
+}
+
+func TestFileCookieStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies.yaml")
+	assert.NoError(t, NewFileCookieStore(path).StoreCookie("https://gw1", &http.Cookie{Name: installationCookie, Value: "a|1"}))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "hosts:\n    - name: https://gw1\n      cookie: a_1\n", string(data))
+
+	// a new store, as in another process, sees the stored cookie
+	c, err := NewFileCookieStore(path).LoadCookie("https://gw1")
+	assert.NoError(t, err)
+	assert.Equal(t, "a|1", c.Value)
+
+	assert.NoError(t, os.WriteFile(path, []byte("hosts: ["), 0o600))
+	_, err = NewFileCookieStore(path).LoadCookie("https://gw1")
+	assert.Error(t, err)
+
+	err = NewFileCookieStore(filepath.Join(path, "missing", "cookies.yaml")).StoreCookie("https://gw1", &http.Cookie{Name: installationCookie, Value: "a"})
+	assert.Error(t, err)
+}
+
+func TestGatewayInstallationCookie(t *testing.T) {
+	var sent []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Header.Get("Cookie"))
+		http.SetCookie(w, &http.Cookie{Name: installationCookie, Value: "pod|1", Path: "/"})
+		w.Write([]byte(`{"nodePoolDetails":[]}`))
+	}))
+	defer ts.Close()
+
+	store := NewMemoryCookieStore()
+	gc := &GatewayClient{
+		http:    ts.Client(),
+		host:    ts.URL,
+		version: "4.0",
+		token:   "token",
+	}
+	gc.SetCookieStore(store)
+
+	_, err := gc.GetAllNodePools()
+	assert.NoError(t, err)
+	_, err = gc.GetAllNodePools()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", installationCookie + "=pod|1"}, sent)
+
+	c, err := store.LoadCookie(ts.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "pod|1", c.Value)
+}
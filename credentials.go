@@ -0,0 +1,157 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials are the username and password used to log in.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// CredentialProvider supplies the credentials used to log in. It is asked
+// again on every login, including the ones that follow a 401, so that
+// rotated passwords are picked up without recreating the client.
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialFunc is a CredentialProvider backed by a function, such as a
+// lookup in a secret manager.
+type CredentialFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials calls f.
+func (f CredentialFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentials returns a CredentialProvider that always supplies the
+// given username and password.
+func StaticCredentials(username, password string) CredentialProvider {
+	return CredentialFunc(func(context.Context) (Credentials, error) {
+		return Credentials{Username: username, Password: password}, nil
+	})
+}
+
+// EnvCredentials returns a CredentialProvider that reads the username and
+// password from the given environment variables on every login.
+func EnvCredentials(usernameVar, passwordVar string) CredentialProvider {
+	return CredentialFunc(func(context.Context) (Credentials, error) {
+		username, ok := os.LookupEnv(usernameVar)
+		if !ok {
+			return Credentials{}, fmt.Errorf("environment variable %s is not set", usernameVar)
+		}
+		password, ok := os.LookupEnv(passwordVar)
+		if !ok {
+			return Credentials{}, fmt.Errorf("environment variable %s is not set", passwordVar)
+		}
+		return Credentials{Username: username, Password: password}, nil
+	})
+}
+
+// FileCredentials is a CredentialProvider that reads the username and
+// password from two files, such as the keys of a mounted Kubernetes secret.
+// The files are read again whenever they change; surrounding whitespace is
+// trimmed.
+type FileCredentials struct {
+	usernameFile string
+	passwordFile string
+
+	mu          sync.Mutex // guards the fields below
+	credentials Credentials
+	versions    [2]fileVersion
+}
+
+// fileVersion identifies the content of a file without reading it.
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewFileCredentials returns a FileCredentials reading the given files.
+func NewFileCredentials(usernameFile, passwordFile string) *FileCredentials {
+	return &FileCredentials{usernameFile: usernameFile, passwordFile: passwordFile}
+}
+
+// Credentials returns the content of the files, reading them again if they
+// changed since the last call.
+func (p *FileCredentials) Credentials(context.Context) (Credentials, error) {
+	var versions [2]fileVersion
+	for i, name := range []string{p.usernameFile, p.passwordFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return Credentials{}, err
+		}
+		versions[i] = fileVersion{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if versions == p.versions {
+		return p.credentials, nil
+	}
+
+	username, err := os.ReadFile(p.usernameFile)
+	if err != nil {
+		return Credentials{}, err
+	}
+	password, err := os.ReadFile(p.passwordFile)
+	if err != nil {
+		return Credentials{}, err
+	}
+	p.credentials = Credentials{
+		Username: strings.TrimSpace(string(username)),
+		Password: strings.TrimSpace(string(password)),
+	}
+	p.versions = versions
+	return p.credentials, nil
+}
+
+// loginCredentials returns the credentials to log in with: those of
+// cc.Credentials when set, and Username and Password otherwise.
+func (cc ConfigConnect) loginCredentials(ctx context.Context) (Credentials, error) {
+	if cc.Credentials == nil {
+		return Credentials{Username: cc.Username, Password: cc.Password}, nil
+	}
+	credentials, err := cc.Credentials.Credentials(ctx)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("unable to get credentials: %w", err)
+	}
+	return credentials, nil
+}
+
+// loginCredentials returns the credentials to log in with: those of the
+// provider when set, and the ones the client was created with otherwise.
+func (gc *GatewayClient) loginCredentials(ctx context.Context) (Credentials, error) {
+	if gc.credentials == nil {
+		return Credentials{Username: gc.username, Password: gc.password}, nil
+	}
+	credentials, err := gc.credentials.Credentials(ctx)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("unable to get credentials: %w", err)
+	}
+	return credentials, nil
+}
+
+// canLogin reports whether gc has credentials to log in again with.
+func (gc *GatewayClient) canLogin() bool {
+	return gc.credentials != nil || gc.username != ""
+}
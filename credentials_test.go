@@ -0,0 +1,194 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dell/goscaleio/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// rotatingArray accepts a single password at a time. Rotating it revokes the
+// tokens issued with the previous one.
+type rotatingArray struct {
+	mu       sync.Mutex
+	password string
+	token    string
+	logins   int
+}
+
+func (a *rotatingArray) rotate(password string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.password = password
+	a.token = ""
+}
+
+// login returns a new token if password is the current one.
+func (a *rotatingArray) login(password string) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if password != a.password {
+		return "", false
+	}
+	a.logins++
+	a.token = fmt.Sprintf("token-%d", a.logins)
+	return a.token, true
+}
+
+func (a *rotatingArray) valid(token string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return token != "" && token == a.token
+}
+
+// rotatingCredentials is a provider whose password changes with the array's.
+func rotatingCredentials(a *rotatingArray) CredentialProvider {
+	return CredentialFunc(func(context.Context) (Credentials, error) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return Credentials{Username: "admin", Password: a.password}, nil
+	})
+}
+
+func TestFileCredentials(t *testing.T) {
+	dir := t.TempDir()
+	usernameFile := filepath.Join(dir, "username")
+	passwordFile := filepath.Join(dir, "password")
+	assert.NoError(t, os.WriteFile(usernameFile, []byte("admin\n"), 0o600))
+	assert.NoError(t, os.WriteFile(passwordFile, []byte("first\n"), 0o600))
+
+	p := NewFileCredentials(usernameFile, passwordFile)
+	credentials, err := p.Credentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "admin", Password: "first"}, credentials)
+
+	assert.NoError(t, os.WriteFile(passwordFile, []byte("rotated\n"), 0o600))
+	credentials, err = p.Credentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "admin", Password: "rotated"}, credentials)
+
+	assert.NoError(t, os.Remove(passwordFile))
+	_, err = p.Credentials(context.Background())
+	assert.Error(t, err)
+}
+
+func TestEnvCredentials(t *testing.T) {
+	p := EnvCredentials("GOSCALEIO_TEST_USERNAME", "GOSCALEIO_TEST_PASSWORD")
+	t.Setenv("GOSCALEIO_TEST_USERNAME", "admin")
+	_, err := p.Credentials(context.Background())
+	assert.ErrorContains(t, err, "GOSCALEIO_TEST_PASSWORD is not set")
+
+	t.Setenv("GOSCALEIO_TEST_PASSWORD", "first")
+	credentials, err := p.Credentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "admin", Password: "first"}, credentials)
+
+	t.Setenv("GOSCALEIO_TEST_PASSWORD", "rotated")
+	credentials, err = p.Credentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated", credentials.Password)
+}
+
+func TestClientRotatedCredentials(t *testing.T) {
+	array := &rotatingArray{password: "first"}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			_, password, _ := r.BasicAuth()
+			token, ok := array.login(password)
+			if !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"Unauthorized","httpStatusCode":401,"errorCode":0}`))
+				return
+			}
+			fmt.Fprintf(w, `"%s"`, token)
+		case "/api/types/System/instances":
+			if _, token, _ := r.BasicAuth(); !array.valid(token) {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"Unauthorized","httpStatusCode":401,"errorCode":0}`))
+				return
+			}
+			w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{Credentials: rotatingCredentials(array)})
+	assert.NoError(t, err)
+
+	array.rotate("rotated")
+	_, err = client.GetSystems()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", client.GetToken())
+
+	// provider failures are returned
+	_, err = client.Authenticate(&ConfigConnect{Credentials: CredentialFunc(func(context.Context) (Credentials, error) {
+		return Credentials{}, fmt.Errorf("vault sealed")
+	})})
+	assert.ErrorContains(t, err, "unable to get credentials: vault sealed")
+}
+
+func TestGatewayRotatedCredentials(t *testing.T) {
+	array := &rotatingArray{password: "first"}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/auth/login":
+			var body struct{ Password string }
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			token, ok := array.login(body.Password)
+			if !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprintf(w, `{"access_token":"%s"}`, token)
+		case "/api/version":
+			w.Write([]byte("4.5"))
+		case "/Api/V1/ManagedDevice":
+			if !array.valid(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	gc, err := NewGatewayWithCredentials(context.Background(), ts.URL, rotatingCredentials(array), api.ClientOptions{})
+	assert.NoError(t, err)
+
+	array.rotate("rotated")
+	_, err = gc.GetAllNodes()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", gc.getToken())
+
+	_, err = NewGatewayWithCredentials(context.Background(), ts.URL, nil, api.ClientOptions{})
+	assert.Error(t, err)
+}
@@ -14,9 +14,7 @@ package goscaleio
 
 import (
 	"bytes"
-	"crypto/tls"
-	"crypto/x509"
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -25,96 +23,124 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"path/filepath"
 	path "path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/dell/goscaleio/api"
 	"github.com/dell/goscaleio/log"
 	types "github.com/dell/goscaleio/types/v1"
-	"gopkg.in/yaml.v3"
 )
 
 var (
-	errNewClient = errors.New("missing endpoint")
-	errSysCerts  = errors.New("Unable to initialize cert pool from system")
+	errNewClient          = errors.New("missing endpoint")
+	errMissingCredentials = errors.New("missing credential provider")
 )
 
 // GatewayClient is client for Gateway server
 type GatewayClient struct {
-	http     *http.Client
-	host     string
-	username string
-	password string
-	token    string
-	version  string
-	insecure bool
+	http        *http.Client
+	host        string
+	username    string
+	password    string
+	credentials CredentialProvider
+	tokenMu     sync.RWMutex // guards token and refresh
+	token       string
+	refresh     string
+	loginMu     sync.Mutex // serializes token refreshes
+	cookies     CookieStore
+	version     string
+	insecure    bool
+	showHTTP    bool
+	retryPolicy *api.RetryPolicy
+	telemetry   *api.Telemetry
+	logger      log.Logger
+	limiter     *api.Limiter
 }
 
 // NewGateway returns a new gateway client.
 func NewGateway(host string, username, password string, insecure, useCerts bool) (*GatewayClient, error) {
-	if host == "" {
-		return nil, errNewClient
-	}
+	return NewGatewayCtx(context.Background(), host, username, password, insecure, useCerts)
+}
 
-	gc := &GatewayClient{
-		http:     &http.Client{},
-		host:     host,
-		username: username,
-		password: password,
-		insecure: insecure,
-	}
+// NewGatewayCtx returns a new gateway client, using ctx for the login and
+// version requests made while it is being set up.
+func NewGatewayCtx(ctx context.Context, host string, username, password string, insecure, useCerts bool) (*GatewayClient, error) {
+	return NewGatewayWithOptions(ctx, host, username, password, api.ClientOptions{
+		Insecure: insecure,
+		UseCerts: useCerts,
+	})
+}
 
-	if insecure {
-		gc.http.Transport = &http.Transport{
-			// #nosec G402
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-				CipherSuites:       api.GetSecuredCipherSuites(),
-			},
-		}
+// NewGatewayWithOptions returns a new gateway client that connects with the
+// TLS, proxy and transport settings in opts, the same way the api client does.
+// Requests are also retried according to opts.RetryPolicy and logged when
+// opts.ShowHTTP is set.
+func NewGatewayWithOptions(ctx context.Context, host string, username, password string, opts api.ClientOptions) (*GatewayClient, error) {
+	return newGateway(ctx, host, opts, func(gc *GatewayClient) {
+		gc.username = username
+		gc.password = password
+	})
+}
+
+// NewGatewayWithCredentials returns a new gateway client like
+// NewGatewayWithOptions, asking credentials for the username and password
+// on every login.
+func NewGatewayWithCredentials(ctx context.Context, host string, credentials CredentialProvider, opts api.ClientOptions) (*GatewayClient, error) {
+	if credentials == nil {
+		return nil, errMissingCredentials
 	}
+	return newGateway(ctx, host, opts, func(gc *GatewayClient) {
+		gc.credentials = credentials
+	})
+}
 
-	if !insecure || useCerts {
-		pool, err := x509.SystemCertPool()
-		if err != nil {
-			return nil, errSysCerts
-		}
+func newGateway(ctx context.Context, host string, opts api.ClientOptions, setCredentials func(gc *GatewayClient)) (*GatewayClient, error) {
+	if host == "" {
+		return nil, errNewClient
+	}
 
-		gc.http.Transport = &http.Transport{
-			// #nosec G402
-			TLSClientConfig: &tls.Config{
-				RootCAs:            pool,
-				InsecureSkipVerify: insecure,
-				CipherSuites:       api.GetSecuredCipherSuites(),
-			},
-		}
+	transport, err := api.NewTransport(opts)
+	if err != nil {
+		return nil, err
 	}
 
+	gc := &GatewayClient{
+		http: &http.Client{
+			Transport: transport,
+			Timeout:   opts.Timeout,
+		},
+		host:        host,
+		insecure:    opts.Insecure,
+		showHTTP:    opts.ShowHTTP,
+		retryPolicy: opts.RetryPolicy,
+		telemetry:   api.NewTelemetry(opts),
+		logger:      log.NewLogger(opts.Logger),
+		limiter:     api.LimiterFor(host, opts.Limits),
+		cookies:     NewMemoryCookieStore(),
+	}
+	setCredentials(gc)
+
+	ctx, span := gc.startSpan(ctx, "NewGateway")
+	defer span.end()
+
 	// For versions greater than 3.5 we need the token in order to get the version.
-	token, err := gc.NewTokenGeneration()
-	if err == nil {
-		gc.token = token
-	}
+	_ = gc.login(ctx)
 
-	version, err := gc.GetVersion()
+	version, err := gc.GetVersionCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if version == "3.5" {
-		gc.version = version
-		// No need to create token
-	} else {
-		token, err := gc.NewTokenGeneration()
-		if err != nil {
+	gc.version = version
+	// Only the PowerFlex Manager platform issues tokens. The session opened
+	// above is kept, so that a single one is opened per client.
+	if gc.supports(FeatureManagementPlatform) && gc.getToken() == "" {
+		if err := gc.login(ctx); err != nil {
 			return nil, err
 		}
-
-		gc.token = token
-		gc.version = version
 	}
 
 	return gc, nil
@@ -122,93 +148,80 @@ func NewGateway(host string, username, password string, insecure, useCerts bool)
 
 // NewTokenGeneration return a new token when logged in
 func (gc *GatewayClient) NewTokenGeneration() (string, error) {
-	var token string
-	bodyData := map[string]interface{}{
-		"username": gc.username,
-		"password": gc.password,
-	}
-
-	body, _ := json.Marshal(bodyData)
+	return gc.NewTokenGenerationCtx(context.Background())
+}
 
-	req, err := http.NewRequest(http.MethodPost, gc.host+"/rest/auth/login", bytes.NewBuffer(body))
-	if err != nil {
-		return "", err
-	}
+// NewTokenGenerationCtx return a new token when logged in
+func (gc *GatewayClient) NewTokenGenerationCtx(ctx context.Context) (string, error) {
+	ctx, span := gc.startSpan(ctx, "NewTokenGeneration")
+	defer span.end()
 
-	req.Header.Add("Content-Type", "application/json")
+	session, err := gc.newSession(ctx)
+	return session.AccessToken, err
+}
 
-	resp, err := gc.http.Do(req)
+// newSession logs in to /rest/auth/login and returns the tokens of the new
+// session.
+func (gc *GatewayClient) newSession(ctx context.Context) (gatewaySession, error) {
+	credentials, err := gc.loginCredentials(ctx)
 	if err != nil {
-		return "", err
+		return gatewaySession{}, err
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"username": credentials.Username,
+		"password": credentials.Password,
+	})
+
+	res, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodPost,
+		path:   "/rest/auth/login",
+		body:   body,
+		auth:   authNone,
+	})
+	if err != nil {
+		return gatewaySession{}, err
 	}
 
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.DoLog(log.Log.Error, err.Error())
-		}
-	}()
-
 	// parse the response
-	switch {
-	case resp == nil:
-		return "", errNilReponse
-	case !(resp.StatusCode >= 200 && resp.StatusCode <= 299):
-		return "", ParseJSONError(resp)
-	}
-
-	bs, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
+	if !(res.StatusCode >= 200 && res.StatusCode <= 299) {
+		return gatewaySession{}, gatewayError(res, responseString)
 	}
 
-	responseBody := string(bs)
-
-	result := make(map[string]interface{})
-	jsonErr := json.Unmarshal([]byte(responseBody), &result)
-	if err != nil {
-		return "", fmt.Errorf("Error For Uploading Package: %s", jsonErr)
+	var session gatewaySession
+	if err := json.Unmarshal([]byte(responseString), &session); err != nil {
+		return gatewaySession{}, fmt.Errorf("Error For Uploading Package: %s", err)
 	}
 
-	if result["access_token"] == nil {
-		log.DoLog(log.Log.Info, "authentication defaulting to basic authentication.")
-		return "", nil
+	if session.AccessToken == "" {
+		gc.logger.Info("authentication defaulting to basic authentication.")
 	}
 
-	token = result["access_token"].(string)
-
-	return token, nil
+	return session, nil
 }
 
 // GetVersion returns version
 func (gc *GatewayClient) GetVersion() (string, error) {
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+"/api/version", nil)
-	if httpError != nil {
-		return "", httpError
-	}
-
-	if gc.token != "" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
+	return gc.GetVersionCtx(context.Background())
+}
 
-	client := gc.http
-	resp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return "", httpRespError
+// GetVersionCtx returns version
+func (gc *GatewayClient) GetVersionCtx(ctx context.Context) (string, error) {
+	ctx, span := gc.startSpan(ctx, "GetVersion")
+	defer span.end()
+
+	res, version, err := gc.do(ctx, gatewayRequest{
+		method:   http.MethodGet,
+		path:     "/api/version",
+		auth:     authToken,
+		noCookie: true,
+	})
+	if err != nil {
+		return "", err
 	}
 
 	// parse the response
-	switch {
-	case resp == nil:
-		return "", errNilReponse
-	case !(resp.StatusCode >= 200 && resp.StatusCode <= 299):
-		return "", fmt.Errorf("error response: %s", resp.Status)
-	}
-
-	version, err := extractString(resp)
-	if err != nil {
-		return "", err
+	if !(res.StatusCode >= 200 && res.StatusCode <= 299) {
+		return "", fmt.Errorf("error response: %s", res.Status)
 	}
 
 	versionRX := regexp.MustCompile(`^(\d+?\.\d+?).*$`)
@@ -220,6 +233,14 @@ func (gc *GatewayClient) GetVersion() (string, error) {
 
 // UploadPackages used for upload package to gateway server
 func (gc *GatewayClient) UploadPackages(filePaths []string) (*types.GatewayResponse, error) {
+	return gc.UploadPackagesCtx(context.Background(), filePaths)
+}
+
+// UploadPackagesCtx used for upload package to gateway server
+func (gc *GatewayClient) UploadPackagesCtx(ctx context.Context, filePaths []string) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "UploadPackages")
+	defer span.end()
+
 	var gatewayResponse types.GatewayResponse
 
 	body := &bytes.Buffer{}
@@ -260,54 +281,40 @@ func (gc *GatewayClient) UploadPackages(filePaths []string) (*types.GatewayRespo
 		return &gatewayResponse, fileWriterError
 	}
 
-	req, httpError := http.NewRequest(http.MethodPost, gc.host+"/im/types/installationPackages/instances/actions/uploadPackages", body)
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	client := gc.http
-	response, httpRespError := client.Do(req)
-
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
+	response, responseString, err := gc.do(ctx, gatewayRequest{
+		method:      http.MethodPost,
+		path:        "/im/types/installationPackages/instances/actions/uploadPackages",
+		body:        body.Bytes(),
+		contentType: writer.FormDataContentType(),
+	})
+	if err != nil {
+		return &gatewayResponse, err
 	}
 
 	if response.StatusCode != 200 {
-		responseString, _ := extractString(response)
-
 		err := json.Unmarshal([]byte(responseString), &gatewayResponse)
 		if err != nil {
 			return &gatewayResponse, fmt.Errorf("failed to parse response body: %v", err)
 		}
 
-		return &gatewayResponse, fmt.Errorf("received bad response: %s", gatewayResponse.Message)
+		return &gatewayResponse, fmt.Errorf("received bad response: %w", installerError(response, &gatewayResponse))
 	}
 
 	gatewayResponse.StatusCode = 200
 
-	// store cookie for successive deployment requests
-	if gc.version == "4.0" {
-		err := storeCookie(response.Header, gc.host)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error While Storing cookie: %s", err)
-		}
-	}
-
 	return &gatewayResponse, nil
 }
 
 // ParseCSV used for upload csv to gateway server and parse it
 func (gc *GatewayClient) ParseCSV(filePath string) (*types.GatewayResponse, error) {
+	return gc.ParseCSVCtx(context.Background(), filePath)
+}
+
+// ParseCSVCtx used for upload csv to gateway server and parse it
+func (gc *GatewayClient) ParseCSVCtx(ctx context.Context, filePath string) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "ParseCSV")
+	defer span.end()
+
 	var gatewayResponse types.GatewayResponse
 
 	file, filePathError := os.Open(path.Clean(filePath))
@@ -338,30 +345,16 @@ func (gc *GatewayClient) ParseCSV(filePath string) (*types.GatewayResponse, erro
 		return &gatewayResponse, fileWriterError
 	}
 
-	req, httpError := http.NewRequest(http.MethodPost, gc.host+"/im/types/Configuration/instances/actions/parseFromCSV", body)
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	client := gc.http
-	response, httpRespError := client.Do(req)
-
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
+	response, responseString, err := gc.do(ctx, gatewayRequest{
+		method:      http.MethodPost,
+		path:        "/im/types/Configuration/instances/actions/parseFromCSV",
+		body:        body.Bytes(),
+		contentType: writer.FormDataContentType(),
+	})
+	if err != nil {
+		return &gatewayResponse, err
 	}
 
-	responseString, _ := extractString(response)
-
 	if response.StatusCode == 200 {
 
 		var parseCSVData map[string]interface{}
@@ -385,57 +378,35 @@ func (gc *GatewayClient) ParseCSV(filePath string) (*types.GatewayResponse, erro
 
 	}
 
-	err := json.Unmarshal([]byte(responseString), &gatewayResponse)
+	err = json.Unmarshal([]byte(responseString), &gatewayResponse)
 	if err != nil {
 		return &gatewayResponse, fmt.Errorf("Error While Parsing Response Data For CSV: %s", err)
 	}
 
-	return &gatewayResponse, fmt.Errorf("Error For Parse CSV: %s", gatewayResponse.Message)
+	return &gatewayResponse, fmt.Errorf("Error For Parse CSV: %w", installerError(response, &gatewayResponse))
 }
 
 // GetPackageDetails used for get package details
 func (gc *GatewayClient) GetPackageDetails() ([]*types.PackageDetails, error) {
-	var packageParam []*types.PackageDetails
-
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+"/im/types/installationPackages/instances?onlyLatest=false&_search=false", nil)
-	if httpError != nil {
-		return packageParam, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
+	return gc.GetPackageDetailsCtx(context.Background())
+}
 
-	req.Header.Set("Content-Type", "application/json")
+// GetPackageDetailsCtx used for get package details
+func (gc *GatewayClient) GetPackageDetailsCtx(ctx context.Context) ([]*types.PackageDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetPackageDetails")
+	defer span.end()
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return packageParam, httpRespError
-	}
+	var packageParam []*types.PackageDetails
 
-	responseString, err := extractString(httpResp)
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   "/im/types/installationPackages/instances?onlyLatest=false&_search=false",
+	})
 	if err != nil {
-		return packageParam, fmt.Errorf("Error Extracting Response: %s", err)
+		return packageParam, err
 	}
 
 	if httpResp.StatusCode == 200 {
-
-		if gc.version == "4.0" {
-			err := storeCookie(httpResp.Header, gc.host)
-			if err != nil {
-				return packageParam, fmt.Errorf("Error While Storing cookie: %s", err)
-			}
-		}
-
 		err := json.Unmarshal([]byte(responseString), &packageParam)
 		if err != nil {
 			return packageParam, fmt.Errorf("Error For Get Package Details: %s", err)
@@ -449,33 +420,23 @@ func (gc *GatewayClient) GetPackageDetails() ([]*types.PackageDetails, error) {
 
 // ValidateMDMDetails used for validate mdm details
 func (gc *GatewayClient) ValidateMDMDetails(mdmTopologyParam []byte) (*types.GatewayResponse, error) {
-	var gatewayResponse types.GatewayResponse
-
-	req, httpError := http.NewRequest(http.MethodPost, gc.host+"/im/types/Configuration/instances", bytes.NewBuffer(mdmTopologyParam))
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
+	return gc.ValidateMDMDetailsCtx(context.Background(), mdmTopologyParam)
+}
 
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
+// ValidateMDMDetailsCtx used for validate mdm details
+func (gc *GatewayClient) ValidateMDMDetailsCtx(ctx context.Context, mdmTopologyParam []byte) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "ValidateMDMDetails")
+	defer span.end()
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
-	}
+	var gatewayResponse types.GatewayResponse
 
-	responseString, err := extractString(httpResp)
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodPost,
+		path:   "/im/types/Configuration/instances",
+		body:   mdmTopologyParam,
+	})
 	if err != nil {
-		return &gatewayResponse, fmt.Errorf("Error Extracting Response: %s", err)
+		return &gatewayResponse, err
 	}
 
 	if httpResp.StatusCode != 200 {
@@ -492,13 +453,6 @@ func (gc *GatewayClient) ValidateMDMDetails(mdmTopologyParam []byte) (*types.Gat
 		return &gatewayResponse, fmt.Errorf("Wrong Primary MDM IP, Please provide valid Primary MDM IP")
 	}
 
-	if gc.version == "4.0" {
-		err := storeCookie(httpResp.Header, gc.host)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error While Storing cookie: %s", err)
-		}
-	}
-
 	var mdmTopologyDetails types.MDMTopologyDetails
 
 	err = json.Unmarshal([]byte(responseString), &mdmTopologyDetails)
@@ -515,33 +469,23 @@ func (gc *GatewayClient) ValidateMDMDetails(mdmTopologyParam []byte) (*types.Gat
 
 // GetClusterDetails used for get MDM cluster details
 func (gc *GatewayClient) GetClusterDetails(mdmTopologyParam []byte, requireJSONOutput bool) (*types.GatewayResponse, error) {
-	var gatewayResponse types.GatewayResponse
-
-	req, httpError := http.NewRequest(http.MethodPost, gc.host+"/im/types/Configuration/instances", bytes.NewBuffer(mdmTopologyParam))
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
+	return gc.GetClusterDetailsCtx(context.Background(), mdmTopologyParam, requireJSONOutput)
+}
 
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
+// GetClusterDetailsCtx used for get MDM cluster details
+func (gc *GatewayClient) GetClusterDetailsCtx(ctx context.Context, mdmTopologyParam []byte, requireJSONOutput bool) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "GetClusterDetails")
+	defer span.end()
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
-	}
+	var gatewayResponse types.GatewayResponse
 
-	responseString, err := extractString(httpResp)
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodPost,
+		path:   "/im/types/Configuration/instances",
+		body:   mdmTopologyParam,
+	})
 	if err != nil {
-		return &gatewayResponse, fmt.Errorf("Error Extracting Response: %s", err)
+		return &gatewayResponse, err
 	}
 
 	if httpResp.StatusCode != 200 {
@@ -558,13 +502,6 @@ func (gc *GatewayClient) GetClusterDetails(mdmTopologyParam []byte, requireJSONO
 		return &gatewayResponse, fmt.Errorf("Error Getting Cluster Details")
 	}
 
-	if gc.version == "4.0" {
-		err := storeCookie(httpResp.Header, gc.host)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error While Storing cookie: %s", err)
-		}
-	}
-
 	if requireJSONOutput {
 		gatewayResponse.StatusCode = 200
 
@@ -589,59 +526,27 @@ func (gc *GatewayClient) GetClusterDetails(mdmTopologyParam []byte, requireJSONO
 
 // DeletePackage used for delete packages from gateway server
 func (gc *GatewayClient) DeletePackage(packageName string) (*types.GatewayResponse, error) {
-	var gatewayResponse types.GatewayResponse
-
-	req, httpError := http.NewRequest("DELETE", gc.host+"/im/types/installationPackages/instances/actions/delete::"+packageName, nil)
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
-	}
-
-	responseString, err := extractString(httpResp)
-	if err != nil {
-		return &gatewayResponse, fmt.Errorf("Error Extracting Response: %s", err)
-	}
-
-	if httpResp.StatusCode != 200 {
-
-		err := json.Unmarshal([]byte(responseString), &gatewayResponse)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error For Delete Package: %s", err)
-		}
-
-		return &gatewayResponse, nil
-	}
-
-	if gc.version == "4.0" {
-		err := storeCookie(httpResp.Header, gc.host)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error While Storing cookie: %s", err)
-		}
-	}
+	return gc.DeletePackageCtx(context.Background(), packageName)
+}
 
-	gatewayResponse.StatusCode = 200
+// DeletePackageCtx used for delete packages from gateway server
+func (gc *GatewayClient) DeletePackageCtx(ctx context.Context, packageName string) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "DeletePackage")
+	defer span.end()
 
-	return &gatewayResponse, nil
+	return gc.installerAction(ctx, http.MethodDelete, "/im/types/installationPackages/instances/actions/delete::"+packageName, "Delete Package")
 }
 
 // BeginInstallation used for start installation
 func (gc *GatewayClient) BeginInstallation(jsonStr, mdmUsername, mdmPassword, liaPassword string, allowNonSecureCommunicationWithMdm, allowNonSecureCommunicationWithLia, disableNonMgmtComponentsAuth, expansion bool) (*types.GatewayResponse, error) {
+	return gc.BeginInstallationCtx(context.Background(), jsonStr, mdmUsername, mdmPassword, liaPassword, allowNonSecureCommunicationWithMdm, allowNonSecureCommunicationWithLia, disableNonMgmtComponentsAuth, expansion)
+}
+
+// BeginInstallationCtx used for start installation
+func (gc *GatewayClient) BeginInstallationCtx(ctx context.Context, jsonStr, mdmUsername, mdmPassword, liaPassword string, allowNonSecureCommunicationWithMdm, allowNonSecureCommunicationWithLia, disableNonMgmtComponentsAuth, expansion bool) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "BeginInstallation")
+	defer span.end()
+
 	var gatewayResponse types.GatewayResponse
 
 	mapData, jsonParseError := jsonToMap(jsonStr)
@@ -663,10 +568,10 @@ func (gc *GatewayClient) BeginInstallation(jsonStr, mdmUsername, mdmPassword, li
 
 	finalJSON, _ := json.Marshal(mapData)
 
-	u, _ := url.Parse(gc.host + "/im/types/Configuration/actions/install")
+	u, _ := url.Parse("/im/types/Configuration/actions/install")
 	q := u.Query()
 
-	if gc.version == "4.0" && !expansion {
+	if gc.supports(FeatureManagementPlatform) && !expansion {
 		q.Set("noSecurityBootstrap", "false")
 	} else {
 		q.Set("noUpload", "false")
@@ -682,36 +587,16 @@ func (gc *GatewayClient) BeginInstallation(jsonStr, mdmUsername, mdmPassword, li
 
 	u.RawQuery = q.Encode()
 
-	req, httpError := http.NewRequest(http.MethodPost, u.String(), bytes.NewBuffer(finalJSON))
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodPost,
+		path:   u.String(),
+		body:   finalJSON,
+	})
+	if err != nil {
+		return &gatewayResponse, err
 	}
 
 	if httpResp.StatusCode != 202 {
-
-		responseString, err := extractString(httpResp)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error Extracting Response: %s", err)
-		}
-
 		err = json.Unmarshal([]byte(responseString), &gatewayResponse)
 		if err != nil {
 			return &gatewayResponse, fmt.Errorf("Error For Begin Installation: %s", err)
@@ -727,299 +612,92 @@ func (gc *GatewayClient) BeginInstallation(jsonStr, mdmUsername, mdmPassword, li
 
 // MoveToNextPhase used for move to next phases in installation
 func (gc *GatewayClient) MoveToNextPhase() (*types.GatewayResponse, error) {
-	var gatewayResponse types.GatewayResponse
-
-	req, httpError := http.NewRequest(http.MethodPost, gc.host+"/im/types/ProcessPhase/actions/moveToNextPhase", nil)
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
-	}
-
-	responseString, err := extractString(httpResp)
-	if err != nil {
-		return &gatewayResponse, fmt.Errorf("Error Extracting Response: %s", err)
-	}
-
-	if httpResp.StatusCode != 200 {
-
-		err := json.Unmarshal([]byte(responseString), &gatewayResponse)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error For Move To Next Phase: %s", err)
-		}
-
-		return &gatewayResponse, nil
-	}
-
-	if gc.version == "4.0" {
-		err := storeCookie(httpResp.Header, gc.host)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error While Storing cookie: %s", err)
-		}
-	}
+	return gc.MoveToNextPhaseCtx(context.Background())
+}
 
-	gatewayResponse.StatusCode = 200
+// MoveToNextPhaseCtx used for move to next phases in installation
+func (gc *GatewayClient) MoveToNextPhaseCtx(ctx context.Context) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "MoveToNextPhase")
+	defer span.end()
 
-	return &gatewayResponse, nil
+	return gc.installerAction(ctx, http.MethodPost, "/im/types/ProcessPhase/actions/moveToNextPhase", "Move To Next Phase")
 }
 
 // RetryPhase used for re run to failed phases in installation
 func (gc *GatewayClient) RetryPhase() (*types.GatewayResponse, error) {
-	var gatewayResponse types.GatewayResponse
-
-	req, httpError := http.NewRequest(http.MethodPost, gc.host+"/im/types/Command/instances/actions/retry/", nil)
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
-	}
-
-	responseString, err := extractString(httpResp)
-	if err != nil {
-		return &gatewayResponse, fmt.Errorf("Error Extracting Response: %s", err)
-	}
-
-	if httpResp.StatusCode != 200 {
-
-		err := json.Unmarshal([]byte(responseString), &gatewayResponse)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error For Retry Phase: %s", err)
-		}
-
-		return &gatewayResponse, nil
-	}
-
-	if gc.version == "4.0" {
-		err := storeCookie(httpResp.Header, gc.host)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error While Storing cookie: %s", err)
-		}
-	}
+	return gc.RetryPhaseCtx(context.Background())
+}
 
-	gatewayResponse.StatusCode = 200
+// RetryPhaseCtx used for re run to failed phases in installation
+func (gc *GatewayClient) RetryPhaseCtx(ctx context.Context) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "RetryPhase")
+	defer span.end()
 
-	return &gatewayResponse, nil
+	return gc.installerAction(ctx, http.MethodPost, "/im/types/Command/instances/actions/retry/", "Retry Phase")
 }
 
 // AbortOperation used for abort installation operation
 func (gc *GatewayClient) AbortOperation() (*types.GatewayResponse, error) {
-	var gatewayResponse types.GatewayResponse
-
-	req, httpError := http.NewRequest(http.MethodPost, gc.host+"/im/types/Command/instances/actions/abort", nil)
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
-	}
-
-	responseString, err := extractString(httpResp)
-	if err != nil {
-		return &gatewayResponse, fmt.Errorf("Error Extracting Response: %s", err)
-	}
-
-	if httpResp.StatusCode != 200 {
-
-		err := json.Unmarshal([]byte(responseString), &gatewayResponse)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error For Abort Operation: %s", err)
-		}
-
-		return &gatewayResponse, nil
-	}
-
-	if gc.version == "4.0" {
-		err := storeCookie(httpResp.Header, gc.host)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error While Storing cookie: %s", err)
-		}
-	}
+	return gc.AbortOperationCtx(context.Background())
+}
 
-	gatewayResponse.StatusCode = 200
+// AbortOperationCtx used for abort installation operation
+func (gc *GatewayClient) AbortOperationCtx(ctx context.Context) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "AbortOperation")
+	defer span.end()
 
-	return &gatewayResponse, nil
+	return gc.installerAction(ctx, http.MethodPost, "/im/types/Command/instances/actions/abort", "Abort Operation")
 }
 
 // ClearQueueCommand used for clear all commands in queue
 func (gc *GatewayClient) ClearQueueCommand() (*types.GatewayResponse, error) {
-	var gatewayResponse types.GatewayResponse
-
-	req, httpError := http.NewRequest(http.MethodPost, gc.host+"/im/types/Command/instances/actions/clear", nil)
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
-	}
-
-	responseString, err := extractString(httpResp)
-	if err != nil {
-		return &gatewayResponse, fmt.Errorf("Error Extracting Response: %s", err)
-	}
-
-	if httpResp.StatusCode != 200 {
-
-		err := json.Unmarshal([]byte(responseString), &gatewayResponse)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error For Clear Queue Commands: %s", err)
-		}
-
-		return &gatewayResponse, nil
-	}
-
-	if gc.version == "4.0" {
-		err := storeCookie(httpResp.Header, gc.host)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error While Storing cookie: %s", err)
-		}
-	}
+	return gc.ClearQueueCommandCtx(context.Background())
+}
 
-	gatewayResponse.StatusCode = 200
+// ClearQueueCommandCtx used for clear all commands in queue
+func (gc *GatewayClient) ClearQueueCommandCtx(ctx context.Context) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "ClearQueueCommand")
+	defer span.end()
 
-	return &gatewayResponse, nil
+	return gc.installerAction(ctx, http.MethodPost, "/im/types/Command/instances/actions/clear", "Clear Queue Commands")
 }
 
 // MoveToIdlePhase used for move gateway installer to idle state
 func (gc *GatewayClient) MoveToIdlePhase() (*types.GatewayResponse, error) {
-	var gatewayResponse types.GatewayResponse
-
-	req, httpError := http.NewRequest(http.MethodPost, gc.host+"/im/types/ProcessPhase/actions/moveToIdlePhase", nil)
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
-	}
-
-	responseString, err := extractString(httpResp)
-	if err != nil {
-		return &gatewayResponse, fmt.Errorf("Error Extracting Response: %s", err)
-	}
-
-	if httpResp.StatusCode != 200 {
-
-		err := json.Unmarshal([]byte(responseString), &gatewayResponse)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error For Move To Ideal Phase: %s", err)
-		}
-
-		return &gatewayResponse, nil
-	}
+	return gc.MoveToIdlePhaseCtx(context.Background())
+}
 
-	if gc.version == "4.0" {
-		err := storeCookie(httpResp.Header, gc.host)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error While Storing cookie: %s", err)
-		}
-	}
+// MoveToIdlePhaseCtx used for move gateway installer to idle state
+func (gc *GatewayClient) MoveToIdlePhaseCtx(ctx context.Context) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "MoveToIdlePhase")
+	defer span.end()
 
-	gatewayResponse.StatusCode = 200
-
-	return &gatewayResponse, nil
+	return gc.installerAction(ctx, http.MethodPost, "/im/types/ProcessPhase/actions/moveToIdlePhase", "Move To Ideal Phase")
 }
 
 // RenewInstallationCookie is used to renew the installation cookie, i.e. LEGACYGWCOOKIE.
 // Using the same LEGACYGWCOOKIE ensures that the REST requests are sent to the same GW pod.
 // That would help to get the correct response from the GW pod that stores installation packages.
 func (gc *GatewayClient) RenewInstallationCookie(retryCount int) error {
-	var packageParam []*types.PackageDetails
+	return gc.RenewInstallationCookieCtx(context.Background(), retryCount)
+}
 
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+"/im/types/installationPackages/instances?onlyLatest=false&_search=false", nil)
-	if httpError != nil {
-		return httpError
-	}
+// RenewInstallationCookieCtx is used to renew the installation cookie, i.e. LEGACYGWCOOKIE.
+// Using the same LEGACYGWCOOKIE ensures that the REST requests are sent to the same GW pod.
+// That would help to get the correct response from the GW pod that stores installation packages.
+func (gc *GatewayClient) RenewInstallationCookieCtx(ctx context.Context, retryCount int) error {
+	ctx, span := gc.startSpan(ctx, "RenewInstallationCookie")
+	defer span.end()
 
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
+	var packageParam []*types.PackageDetails
 
 	for i := 0; i < retryCount; i++ {
-		httpResp, httpRespError := gc.http.Do(req)
-		if httpRespError != nil {
-			continue
-		}
-
-		responseString, err := extractString(httpResp)
+		// the cookie is left out so that the gateway hands out a new one
+		httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+			method:   http.MethodGet,
+			path:     "/im/types/installationPackages/instances?onlyLatest=false&_search=false",
+			noCookie: true,
+		})
 		if err != nil {
 			continue
 		}
@@ -1027,7 +705,7 @@ func (gc *GatewayClient) RenewInstallationCookie(retryCount int) error {
 		if httpResp.StatusCode == 200 {
 			err := json.Unmarshal([]byte(responseString), &packageParam)
 			// No packages found. Retry to find the cookie that can return packages info
-			if err != nil || len(packageParam) == 0 || storeCookie(httpResp.Header, gc.host) != nil {
+			if err != nil || len(packageParam) == 0 || gc.storeCookie(httpResp.Header) != nil {
 				continue
 			}
 			return nil
@@ -1038,44 +716,26 @@ func (gc *GatewayClient) RenewInstallationCookie(retryCount int) error {
 
 // GetInQueueCommand used for get in queue commands
 func (gc *GatewayClient) GetInQueueCommand() ([]types.MDMQueueCommandDetails, error) {
-	var mdmQueueCommandDetails []types.MDMQueueCommandDetails
-
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+"/im/types/Command/instances", nil)
-	if httpError != nil {
-		return mdmQueueCommandDetails, httpError
-	}
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
+	return gc.GetInQueueCommandCtx(context.Background())
+}
 
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
+// GetInQueueCommandCtx used for get in queue commands
+func (gc *GatewayClient) GetInQueueCommandCtx(ctx context.Context) ([]types.MDMQueueCommandDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetInQueueCommand")
+	defer span.end()
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return mdmQueueCommandDetails, httpRespError
-	}
+	var mdmQueueCommandDetails []types.MDMQueueCommandDetails
 
-	responseString, err := extractString(httpResp)
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   "/im/types/Command/instances",
+	})
 	if err != nil {
-		return mdmQueueCommandDetails, fmt.Errorf("Error Extracting Response: %s", err)
+		return mdmQueueCommandDetails, err
 	}
 
 	if httpResp.StatusCode == 200 {
 
-		if gc.version == "4.0" {
-			err := storeCookie(httpResp.Header, gc.host)
-			if err != nil {
-				return mdmQueueCommandDetails, fmt.Errorf("Error While Storing cookie: %s", err)
-			}
-		}
-
 		var queueCommandDetails map[string][]interface{}
 
 		err := json.Unmarshal([]byte(responseString), &queueCommandDetails)
@@ -1104,9 +764,17 @@ func (gc *GatewayClient) GetInQueueCommand() ([]types.MDMQueueCommandDetails, er
 
 // CheckForCompletionQueueCommands used for check queue commands completed or not
 func (gc *GatewayClient) CheckForCompletionQueueCommands(currentPhase string) (*types.GatewayResponse, error) {
+	return gc.CheckForCompletionQueueCommandsCtx(context.Background(), currentPhase)
+}
+
+// CheckForCompletionQueueCommandsCtx used for check queue commands completed or not
+func (gc *GatewayClient) CheckForCompletionQueueCommandsCtx(ctx context.Context, currentPhase string) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "CheckForCompletionQueueCommands")
+	defer span.end()
+
 	var gatewayResponse types.GatewayResponse
 
-	mdmQueueCommandDetails, err := gc.GetInQueueCommand()
+	mdmQueueCommandDetails, err := gc.GetInQueueCommandCtx(ctx)
 	if err != nil {
 		return &gatewayResponse, err
 	}
@@ -1138,6 +806,14 @@ func (gc *GatewayClient) CheckForCompletionQueueCommands(currentPhase string) (*
 
 // UninstallCluster used for uninstallation of cluster
 func (gc *GatewayClient) UninstallCluster(jsonStr, mdmUsername, mdmPassword, liaPassword string, allowNonSecureCommunicationWithMdm, allowNonSecureCommunicationWithLia, disableNonMgmtComponentsAuth, _ bool) (*types.GatewayResponse, error) {
+	return gc.UninstallClusterCtx(context.Background(), jsonStr, mdmUsername, mdmPassword, liaPassword, allowNonSecureCommunicationWithMdm, allowNonSecureCommunicationWithLia, disableNonMgmtComponentsAuth, false)
+}
+
+// UninstallClusterCtx used for uninstallation of cluster
+func (gc *GatewayClient) UninstallClusterCtx(ctx context.Context, jsonStr, mdmUsername, mdmPassword, liaPassword string, allowNonSecureCommunicationWithMdm, allowNonSecureCommunicationWithLia, disableNonMgmtComponentsAuth, _ bool) (*types.GatewayResponse, error) {
+	ctx, span := gc.startSpan(ctx, "UninstallCluster")
+	defer span.end()
+
 	var gatewayResponse types.GatewayResponse
 
 	clusterData, jsonParseError := jsonToMap(jsonStr)
@@ -1159,38 +835,16 @@ func (gc *GatewayClient) UninstallCluster(jsonStr, mdmUsername, mdmPassword, lia
 
 	finalJSON, _ := json.Marshal(clusterData)
 
-	u, _ := url.Parse(gc.host + "/im/types/Configuration/actions/uninstall")
-
-	req, httpError := http.NewRequest(http.MethodPost, u.String(), bytes.NewBuffer(finalJSON))
-	if httpError != nil {
-		return &gatewayResponse, httpError
-	}
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &gatewayResponse, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodPost,
+		path:   "/im/types/Configuration/actions/uninstall",
+		body:   finalJSON,
+	})
+	if err != nil {
+		return &gatewayResponse, err
 	}
 
 	if httpResp.StatusCode != 202 {
-
-		responseString, err := extractString(httpResp)
-		if err != nil {
-			return &gatewayResponse, fmt.Errorf("Error Extracting Response: %s", err)
-		}
-
 		err = json.Unmarshal([]byte(responseString), &gatewayResponse)
 		if err != nil {
 			return &gatewayResponse, fmt.Errorf("Error For Uninstall Cluster: %s", err)
@@ -1204,143 +858,41 @@ func (gc *GatewayClient) UninstallCluster(jsonStr, mdmUsername, mdmPassword, lia
 	return &gatewayResponse, nil
 }
 
-// jsonToMap used for convert json to map
-func jsonToMap(jsonStr string) (map[string]interface{}, error) {
-	result := make(map[string]interface{})
-	err := json.Unmarshal([]byte(jsonStr), &result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
+// installerAction sends a request that changes the state of the installer
+// and returns no data. operation names the request in parsing errors.
+func (gc *GatewayClient) installerAction(ctx context.Context, method, path, operation string) (*types.GatewayResponse, error) {
+	var gatewayResponse types.GatewayResponse
 
-// getConfigPath returns the path to the cookie configuration file in the user's home directory.
-func getConfigPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: method,
+		path:   path,
+	})
 	if err != nil {
-		return "/home/.cookie_config.yaml", err
-	}
-
-	configPath := filepath.Join(homeDir, ".cookie_config.yaml")
-
-	return configPath, nil
-}
-
-var globalCookie string
-
-// CookieConfig represents the YAML structure
-type CookieConfig struct {
-	Hosts []Host `yaml:"hosts"`
-}
-
-// Host represents individual hosts in the YAML structure
-type Host struct {
-	Name           string `yaml:"name"`
-	LegacyGWCookie string `yaml:"cookie"`
-}
-
-func storeCookie(header http.Header, host string) error {
-	return storeCookieFunc(header, host)
-}
-
-var storeCookieFunc = func(header http.Header, host string) error {
-	if header != nil && header["Set-Cookie"] != nil {
-
-		newCookie := strings.Split(header["Set-Cookie"][0], ";")[0]
-		sanitizedCookie := strings.ReplaceAll(strings.Split(newCookie, "=")[1], "|", "_")
-
-		// Load existing configuration
-		config, err := loadConfig()
-		if err != nil {
-			return err
-		}
-
-		// Check if the host already exists, and update or add accordingly
-		found := false
-		for i, h := range config.Hosts {
-			if h.Name == host {
-				config.Hosts[i].LegacyGWCookie = sanitizedCookie
-				found = true
-				break
-			}
-		}
-
-		// If the host is not found, add a new host
-		if !found {
-			config.Hosts = append(config.Hosts, Host{Name: host, LegacyGWCookie: sanitizedCookie})
-		}
-
-		// Update the global variable directly
-		globalCookie = sanitizedCookie
-
-		err = writeConfig(config)
-		if err != nil {
-			return err
-		}
+		return &gatewayResponse, err
 	}
 
-	return nil
-}
-
-func setCookie(header http.Header, host string) error {
-	return setCookieFunc(header, host)
-}
-
-var setCookieFunc = func(header http.Header, host string) error {
-	if globalCookie != "" {
-		header.Set("Cookie", "LEGACYGWCOOKIE="+strings.ReplaceAll(globalCookie, "_", "|"))
-	} else {
-		config, err := loadConfig()
+	if httpResp.StatusCode != 200 {
+		err := json.Unmarshal([]byte(responseString), &gatewayResponse)
 		if err != nil {
-			return err
+			return &gatewayResponse, fmt.Errorf("Error For %s: %s", operation, err)
 		}
 
-		// Check if the host already exists and set the globalCookie
-		for _, h := range config.Hosts {
-			if h.Name == host {
-				globalCookie = h.LegacyGWCookie
-				header.Set("Cookie", "LEGACYGWCOOKIE="+strings.ReplaceAll(globalCookie, "_", "|"))
-				break
-			}
-		}
+		return &gatewayResponse, nil
 	}
 
-	return nil
-}
-
-func loadConfig() (*CookieConfig, error) {
-	configFile, _ := getConfigPath()
-	if _, err := os.Stat(filepath.Clean(configFile)); err == nil {
-		data, err := os.ReadFile(configFile)
-		if err != nil {
-			return nil, err
-		}
-
-		var config CookieConfig
-		err = yaml.Unmarshal(data, &config)
-		if err != nil {
-			return nil, err
-		}
-
-		return &config, nil
-	}
+	gatewayResponse.StatusCode = 200
 
-	return &CookieConfig{}, nil
+	return &gatewayResponse, nil
 }
 
-func writeConfig(config *CookieConfig) error {
-	data, err := yaml.Marshal(&config)
-	if err != nil {
-		return err
-	}
-	// #nosec G306
-	configFile, _ := getConfigPath()
-	err = os.WriteFile(configFile, data, 0o600)
+// jsonToMap used for convert json to map
+func jsonToMap(jsonStr string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	err := json.Unmarshal([]byte(jsonStr), &result)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	return nil
+	return result, nil
 }
 
 // ParseJSONError parses the JSON in response into an error object
@@ -13,18 +13,24 @@
 package goscaleio
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/dell/goscaleio/api"
 	types "github.com/dell/goscaleio/types/v1"
 	"github.com/stretchr/testify/assert"
 )
@@ -141,6 +147,65 @@ func TestNewGatewayInsecure(t *testing.T) {
 	assert.Equal(t, "4.0", gc.version, "Unexpected version")
 }
 
+func TestNewGatewayWithOptions(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/rest/auth/login" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintln(w, `{"access_token":"mock_access_token"}`)
+			return
+		}
+		if r.Method == http.MethodGet && r.URL.Path == "/api/version" {
+			fmt.Fprintln(w, "4.0")
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caFile, pemData, 0o600))
+
+	// the server's certificate is not in the system pool
+	_, err := NewGatewayWithOptions(context.Background(), server.URL, "test_username", "test_password", api.ClientOptions{})
+	assert.ErrorContains(t, err, "certificate")
+
+	gc, err := NewGatewayWithOptions(context.Background(), server.URL, "test_username", "test_password", api.ClientOptions{CACertFile: caFile})
+	assert.NoError(t, err)
+	assert.Equal(t, "mock_access_token", gc.token)
+	assert.Equal(t, "4.0", gc.version)
+
+	_, err = NewGatewayWithOptions(context.Background(), server.URL, "test_username", "test_password", api.ClientOptions{CACertFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.ErrorContains(t, err, "unable to read CA certificate file")
+}
+
+func TestGatewayLoggerRedactsCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/auth/login":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintln(w, `{"access_token":"mock_access_token"}`)
+		case "/api/version":
+			fmt.Fprintln(w, "4.0")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	_, err := NewGatewayWithOptions(context.Background(), server.URL, "test_username", "test_password", api.ClientOptions{
+		ShowHTTP: true,
+		Logger:   logger,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "GOSCALEIO HTTP REQUEST")
+	assert.Contains(t, buf.String(), "test_username")
+	assert.NotContains(t, buf.String(), "test_password")
+	assert.NotContains(t, buf.String(), "mock_access_token")
+}
+
 // errorTransport simulates an error during response body reading
 type errorTransport struct{}
 
@@ -359,7 +424,7 @@ func TestUploadPackages(t *testing.T) {
 
 	t.Run("error - set cookie", func(t *testing.T) {
 		defaultCookieFunc := setCookieFunc
-		setCookieFunc = func(_ http.Header, _ string) error {
+		setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 			return errors.New("cookie error")
 		}
 		name := "test_file.tar"
@@ -505,7 +570,7 @@ func TestParseCSV(t *testing.T) {
 
 	t.Run("cookie error", func(t *testing.T) {
 		defaultCookieFunc := setCookieFunc
-		setCookieFunc = func(_ http.Header, _ string) error {
+		setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 			return errors.New("cookie error")
 		}
 		file, err := os.CreateTemp("", "test_file.csv")
@@ -596,7 +661,7 @@ func TestGetPackageDetails(t *testing.T) {
 	})
 	t.Run("set cookie error", func(t *testing.T) {
 		defaultCookieFunc := setCookieFunc
-		setCookieFunc = func(_ http.Header, _ string) error {
+		setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 			return errors.New("cookie error")
 		}
 		_, err := gc.GetPackageDetails()
@@ -652,7 +717,7 @@ func TestDeletePackage(t *testing.T) {
 		}))
 		defer server.Close()
 		defaultSetCookieFunc := setCookieFunc
-		setCookieFunc = func(_ http.Header, _ string) error {
+		setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 			return errors.New("cookie error")
 		}
 
@@ -756,7 +821,7 @@ func TestBeginInstallation(t *testing.T) {
 			expectedStatus: -1,
 			expectedErr:    errors.New("Error While Handling Cookie: cookie error"),
 			setup: func() {
-				setCookieFunc = func(_ http.Header, _ string) error {
+				setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 					return errors.New("cookie error")
 				}
 			},
@@ -839,7 +904,7 @@ func TestMoveToNextPhase(t *testing.T) {
 	})
 	t.Run("fail - setCookie", func(t *testing.T) {
 		temp := setCookieFunc
-		setCookieFunc = func(_ http.Header, _ string) error {
+		setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 			return errors.New("cookie error")
 		}
 		gc.username = "test_username"
@@ -904,7 +969,7 @@ func TestRetryPhase(t *testing.T) {
 	})
 	t.Run("fail - setCookie", func(t *testing.T) {
 		temp := setCookieFunc
-		setCookieFunc = func(_ http.Header, _ string) error {
+		setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 			return errors.New("cookie error")
 		}
 		gc.username = "test_username"
@@ -969,7 +1034,7 @@ func TestAbortOperation(t *testing.T) {
 	})
 	t.Run("fail - setCookie", func(t *testing.T) {
 		temp := setCookieFunc
-		setCookieFunc = func(_ http.Header, _ string) error {
+		setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 			return errors.New("cookie error")
 		}
 		gc.username = "test_username"
@@ -1034,7 +1099,7 @@ func TestClearQueueCommand(t *testing.T) {
 	})
 	t.Run("fail - setCookie", func(t *testing.T) {
 		temp := setCookieFunc
-		setCookieFunc = func(_ http.Header, _ string) error {
+		setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 			return errors.New("cookie error")
 		}
 		gc.username = "test_username"
@@ -1098,7 +1163,7 @@ func TestMoveToIdlePhase(t *testing.T) {
 	})
 	t.Run("fail - setCookie", func(t *testing.T) {
 		temp := setCookieFunc
-		setCookieFunc = func(_ http.Header, _ string) error {
+		setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 			return errors.New("cookie error")
 		}
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1300,7 +1365,7 @@ func TestUninstallCluster(t *testing.T) {
 	})
 	t.Run("error - set cookies", func(t *testing.T) {
 		temp := setCookieFunc
-		setCookieFunc = func(_ http.Header, _ string) error {
+		setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 			return errors.New("cookie error")
 		}
 		gc.version = "4.0"
@@ -1438,7 +1503,7 @@ func TestValidateMDMDetails(t *testing.T) {
 			version:          "4.0",
 			expectedErr:      errors.New("Error While Handling Cookie: Cookie error"),
 			setup: func() {
-				setCookieFunc = func(_ http.Header, _ string) error {
+				setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 					return errors.New("Cookie error")
 				}
 			},
@@ -1573,7 +1638,7 @@ func TestGetClusterDetails(t *testing.T) {
 			expectedStatusCode: http.StatusOK,
 			expectedErr:        errors.New("Error While Handling Cookie: Cookie error"),
 			setup: func() {
-				setCookieFunc = func(_ http.Header, _ string) error {
+				setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 					return errors.New("Cookie error")
 				}
 			},
@@ -1938,7 +2003,7 @@ func TestGatewayClient_GetInQueueCommand(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 			setup: func() {
-				setCookieFunc = func(_ http.Header, _ string) error {
+				setCookieFunc = func(_ CookieStore, _ http.Header, _ string) error {
 					return errors.New("cookie error")
 				}
 			},
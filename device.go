@@ -13,11 +13,11 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
@@ -46,10 +46,16 @@ func NewDeviceEx(client *Client, device *types.Device) *Device {
 
 // AttachDevice attaches a device
 func (sp *StoragePool) AttachDevice(deviceParam *types.DeviceParam) (string, error) {
-	defer TimeSpent("AttachDevice", time.Now())
+	return sp.AttachDeviceCtx(sp.client.callContext(), deviceParam)
+}
+
+// AttachDeviceCtx attaches a device
+func (sp *StoragePool) AttachDeviceCtx(ctx context.Context, deviceParam *types.DeviceParam) (string, error) {
+	ctx, span := sp.client.startSpan(ctx, "AttachDevice")
+	defer span.end()
 	deviceParam.StoragePoolID = sp.StoragePool.ID
 	dev := types.DeviceResp{}
-	err := sp.client.getJSONWithRetry(
+	err := sp.client.getJSONWithRetry(ctx,
 		http.MethodPost, "/api/types/Device/instances",
 		deviceParam, &dev)
 	if err != nil {
@@ -61,14 +67,20 @@ func (sp *StoragePool) AttachDevice(deviceParam *types.DeviceParam) (string, err
 
 // GetDevice returns a device based on Storage Pool ID
 func (sp *StoragePool) GetDevice() ([]types.Device, error) {
-	defer TimeSpent("GetDevice", time.Now())
+	return sp.GetDeviceCtx(sp.client.callContext())
+}
+
+// GetDeviceCtx returns a device based on Storage Pool ID
+func (sp *StoragePool) GetDeviceCtx(ctx context.Context) ([]types.Device, error) {
+	ctx, span := sp.client.startSpan(ctx, "GetDevice")
+	defer span.end()
 
 	path := fmt.Sprintf(
 		"/api/instances/StoragePool::%v/relationships/Device",
 		sp.StoragePool.ID)
 
 	var devices []types.Device
-	err := sp.client.getJSONWithRetry(
+	err := sp.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &devices)
 	if err != nil {
 		return nil, err
@@ -81,9 +93,17 @@ func (sp *StoragePool) GetDevice() ([]types.Device, error) {
 func (sp *StoragePool) FindDevice(
 	field, value string,
 ) (*types.Device, error) {
-	defer TimeSpent("FindDevice", time.Now())
+	return sp.FindDeviceCtx(sp.client.callContext(), field, value)
+}
 
-	devices, err := sp.GetDevice()
+// FindDeviceCtx returns a Device
+func (sp *StoragePool) FindDeviceCtx(ctx context.Context,
+	field, value string,
+) (*types.Device, error) {
+	ctx, span := sp.client.startSpan(ctx, "FindDevice")
+	defer span.end()
+
+	devices, err := sp.GetDeviceCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -101,14 +121,20 @@ func (sp *StoragePool) FindDevice(
 
 // GetDevice returns a devices based on SDS ID
 func (sds *Sds) GetDevice() ([]types.Device, error) {
-	defer TimeSpent("GetSDSDevice", time.Now())
+	return sds.GetDeviceCtx(sds.client.callContext())
+}
+
+// GetDeviceCtx returns a devices based on SDS ID
+func (sds *Sds) GetDeviceCtx(ctx context.Context) ([]types.Device, error) {
+	ctx, span := sds.client.startSpan(ctx, "GetSDSDevice")
+	defer span.end()
 
 	path := fmt.Sprintf(
 		"/api/instances/Sds::%v/relationships/Device",
 		sds.Sds.ID)
 
 	var devices []types.Device
-	err := sds.client.getJSONWithRetry(http.MethodGet, path, nil, &devices)
+	err := sds.client.getJSONWithRetry(ctx, http.MethodGet, path, nil, &devices)
 	if err != nil {
 		return nil, err
 	}
@@ -120,9 +146,17 @@ func (sds *Sds) GetDevice() ([]types.Device, error) {
 func (sds *Sds) FindDevice(
 	field, value string,
 ) (*types.Device, error) {
-	defer TimeSpent("FindDevice", time.Now())
+	return sds.FindDeviceCtx(sds.client.callContext(), field, value)
+}
 
-	devices, err := sds.GetDevice()
+// FindDeviceCtx returns a Device
+func (sds *Sds) FindDeviceCtx(ctx context.Context,
+	field, value string,
+) (*types.Device, error) {
+	ctx, span := sds.client.startSpan(ctx, "FindDevice")
+	defer span.end()
+
+	devices, err := sds.GetDeviceCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -140,12 +174,18 @@ func (sds *Sds) FindDevice(
 
 // GetAllDevice returns all device in the system
 func (s *System) GetAllDevice() ([]types.Device, error) {
-	defer TimeSpent("GetAllDevice", time.Now())
+	return s.GetAllDeviceCtx(s.client.callContext())
+}
+
+// GetAllDeviceCtx returns all device in the system
+func (s *System) GetAllDeviceCtx(ctx context.Context) ([]types.Device, error) {
+	ctx, span := s.client.startSpan(ctx, "GetAllDevice")
+	defer span.end()
 
 	path := "/api/types/Device/instances"
 
 	var deviceResult []types.Device
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &deviceResult)
 	if err != nil {
 		return nil, err
@@ -158,37 +198,60 @@ func (s *System) GetAllDevice() ([]types.Device, error) {
 func (s *System) GetDeviceByField(
 	field, value string,
 ) ([]types.Device, error) {
-	defer TimeSpent("GetDeviceByField", time.Now())
+	return s.GetDeviceByFieldCtx(s.client.callContext(), field, value)
+}
+
+// GetDeviceByFieldCtx returns a Device list filter by the field
+func (s *System) GetDeviceByFieldCtx(ctx context.Context,
+	field, value string,
+) ([]types.Device, error) {
+	ctx, span := s.client.startSpan(ctx, "GetDeviceByField")
+	defer span.end()
 
-	devices, err := s.GetAllDevice()
+	devices, err := s.QueryDeviceCtx(ctx, Query().Where(field, Eq, value))
 	if err != nil {
 		return nil, err
 	}
-
-	var filterdevices []types.Device
-	for _, device := range devices {
-		valueOf := reflect.ValueOf(device)
-		if reflect.Indirect(valueOf).FieldByName(field).String() == value {
-			filterdevices = append(filterdevices, device)
-		}
-	}
-	if len(filterdevices) > 0 {
-		return filterdevices, nil
+	if len(devices) > 0 {
+		return devices, nil
 	}
 
 	return nil, errors.New("couldn't find device")
 }
 
+// QueryDevice returns the devices in the system that match q
+func (s *System) QueryDevice(q *QueryBuilder) ([]types.Device, error) {
+	return s.QueryDeviceCtx(s.client.callContext(), q)
+}
+
+// QueryDeviceCtx returns the devices in the system that match q
+func (s *System) QueryDeviceCtx(ctx context.Context, q *QueryBuilder) ([]types.Device, error) {
+	ctx, span := s.client.startSpan(ctx, "QueryDevice")
+	defer span.end()
+
+	// device names are only unique within an SDS, so they are not looked up
+	return queryMDM[types.Device](ctx, s.client, mdmType{
+		name: "Device",
+		list: "/api/types/Device/instances",
+	}, q)
+}
+
 // GetDevice returns a device using Device ID
 func (s *System) GetDevice(id string) (*types.Device, error) {
-	defer TimeSpent("GetDevice", time.Now())
+	return s.GetDeviceCtx(s.client.callContext(), id)
+}
+
+// GetDeviceCtx returns a device using Device ID
+func (s *System) GetDeviceCtx(ctx context.Context, id string) (*types.Device, error) {
+	ctx, span := s.client.startSpan(ctx, "GetDevice")
+	defer span.end()
 
 	path := fmt.Sprintf(
 		"/api/instances/Device::%v",
 		id)
 
 	var deviceResult types.Device
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &deviceResult)
 	if err != nil {
 		return nil, err
@@ -197,16 +260,35 @@ func (s *System) GetDevice(id string) (*types.Device, error) {
 	return &deviceResult, nil
 }
 
+// GetDevicesByIDs returns the devices with the given IDs, fetched in batches
+func (s *System) GetDevicesByIDs(ids []string) ([]types.Device, error) {
+	return s.GetDevicesByIDsCtx(s.client.callContext(), ids)
+}
+
+// GetDevicesByIDsCtx returns the devices with the given IDs, fetched in batches
+func (s *System) GetDevicesByIDsCtx(ctx context.Context, ids []string) ([]types.Device, error) {
+	ctx, span := s.client.startSpan(ctx, "GetDevicesByIDs")
+	defer span.end()
+
+	return queryBySelectedIDs[types.Device](ctx, s.client, "Device", ids)
+}
+
 // SetDeviceName modifies device name
 func (sp *StoragePool) SetDeviceName(id, name string) error {
-	defer TimeSpent("SetDeviceName", time.Now())
+	return sp.SetDeviceNameCtx(sp.client.callContext(), id, name)
+}
+
+// SetDeviceNameCtx modifies device name
+func (sp *StoragePool) SetDeviceNameCtx(ctx context.Context, id, name string) error {
+	ctx, span := sp.client.startSpan(ctx, "SetDeviceName")
+	defer span.end()
 
 	deviceParam := &types.SetDeviceName{
 		Name: name,
 	}
 	path := fmt.Sprintf("/api/instances/Device::%v/action/setDeviceName", id)
 
-	err := sp.client.getJSONWithRetry(
+	err := sp.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, deviceParam, nil)
 	if err != nil {
 		return err
@@ -216,14 +298,20 @@ func (sp *StoragePool) SetDeviceName(id, name string) error {
 
 // SetDeviceMediaType modifies device media type
 func (sp *StoragePool) SetDeviceMediaType(id, mediaType string) error {
-	defer TimeSpent("SetDeviceMediaType", time.Now())
+	return sp.SetDeviceMediaTypeCtx(sp.client.callContext(), id, mediaType)
+}
+
+// SetDeviceMediaTypeCtx modifies device media type
+func (sp *StoragePool) SetDeviceMediaTypeCtx(ctx context.Context, id, mediaType string) error {
+	ctx, span := sp.client.startSpan(ctx, "SetDeviceMediaType")
+	defer span.end()
 
 	deviceParam := &types.SetDeviceMediaType{
 		MediaType: mediaType,
 	}
 	path := fmt.Sprintf("/api/instances/Device::%v/action/setMediaType", id)
 
-	err := sp.client.getJSONWithRetry(
+	err := sp.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, deviceParam, nil)
 	if err != nil {
 		return err
@@ -233,14 +321,20 @@ func (sp *StoragePool) SetDeviceMediaType(id, mediaType string) error {
 
 // SetDeviceExternalAccelerationType modifies device external acceleration type
 func (sp *StoragePool) SetDeviceExternalAccelerationType(id, externalAccelerationType string) error {
-	defer TimeSpent("SetDeviceExternalAccelerationType", time.Now())
+	return sp.SetDeviceExternalAccelerationTypeCtx(sp.client.callContext(), id, externalAccelerationType)
+}
+
+// SetDeviceExternalAccelerationTypeCtx modifies device external acceleration type
+func (sp *StoragePool) SetDeviceExternalAccelerationTypeCtx(ctx context.Context, id, externalAccelerationType string) error {
+	ctx, span := sp.client.startSpan(ctx, "SetDeviceExternalAccelerationType")
+	defer span.end()
 
 	deviceParam := &types.SetDeviceExternalAccelerationType{
 		ExternalAccelerationType: externalAccelerationType,
 	}
 	path := fmt.Sprintf("/api/instances/Device::%v/action/setExternalAccelerationType", id)
 
-	err := sp.client.getJSONWithRetry(
+	err := sp.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, deviceParam, nil)
 	if err != nil {
 		return err
@@ -250,14 +344,20 @@ func (sp *StoragePool) SetDeviceExternalAccelerationType(id, externalAcceleratio
 
 // SetDeviceCapacityLimit modifies device capacity limit
 func (sp *StoragePool) SetDeviceCapacityLimit(id, capacityLimitInGB string) error {
-	defer TimeSpent("SetDeviceExternalAccelerationType", time.Now())
+	return sp.SetDeviceCapacityLimitCtx(sp.client.callContext(), id, capacityLimitInGB)
+}
+
+// SetDeviceCapacityLimitCtx modifies device capacity limit
+func (sp *StoragePool) SetDeviceCapacityLimitCtx(ctx context.Context, id, capacityLimitInGB string) error {
+	ctx, span := sp.client.startSpan(ctx, "SetDeviceExternalAccelerationType")
+	defer span.end()
 
 	deviceParam := &types.SetDeviceCapacityLimit{
 		DeviceCapacityLimit: capacityLimitInGB,
 	}
 	path := fmt.Sprintf("/api/instances/Device::%v/action/setDeviceCapacityLimit", id)
 
-	err := sp.client.getJSONWithRetry(
+	err := sp.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, deviceParam, nil)
 	if err != nil {
 		return err
@@ -267,12 +367,18 @@ func (sp *StoragePool) SetDeviceCapacityLimit(id, capacityLimitInGB string) erro
 
 // UpdateDeviceOriginalPathways modifies device path if changed during server restart
 func (sp *StoragePool) UpdateDeviceOriginalPathways(id string) error {
-	defer TimeSpent("UpdateDeviceOriginalPathways", time.Now())
+	return sp.UpdateDeviceOriginalPathwaysCtx(sp.client.callContext(), id)
+}
+
+// UpdateDeviceOriginalPathwaysCtx modifies device path if changed during server restart
+func (sp *StoragePool) UpdateDeviceOriginalPathwaysCtx(ctx context.Context, id string) error {
+	ctx, span := sp.client.startSpan(ctx, "UpdateDeviceOriginalPathways")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Device::%v/action/updateDeviceOriginalPathname", id)
 	deviceParam := &types.EmptyPayload{}
 
-	err := sp.client.getJSONWithRetry(
+	err := sp.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, deviceParam, nil)
 	if err != nil {
 		return err
@@ -282,11 +388,17 @@ func (sp *StoragePool) UpdateDeviceOriginalPathways(id string) error {
 
 // RemoveDevice removes device from storage pool
 func (sp *StoragePool) RemoveDevice(id string) error {
-	defer TimeSpent("RemoveDevice", time.Now())
+	return sp.RemoveDeviceCtx(sp.client.callContext(), id)
+}
+
+// RemoveDeviceCtx removes device from storage pool
+func (sp *StoragePool) RemoveDeviceCtx(ctx context.Context, id string) error {
+	ctx, span := sp.client.startSpan(ctx, "RemoveDevice")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Device::%v/action/removeDevice", id)
 
-	err := sp.client.getJSONWithRetry(
+	err := sp.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, nil, nil)
 	if err != nil {
 		return err
@@ -229,6 +229,14 @@ func TestGetDeviceByField(t *testing.T) {
 						t.Fatal(err)
 					}
 
+					resp.Write(content)
+				case "/api/instances/Device::mock-device-id-1":
+					resp.WriteHeader(http.StatusOK)
+					content, err := json.Marshal(types.Device{ID: "mock-device-id-1", Name: "mock-device-name-1"})
+					if err != nil {
+						t.Fatal(err)
+					}
+
 					resp.Write(content)
 				default:
 					resp.WriteHeader(http.StatusNoContent)
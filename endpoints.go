@@ -0,0 +1,91 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"github.com/dell/goscaleio/api"
+)
+
+// ActiveEndpoint returns the endpoint requests are sent to, which changes
+// when the client fails over to another endpoint of the system.
+func (c *Client) ActiveEndpoint() string {
+	if c.endpoints == nil {
+		return ""
+	}
+	return c.endpoints.Active()
+}
+
+// Endpoints returns the endpoints of the system, in the order the client
+// fails over to them.
+func (c *Client) Endpoints() []string {
+	if c.endpoints == nil {
+		return nil
+	}
+	return c.endpoints.Endpoints()
+}
+
+// CheckEndpoints health checks the endpoints of the system. When the active
+// endpoint fails the check, the first healthy one becomes active.
+func (c *Client) CheckEndpoints() []api.EndpointHealth {
+	return c.CheckEndpointsCtx(c.callContext())
+}
+
+// CheckEndpointsCtx health checks the endpoints of the system. When the
+// active endpoint fails the check, the first healthy one becomes active.
+func (c *Client) CheckEndpointsCtx(ctx context.Context) []api.EndpointHealth {
+	ctx, span := c.startSpan(ctx, "CheckEndpoints")
+	defer span.end()
+
+	if c.endpoints == nil {
+		return nil
+	}
+	return c.endpoints.Check(ctx)
+}
+
+func (c *Client) addEndpoints(ctx context.Context, endpoints ...string) error {
+	if c.endpoints == nil {
+		return nil
+	}
+	return c.endpoints.Add(ctx, endpoints...)
+}
+
+// discoverEndpoints adds the management IPs of the MDMs of the systems to the
+// endpoints, reached with the scheme and port of the active endpoint.
+func (c *Client) discoverEndpoints(ctx context.Context) error {
+	systems, err := c.GetInstanceCtx(ctx, "")
+	if err != nil {
+		return err
+	}
+	active, err := url.Parse(c.ActiveEndpoint())
+	if err != nil {
+		return err
+	}
+
+	var endpoints []string
+	for _, system := range systems {
+		for _, ip := range system.MdmManagementIPList {
+			endpoint := url.URL{Scheme: active.Scheme, Host: ip}
+			if port := active.Port(); port != "" {
+				endpoint.Host = net.JoinHostPort(ip, port)
+			} else if addr := net.ParseIP(ip); addr != nil && addr.To4() == nil {
+				endpoint.Host = "[" + ip + "]"
+			}
+			endpoints = append(endpoints, endpoint.String())
+		}
+	}
+	return c.addEndpoints(ctx, endpoints...)
+}
@@ -0,0 +1,115 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newMdmTestServer returns a server that issues its own tokens, so that a
+// client failing over to it has to log in again.
+func newMdmTestServer(t *testing.T, name string, logins *int32, ips ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/version":
+			w.Write([]byte(`"3.6"`))
+		case "/api/login":
+			atomic.AddInt32(logins, 1)
+			fmt.Fprintf(w, `"%s"`, name)
+		case "/api/types/System/instances":
+			if _, token, _ := r.BasicAuth(); token != name {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"Unauthorized","httpStatusCode":401,"errorCode":0}`))
+				return
+			}
+			list, _ := json.Marshal(ips)
+			fmt.Fprintf(w, `[{"id":"%s","mdmManagementIPList":%s}]`, name, list)
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+}
+
+func TestClientFailover(t *testing.T) {
+	var primaryLogins, secondaryLogins int32
+	primary := newMdmTestServer(t, "primary", &primaryLogins)
+	defer primary.Close()
+	secondary := newMdmTestServer(t, "secondary", &secondaryLogins)
+	defer secondary.Close()
+
+	client, err := NewClientWithArgs(primary.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{
+		Username:  "admin",
+		Password:  "password",
+		Endpoints: []string{secondary.URL},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{primary.URL, secondary.URL}, client.Endpoints())
+	assert.Equal(t, primary.URL, client.ActiveEndpoint())
+
+	// the secondary rejects the token of the primary, so the client logs in
+	// to it and the call succeeds
+	primary.Close()
+	systems, err := client.GetSystems()
+	assert.NoError(t, err)
+	assert.Len(t, systems, 1)
+	assert.Equal(t, "secondary", systems[0].ID)
+	assert.Equal(t, secondary.URL, client.ActiveEndpoint())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&primaryLogins))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&secondaryLogins))
+
+	health := client.CheckEndpoints()
+	assert.Len(t, health, 2)
+	assert.Error(t, health[0].Err)
+	assert.NoError(t, health[1].Err)
+	assert.True(t, health[1].Active)
+}
+
+func TestDiscoverEndpoints(t *testing.T) {
+	var logins int32
+	ts := newMdmTestServer(t, "primary", &logins, "127.0.0.1", "10.0.0.2", "fd00::3")
+	defer ts.Close()
+	port := ts.Listener.Addr().(*net.TCPAddr).Port
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{
+		Username:          "admin",
+		Password:          "password",
+		DiscoverEndpoints: true,
+	})
+	assert.NoError(t, err)
+
+	// the endpoint in use is known already
+	assert.Equal(t, []string{
+		ts.URL,
+		fmt.Sprintf("http://10.0.0.2:%d", port),
+		fmt.Sprintf("http://[fd00::3]:%d", port),
+	}, client.Endpoints())
+	assert.Equal(t, ts.URL, client.ActiveEndpoint())
+
+	u, err := url.Parse(client.Endpoints()[2])
+	assert.NoError(t, err)
+	assert.Equal(t, "fd00::3", u.Hostname())
+}
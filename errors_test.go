@@ -0,0 +1,52 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorsIs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/instances/Volume::missing":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"Could not find the volume","httpStatusCode":500,"errorCode":79}`))
+		case "/im/types/installationPackages/instances/actions/uploadPackages":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message":"Package already exists"}`))
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.GetVolume("", "missing", "", "", false)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrAlreadyExists))
+
+	pkg := filepath.Join(t.TempDir(), "package.tar")
+	assert.NoError(t, os.WriteFile(pkg, []byte("package"), 0o600))
+	gc := &GatewayClient{http: ts.Client(), host: ts.URL, username: "admin", password: "password"}
+	_, err = gc.UploadPackages([]string{pkg})
+	assert.EqualError(t, err, "received bad response: Package already exists")
+	assert.True(t, errors.Is(err, ErrAlreadyExists))
+}
@@ -13,20 +13,28 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // CreateFaultSet creates a fault set
 func (pd *ProtectionDomain) CreateFaultSet(fs *types.FaultSetParam) (string, error) {
+	return pd.CreateFaultSetCtx(pd.client.callContext(), fs)
+}
+
+// CreateFaultSetCtx creates a fault set
+func (pd *ProtectionDomain) CreateFaultSetCtx(ctx context.Context, fs *types.FaultSetParam) (string, error) {
+	ctx, span := pd.client.startSpan(ctx, "CreateFaultSet")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/types/FaultSet/instances")
 	fs.ProtectionDomainID = pd.ProtectionDomain.ID
 	fsResp := types.FaultSetResp{}
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, fs, &fsResp)
 	if err != nil {
 		return "", err
@@ -36,9 +44,17 @@ func (pd *ProtectionDomain) CreateFaultSet(fs *types.FaultSetParam) (string, err
 
 // DeleteFaultSet will delete a fault set
 func (pd *ProtectionDomain) DeleteFaultSet(id string) error {
+	return pd.DeleteFaultSetCtx(pd.client.callContext(), id)
+}
+
+// DeleteFaultSetCtx will delete a fault set
+func (pd *ProtectionDomain) DeleteFaultSetCtx(ctx context.Context, id string) error {
+	ctx, span := pd.client.startSpan(ctx, "DeleteFaultSet")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/FaultSet::%v/action/removeFaultSet", id)
 	fsParam := &types.EmptyPayload{}
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, fsParam, nil)
 	if err != nil {
 		return err
@@ -48,11 +64,19 @@ func (pd *ProtectionDomain) DeleteFaultSet(id string) error {
 
 // ModifyFaultSetName will modify the name of the fault set
 func (pd *ProtectionDomain) ModifyFaultSetName(id, name string) error {
+	return pd.ModifyFaultSetNameCtx(pd.client.callContext(), id, name)
+}
+
+// ModifyFaultSetNameCtx will modify the name of the fault set
+func (pd *ProtectionDomain) ModifyFaultSetNameCtx(ctx context.Context, id, name string) error {
+	ctx, span := pd.client.startSpan(ctx, "ModifyFaultSetName")
+	defer span.end()
+
 	fs := &types.FaultSetRename{}
 	fs.NewName = name
 	path := fmt.Sprintf("/api/instances/FaultSet::%v/action/setFaultSetName", id)
 
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, fs, nil)
 	if err != nil {
 		return err
@@ -62,11 +86,19 @@ func (pd *ProtectionDomain) ModifyFaultSetName(id, name string) error {
 
 // ModifyFaultSetPerfProfile will modify the performance profile of the fault set
 func (pd *ProtectionDomain) ModifyFaultSetPerfProfile(id, perfProfile string) error {
+	return pd.ModifyFaultSetPerfProfileCtx(pd.client.callContext(), id, perfProfile)
+}
+
+// ModifyFaultSetPerfProfileCtx will modify the performance profile of the fault set
+func (pd *ProtectionDomain) ModifyFaultSetPerfProfileCtx(ctx context.Context, id, perfProfile string) error {
+	ctx, span := pd.client.startSpan(ctx, "ModifyFaultSetPerfProfile")
+	defer span.end()
+
 	pp := &types.ChangeSdcPerfProfile{}
 	pp.PerfProfile = perfProfile
 	path := fmt.Sprintf("/api/instances/FaultSet::%v/action/setSdsPerformanceParameters", id)
 
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, pp, nil)
 	if err != nil {
 		return err
@@ -76,10 +108,18 @@ func (pd *ProtectionDomain) ModifyFaultSetPerfProfile(id, perfProfile string) er
 
 // GetFaultSetByID will read the fault set using the ID.
 func (s *System) GetFaultSetByID(id string) (*types.FaultSet, error) {
+	return s.GetFaultSetByIDCtx(s.client.callContext(), id)
+}
+
+// GetFaultSetByIDCtx will read the fault set using the ID.
+func (s *System) GetFaultSetByIDCtx(ctx context.Context, id string) (*types.FaultSet, error) {
+	ctx, span := s.client.startSpan(ctx, "GetFaultSetByID")
+	defer span.end()
+
 	fs := &types.FaultSet{}
 	path := fmt.Sprintf("/api/instances/FaultSet::%v", id)
 
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, fs)
 	if err != nil {
 		return nil, err
@@ -89,11 +129,17 @@ func (s *System) GetFaultSetByID(id string) (*types.FaultSet, error) {
 
 // GetAllFaultSets returns all fault sets on the system
 func (s *System) GetAllFaultSets() ([]types.FaultSet, error) {
-	defer TimeSpent("FaultSet", time.Now())
+	return s.GetAllFaultSetsCtx(s.client.callContext())
+}
+
+// GetAllFaultSetsCtx returns all fault sets on the system
+func (s *System) GetAllFaultSetsCtx(ctx context.Context) ([]types.FaultSet, error) {
+	ctx, span := s.client.startSpan(ctx, "GetAllFaultSets")
+	defer span.end()
 	path := "/api/types/FaultSet/instances"
 
 	var faultsets []types.FaultSet
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &faultsets)
 	if err != nil {
 		return nil, err
@@ -104,11 +150,17 @@ func (s *System) GetAllFaultSets() ([]types.FaultSet, error) {
 
 // GetAllSDSByFaultSetID returns SDS details associated with fault set
 func (s *System) GetAllSDSByFaultSetID(faultsetid string) ([]types.Sds, error) {
-	defer TimeSpent("FaultSet", time.Now())
+	return s.GetAllSDSByFaultSetIDCtx(s.client.callContext(), faultsetid)
+}
+
+// GetAllSDSByFaultSetIDCtx returns SDS details associated with fault set
+func (s *System) GetAllSDSByFaultSetIDCtx(ctx context.Context, faultsetid string) ([]types.Sds, error) {
+	ctx, span := s.client.startSpan(ctx, "GetAllSDSByFaultSetID")
+	defer span.end()
 	path := fmt.Sprintf("/api/instances/FaultSet::%v/relationships/Sds", faultsetid)
 
 	var faultsets []types.Sds
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &faultsets)
 	if err != nil {
 		return nil, err
@@ -119,7 +171,15 @@ func (s *System) GetAllSDSByFaultSetID(faultsetid string) ([]types.Sds, error) {
 
 // GetFaultSetByName will read the fault set using the name
 func (s *System) GetFaultSetByName(name string) (*types.FaultSet, error) {
-	allFaultSets, err := s.GetAllFaultSets()
+	return s.GetFaultSetByNameCtx(s.client.callContext(), name)
+}
+
+// GetFaultSetByNameCtx will read the fault set using the name
+func (s *System) GetFaultSetByNameCtx(ctx context.Context, name string) (*types.FaultSet, error) {
+	ctx, span := s.client.startSpan(ctx, "GetFaultSetByName")
+	defer span.end()
+
+	allFaultSets, err := s.GetAllFaultSetsCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -13,10 +13,10 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
@@ -37,11 +37,17 @@ func NewFileSystem(client *Client, fs *types.FileSystem) *FileSystem {
 
 // GetAllFileSystems returns a file system
 func (s *System) GetAllFileSystems() ([]types.FileSystem, error) {
-	defer TimeSpent("GetAllFileSystems", time.Now())
+	return s.GetAllFileSystemsCtx(s.client.callContext())
+}
+
+// GetAllFileSystemsCtx returns a file system
+func (s *System) GetAllFileSystemsCtx(ctx context.Context) ([]types.FileSystem, error) {
+	ctx, span := s.client.startSpan(ctx, "GetAllFileSystems")
+	defer span.end()
 
 	path := fmt.Sprintf("/rest/v1/file-systems?select=*")
 	var fs []types.FileSystem
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &fs)
 	if err != nil {
 		return nil, err
@@ -52,7 +58,13 @@ func (s *System) GetAllFileSystems() ([]types.FileSystem, error) {
 
 // GetFileSystemByIDName returns a file system by Name or ID
 func (s *System) GetFileSystemByIDName(id string, name string) (*types.FileSystem, error) {
-	defer TimeSpent("GetFileSystemByIDName", time.Now())
+	return s.GetFileSystemByIDNameCtx(s.client.callContext(), id, name)
+}
+
+// GetFileSystemByIDNameCtx returns a file system by Name or ID
+func (s *System) GetFileSystemByIDNameCtx(ctx context.Context, id string, name string) (*types.FileSystem, error) {
+	ctx, span := s.client.startSpan(ctx, "GetFileSystemByIDName")
+	defer span.end()
 
 	if id == "" && name == "" {
 		return nil, errors.New("file system name or ID is mandatory, please enter a valid value")
@@ -62,7 +74,7 @@ func (s *System) GetFileSystemByIDName(id string, name string) (*types.FileSyste
 	if id != "" {
 		path := fmt.Sprintf("/rest/v1/file-systems/%v?select=*", id)
 		var fs types.FileSystem
-		err := s.client.getJSONWithRetry(
+		err := s.client.getJSONWithRetry(ctx,
 			http.MethodGet, path, nil, &fs)
 		if err != nil {
 			return nil, errors.New("couldn't find filesystem by id")
@@ -72,7 +84,7 @@ func (s *System) GetFileSystemByIDName(id string, name string) (*types.FileSyste
 	}
 
 	// Get filesystem by name
-	filesystems, err := s.GetAllFileSystems()
+	filesystems, err := s.GetAllFileSystemsCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -88,11 +100,17 @@ func (s *System) GetFileSystemByIDName(id string, name string) (*types.FileSyste
 
 // CreateFileSystem creates a file system
 func (s *System) CreateFileSystem(fs *types.FsCreate) (*types.FileSystemResp, error) {
-	defer TimeSpent("CreateFileSystem", time.Now())
+	return s.CreateFileSystemCtx(s.client.callContext(), fs)
+}
+
+// CreateFileSystemCtx creates a file system
+func (s *System) CreateFileSystemCtx(ctx context.Context, fs *types.FsCreate) (*types.FileSystemResp, error) {
+	ctx, span := s.client.startSpan(ctx, "CreateFileSystem")
+	defer span.end()
 
 	path := fmt.Sprintf("/rest/v1/file-systems")
 	fsResponse := types.FileSystemResp{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, fs, &fsResponse)
 	if err != nil {
 		return nil, err
@@ -103,11 +121,17 @@ func (s *System) CreateFileSystem(fs *types.FsCreate) (*types.FileSystemResp, er
 
 // CreateFileSystemSnapshot creates a snapshot for a given file system
 func (s *System) CreateFileSystemSnapshot(createSnapParam *types.CreateFileSystemSnapshotParam, fsID string) (*types.CreateFileSystemSnapshotResponse, error) {
-	defer TimeSpent("CreateFileSystemSnapshot", time.Now())
+	return s.CreateFileSystemSnapshotCtx(s.client.callContext(), createSnapParam, fsID)
+}
+
+// CreateFileSystemSnapshotCtx creates a snapshot for a given file system
+func (s *System) CreateFileSystemSnapshotCtx(ctx context.Context, createSnapParam *types.CreateFileSystemSnapshotParam, fsID string) (*types.CreateFileSystemSnapshotResponse, error) {
+	ctx, span := s.client.startSpan(ctx, "CreateFileSystemSnapshot")
+	defer span.end()
 
 	path := fmt.Sprintf("/rest/v1/file-systems/%v/snapshot", fsID)
 	snapResponse := types.CreateFileSystemSnapshotResponse{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, createSnapParam, &snapResponse)
 	if err != nil {
 		return nil, err
@@ -118,20 +142,26 @@ func (s *System) CreateFileSystemSnapshot(createSnapParam *types.CreateFileSyste
 
 // RestoreFileSystemFromSnapshot restores the filesystem from a given snapshot using filesytem id
 func (s *System) RestoreFileSystemFromSnapshot(restoreSnapParam *types.RestoreFsSnapParam, fsID string) (*types.RestoreFsSnapResponse, error) {
-	defer TimeSpent("CreateFileSystemSnapshot", time.Now())
+	return s.RestoreFileSystemFromSnapshotCtx(s.client.callContext(), restoreSnapParam, fsID)
+}
+
+// RestoreFileSystemFromSnapshotCtx restores the filesystem from a given snapshot using filesytem id
+func (s *System) RestoreFileSystemFromSnapshotCtx(ctx context.Context, restoreSnapParam *types.RestoreFsSnapParam, fsID string) (*types.RestoreFsSnapResponse, error) {
+	ctx, span := s.client.startSpan(ctx, "CreateFileSystemSnapshot")
+	defer span.end()
 
 	path := fmt.Sprintf("/rest/v1/file-systems/%v/restore", fsID)
 
 	restoreFsResponse := types.RestoreFsSnapResponse{}
 	var err error
 	if restoreSnapParam.CopyName == "" {
-		err = s.client.getJSONWithRetry(
+		err = s.client.getJSONWithRetry(ctx,
 			http.MethodPost, path, restoreSnapParam, nil)
 		if err == nil {
 			return nil, nil
 		}
 	} else {
-		err = s.client.getJSONWithRetry(
+		err = s.client.getJSONWithRetry(ctx,
 			http.MethodPost, path, restoreSnapParam, &restoreFsResponse)
 		if err == nil {
 			return &restoreFsResponse, nil
@@ -147,9 +177,15 @@ func (s *System) RestoreFileSystemFromSnapshot(restoreSnapParam *types.RestoreFs
 
 // GetFsSnapshotsByVolumeID gets list of snapshots associated with a filesystem
 func (s *System) GetFsSnapshotsByVolumeID(fsID string) ([]types.FileSystem, error) {
-	defer TimeSpent("GetFsSnapshotsByVolumeID", time.Now())
+	return s.GetFsSnapshotsByVolumeIDCtx(s.client.callContext(), fsID)
+}
+
+// GetFsSnapshotsByVolumeIDCtx gets list of snapshots associated with a filesystem
+func (s *System) GetFsSnapshotsByVolumeIDCtx(ctx context.Context, fsID string) ([]types.FileSystem, error) {
+	ctx, span := s.client.startSpan(ctx, "GetFsSnapshotsByVolumeID")
+	defer span.end()
 	var snapshotList []types.FileSystem
-	fsList, err := s.GetAllFileSystems()
+	fsList, err := s.GetAllFileSystemsCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -163,16 +199,22 @@ func (s *System) GetFsSnapshotsByVolumeID(fsID string) ([]types.FileSystem, erro
 
 // DeleteFileSystem deletes a file system
 func (s *System) DeleteFileSystem(name string) error {
-	defer TimeSpent("DeleteFileSystem", time.Now())
+	return s.DeleteFileSystemCtx(s.client.callContext(), name)
+}
+
+// DeleteFileSystemCtx deletes a file system
+func (s *System) DeleteFileSystemCtx(ctx context.Context, name string) error {
+	ctx, span := s.client.startSpan(ctx, "DeleteFileSystem")
+	defer span.end()
 
-	fs, err := s.GetFileSystemByIDName("", name)
+	fs, err := s.GetFileSystemByIDNameCtx(ctx, "", name)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf("/rest/v1/file-systems/%v", fs.ID)
 
-	err = s.client.getJSONWithRetry(
+	err = s.client.getJSONWithRetry(ctx,
 		http.MethodDelete, path, nil, nil)
 	if err != nil {
 		return err
@@ -183,9 +225,15 @@ func (s *System) DeleteFileSystem(name string) error {
 
 // ModifyFileSystem modifies a file system
 func (s *System) ModifyFileSystem(modifyFsParam *types.FSModify, id string) error {
-	defer TimeSpent("ModifyFileSystem", time.Now())
+	return s.ModifyFileSystemCtx(s.client.callContext(), modifyFsParam, id)
+}
+
+// ModifyFileSystemCtx modifies a file system
+func (s *System) ModifyFileSystemCtx(ctx context.Context, modifyFsParam *types.FSModify, id string) error {
+	ctx, span := s.client.startSpan(ctx, "ModifyFileSystem")
+	defer span.end()
 
-	fs, err := s.GetFileSystemByIDName(id, "")
+	fs, err := s.GetFileSystemByIDNameCtx(ctx, id, "")
 	if err != nil {
 		return err
 	}
@@ -193,7 +241,7 @@ func (s *System) ModifyFileSystem(modifyFsParam *types.FSModify, id string) erro
 	var body *types.FSModify = modifyFsParam
 	path := fmt.Sprintf("/rest/v1/file-systems/%v", fs.ID)
 
-	err = s.client.getJSONWithRetry(http.MethodPatch, path, body, nil)
+	err = s.client.getJSONWithRetry(ctx, http.MethodPatch, path, body, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,228 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/dell/goscaleio/api"
+	types "github.com/dell/goscaleio/types/v1"
+)
+
+// gatewayAuth selects the credentials sent with a gateway request.
+type gatewayAuth int
+
+const (
+	// authByVersion sends the bearer token and the installation cookie to
+	// 4.0 gateways and basic auth to older ones.
+	authByVersion gatewayAuth = iota
+	// authToken sends the bearer token, if any, and the installation cookie
+	// whatever the gateway version.
+	authToken
+	// authNone sends no credentials, as for the login request itself.
+	authNone
+)
+
+// gatewayRequest describes a request sent by GatewayClient.do.
+type gatewayRequest struct {
+	method string
+	// path is relative to the gateway host and may include a query string.
+	path string
+	body []byte
+	// contentType defaults to application/json.
+	contentType string
+	auth        gatewayAuth
+	// noCookie leaves out the installation cookie, both when sending the
+	// request and when handling the response.
+	noCookie bool
+}
+
+// usesToken reports whether r is sent with the bearer token.
+func (gc *GatewayClient) usesToken(r gatewayRequest) bool {
+	return r.auth == authToken || (r.auth == authByVersion && gc.supports(FeatureManagementPlatform))
+}
+
+// do sends r and reads the whole response body, which is returned with
+// surrounding whitespace and quotes trimmed. A request rejected with 401 is
+// sent once more after logging in again. On a 2xx response the installation
+// cookie the gateway returned is stored for the following requests.
+//
+// Only transport and cookie failures are returned as errors; callers check
+// the status code themselves and may use gatewayError for non-2xx responses.
+func (gc *GatewayClient) do(ctx context.Context, r gatewayRequest) (res *http.Response, body string, err error) {
+	defer func() { api.CallFromContext(ctx).SetError(err) }()
+
+	if res, err = gc.open(ctx, r); err != nil {
+		return nil, "", err
+	}
+	defer gc.closeBody(res)
+
+	body, err = extractString(res)
+	if err != nil {
+		return res, "", fmt.Errorf("Error Extracting Response: %w", err)
+	}
+
+	if err := gc.keepCookie(r, res); err != nil {
+		return res, body, err
+	}
+
+	return res, body, nil
+}
+
+// open sends r and returns the response with its body unread. A request
+// rejected with 401 is sent once more after logging in again.
+func (gc *GatewayClient) open(ctx context.Context, r gatewayRequest) (*http.Response, error) {
+	token := gc.getToken()
+	res, err := gc.send(ctx, r, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized && gc.usesToken(r) && token != "" && gc.canLogin() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		gc.closeBody(res)
+
+		if err := gc.refreshToken(ctx, token); err != nil {
+			return nil, err
+		}
+		if res, err = gc.send(ctx, r, gc.getToken()); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// keepCookie stores the installation cookie of a 2xx response to r.
+func (gc *GatewayClient) keepCookie(r gatewayRequest, res *http.Response) error {
+	if gc.usesToken(r) && !r.noCookie && res.StatusCode >= 200 && res.StatusCode <= 299 {
+		if err := gc.storeCookie(res.Header); err != nil {
+			return fmt.Errorf("Error While Storing cookie: %s", err)
+		}
+	}
+	return nil
+}
+
+// send sends a single request described by r, using token for bearer
+// authentication, with the retries and logging configured for gc.
+func (gc *GatewayClient) send(ctx context.Context, r gatewayRequest, token string) (*http.Response, error) {
+	// headers are built once and copied into the request of every attempt
+	header := http.Header{}
+	contentType := r.contentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	header.Set("Content-Type", contentType)
+
+	switch {
+	case r.auth == authNone:
+	case gc.usesToken(r):
+		if token != "" {
+			header.Set("Authorization", "Bearer "+token)
+		}
+		if !r.noCookie {
+			if err := gc.setCookie(header); err != nil {
+				return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
+			}
+		}
+	default:
+		credentials, err := gc.loginCredentials(ctx)
+		if err != nil {
+			return nil, err
+		}
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials.Username+":"+credentials.Password)))
+	}
+
+	sender := &api.Sender{HTTP: gc.http, RetryPolicy: gc.retryPolicy, ShowHTTP: gc.showHTTP, Telemetry: gc.telemetry, Logger: gc.logger, Limiter: gc.limiter}
+	return sender.Send(ctx, func() (*http.Request, error) {
+		var body io.Reader
+		if r.body != nil {
+			body = bytes.NewReader(r.body)
+		}
+		req, err := http.NewRequestWithContext(ctx, r.method, gc.host+r.path, body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header = header.Clone()
+		return req, nil
+	}, true)
+}
+
+// refreshToken logs in again after a request sent with stale was rejected.
+// Concurrent callers wait for a single login.
+func (gc *GatewayClient) refreshToken(ctx context.Context, stale string) error {
+	gc.loginMu.Lock()
+	defer gc.loginMu.Unlock()
+
+	if gc.getToken() != stale {
+		// another request already logged in
+		return nil
+	}
+
+	gc.logger.Info("Need to re-auth")
+	if err := gc.renew(ctx); err != nil {
+		return fmt.Errorf("Error Authenticating: %s", err)
+	}
+	return nil
+}
+
+func (gc *GatewayClient) getToken() string {
+	gc.tokenMu.RLock()
+	defer gc.tokenMu.RUnlock()
+	return gc.token
+}
+
+func (gc *GatewayClient) setToken(token string) {
+	gc.tokenMu.Lock()
+	defer gc.tokenMu.Unlock()
+	gc.token = token
+}
+
+// gatewayError returns the typed error for a non-2xx response whose body has
+// already been read by GatewayClient.do.
+func gatewayError(res *http.Response, body string) error {
+	res.Body = io.NopCloser(strings.NewReader(body))
+	return ParseJSONError(res)
+}
+
+// installerError returns the typed error for an installer request the
+// gateway rejected with r.
+func installerError(res *http.Response, r *types.GatewayResponse) error {
+	err := r.ToError()
+	if err.HTTPStatusCode == 0 {
+		err.HTTPStatusCode = res.StatusCode
+	}
+	return err
+}
+
+// serviceError returns the typed error for a deployment request the gateway
+// rejected with r.
+func serviceError(res *http.Response, r *types.ServiceFailedResponse) error {
+	err := r.ToError()
+	if err.HTTPStatusCode == 0 {
+		err.HTTPStatusCode = res.StatusCode
+	}
+	return err
+}
+
+// closeBody closes the body of res, logging the failures.
+func (gc *GatewayClient) closeBody(res *http.Response) {
+	if err := res.Body.Close(); err != nil {
+		gc.logger.Error(err.Error())
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dell/goscaleio/api"
+	"github.com/dell/goscaleio/log"
+	types "github.com/dell/goscaleio/types/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// newGatewayTokenServer returns a gateway that issues a new token on every
+// login and rejects requests made with any other token.
+func newGatewayTokenServer(t *testing.T, logins *int32) *httptest.Server {
+	var mu sync.Mutex
+	current := ""
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/auth/login":
+			time.Sleep(20 * time.Millisecond)
+			n := atomic.AddInt32(logins, 1)
+			mu.Lock()
+			current = fmt.Sprintf("token-%d", n)
+			mu.Unlock()
+			fmt.Fprintf(w, `{"access_token":"token-%d"}`, n)
+		case "/Api/V1/ManagedDevice":
+			mu.Lock()
+			ok := r.Header.Get("Authorization") == "Bearer "+current
+			mu.Unlock()
+			if !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`[{"refId":"1"}]`))
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+}
+
+func TestGatewayReauthentication(t *testing.T) {
+	var logins int32
+	ts := newGatewayTokenServer(t, &logins)
+	defer ts.Close()
+
+	gc := &GatewayClient{
+		http:     ts.Client(),
+		host:     ts.URL,
+		username: "admin",
+		password: "password",
+		version:  "4.0",
+		token:    "expired",
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = gc.GetAllNodes()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+	assert.Equal(t, "token-1", gc.getToken())
+}
+
+func TestGatewayReauthenticationFails(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/auth/login" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"invalid credentials","httpStatusCode":401,"errorCode":0}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	gc := &GatewayClient{
+		http:     ts.Client(),
+		host:     ts.URL,
+		username: "admin",
+		password: "wrong",
+		version:  "4.0",
+		token:    "expired",
+	}
+
+	_, err := gc.GetAllNodes()
+	assert.ErrorContains(t, err, "Error Authenticating: invalid credentials")
+}
+
+func TestGatewayRequest(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Api/V1/nodepool":
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:password"))
+			assert.Equal(t, basic, r.Header.Get("Authorization"))
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			w.Write([]byte(`{"nodePoolDetails":[]}`))
+		case "/Api/V1/ManagedDevice":
+			time.Sleep(200 * time.Millisecond)
+		}
+	}))
+	defer ts.Close()
+
+	policy := api.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	gc := &GatewayClient{
+		http:        ts.Client(),
+		host:        ts.URL,
+		username:    "admin",
+		password:    "password",
+		version:     "3.6",
+		retryPolicy: policy,
+	}
+
+	_, err := gc.GetAllNodePools()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = gc.GetAllNodesCtx(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGatewayError(t *testing.T) {
+	res := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Status:     "404 Not Found",
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+	}
+	err := gatewayError(res, `{"message":"not found","httpStatusCode":404,"errorCode":0}`)
+
+	var apiErr *types.Error
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.HTTPStatusCode)
+	assert.Equal(t, "not found", apiErr.Message)
+}
+
+// failingBody is a response body that fails to close.
+type failingBody struct{ io.Reader }
+
+func (failingBody) Close() error { return errors.New("close failed") }
+
+func TestCloseBodyLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	(&Client{logger: logger}).closeBody(&http.Response{Body: failingBody{}})
+	(&GatewayClient{logger: logger}).closeBody(&http.Response{Body: failingBody{}})
+	assert.Equal(t, 2, strings.Count(buf.String(), "close failed"))
+}
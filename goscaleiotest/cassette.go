@@ -0,0 +1,248 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleiotest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the secrets scrubbed from a cassette.
+const Redacted = "REDACTED"
+
+// Interaction is a request and its response, as stored in a cassette. A
+// cassette is a file with one JSON interaction per line.
+type Interaction struct {
+	Method       string `json:"method"`
+	URI          string `json:"uri"` // path and query, without the host
+	RequestBody  string `json:"requestBody,omitempty"`
+	Status       int    `json:"status"`
+	ContentType  string `json:"contentType,omitempty"`
+	ResponseBody string `json:"responseBody"`
+}
+
+func (i *Interaction) key() string {
+	return i.Method + " " + i.URI
+}
+
+// Recorder is an http.RoundTripper that sends requests with the next
+// transport and records them, for use as api.ClientOptions.Transport.
+// Request headers are not recorded, so neither are credentials and tokens
+// sent in them.
+type Recorder struct {
+	next http.RoundTripper
+
+	// Scrub, when set, is called on every interaction after ScrubInteraction,
+	// e.g. to hide IP addresses.
+	Scrub func(*Interaction)
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder returns a Recorder that sends requests with next, or
+// http.DefaultTransport when it is nil.
+func NewRecorder(next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interactions = append(r.interactions, Interaction{
+		Method:       req.Method,
+		URI:          req.URL.RequestURI(),
+		RequestBody:  string(reqBody),
+		Status:       resp.StatusCode,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ResponseBody: string(respBody),
+	})
+	return resp, nil
+}
+
+// Save writes the recorded interactions, scrubbed, to a cassette at path.
+func (r *Recorder) Save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	for _, i := range r.interactions {
+		ScrubInteraction(&i)
+		if r.Scrub != nil {
+			r.Scrub(&i)
+		}
+		if err := enc.Encode(&i); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o600)
+}
+
+// ScrubInteraction replaces the session token returned by /api/login and the
+// values of the JSON fields whose names contain "password", "token" or
+// "secret" with Redacted.
+func ScrubInteraction(i *Interaction) {
+	path, _, _ := strings.Cut(i.URI, "?")
+	if strings.HasSuffix(path, "/api/login") && i.Status == http.StatusOK {
+		i.ResponseBody = `"` + Redacted + `"`
+	}
+	i.RequestBody = scrubJSON(i.RequestBody)
+	i.ResponseBody = scrubJSON(i.ResponseBody)
+}
+
+// scrubJSON returns body with its secret fields redacted, or unchanged when
+// it is not JSON or has none.
+func scrubJSON(body string) string {
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	if !scrubValue(v) {
+		return body
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(b)
+}
+
+func scrubValue(v any) bool {
+	scrubbed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if _, ok := value.(string); ok && isSecret(key) {
+				v[key] = Redacted
+				scrubbed = true
+				continue
+			}
+			scrubbed = scrubValue(value) || scrubbed
+		}
+	case []any:
+		for _, value := range v {
+			scrubbed = scrubValue(value) || scrubbed
+		}
+	}
+	return scrubbed
+}
+
+func isSecret(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "password") || strings.Contains(key, "token") || strings.Contains(key, "secret")
+}
+
+// Replayer is an http.RoundTripper that answers requests from a cassette
+// instead of sending them. A request gets the next recorded response to the
+// same method and URI; bodies and headers are not compared.
+type Replayer struct {
+	mu        sync.Mutex
+	responses map[string][]Interaction
+}
+
+// LoadCassette returns a Replayer for the cassette at path.
+func LoadCassette(path string) (*Replayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := &Replayer{responses: map[string][]Interaction{}}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64*1024*1024)
+	for scanner.Scan() {
+		var i Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &i); err != nil {
+			return nil, fmt.Errorf("cassette %s: %w", path, err)
+		}
+		r.responses[i.key()] = append(r.responses[i.key()], i)
+	}
+	return r, scanner.Err()
+}
+
+// Remaining returns the number of recorded responses not replayed yet.
+func (r *Replayer) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for _, responses := range r.responses {
+		n += len(responses)
+	}
+	return n
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	closeBody(req)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := req.Method + " " + req.URL.RequestURI()
+	responses := r.responses[key]
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("goscaleiotest: no recorded response to %s", key)
+	}
+	i := responses[0]
+	r.responses[key] = responses[1:]
+
+	header := http.Header{}
+	if i.ContentType != "" {
+		header.Set("Content-Type", i.ContentType)
+	}
+	return newResponse(req, i.Status, header, []byte(i.ResponseBody)), nil
+}
@@ -0,0 +1,87 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleiotest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dell/goscaleio"
+	"github.com/dell/goscaleio/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordReplay(t *testing.T) {
+	srv := NewServer(Config{})
+	defer srv.Close()
+	systemID := srv.AddSystem("system")
+	srv.AddVolume(srv.AddStoragePool(srv.AddProtectionDomain(systemID, "pd"), "pool"), "vol", 1)
+
+	run := func(endpoint string, transport http.RoundTripper) []string {
+		client, err := goscaleio.NewClientWithOptions(endpoint, "", api.ClientOptions{Transport: transport})
+		assert.NoError(t, err)
+		_, err = client.Authenticate(&goscaleio.ConfigConnect{
+			Endpoint: endpoint, Username: DefaultUsername, Password: DefaultPassword,
+		})
+		assert.NoError(t, err)
+		vols, err := client.GetVolume("", "", "", "vol", false)
+		assert.NoError(t, err)
+		_, err = client.GetVolume("", "", "", "missing", false)
+		assert.NoError(t, err)
+
+		var ids []string
+		for _, v := range vols {
+			ids = append(ids, v.ID)
+		}
+		return ids
+	}
+
+	recorder := NewRecorder(nil)
+	recorded := run(srv.URL, recorder)
+	cassette := filepath.Join(t.TempDir(), "cassette.jsonl")
+	assert.NoError(t, recorder.Save(cassette))
+	srv.Close()
+
+	data, err := os.ReadFile(cassette)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"uri":"/api/login"`)
+	assert.Contains(t, string(data), Redacted)
+
+	replayer, err := LoadCassette(cassette)
+	assert.NoError(t, err)
+	assert.Equal(t, recorded, run("https://powerflex.invalid", replayer))
+	assert.Equal(t, 0, replayer.Remaining())
+
+	_, err = replayer.RoundTrip(httptest.NewRequest(http.MethodGet, "/api/version", nil))
+	assert.ErrorContains(t, err, "no recorded response to GET /api/version")
+}
+
+func TestScrubInteraction(t *testing.T) {
+	i := Interaction{
+		Method:       http.MethodPost,
+		URI:          "/rest/auth/login",
+		RequestBody:  `{"username":"admin","password":"Password123"}`,
+		Status:       http.StatusOK,
+		ResponseBody: `{"access_token":"a","refresh_token":"r","scope":"openid","expires_in":300}`,
+	}
+	ScrubInteraction(&i)
+	assert.JSONEq(t, `{"username":"admin","password":"REDACTED"}`, i.RequestBody)
+	assert.JSONEq(t, `{"access_token":"REDACTED","refresh_token":"REDACTED","scope":"openid","expires_in":300}`, i.ResponseBody)
+
+	i = Interaction{Method: http.MethodGet, URI: "/api/types/Volume/instances", ResponseBody: `[{"id":"1"}]`}
+	ScrubInteraction(&i)
+	assert.Equal(t, `[{"id":"1"}]`, i.ResponseBody)
+}
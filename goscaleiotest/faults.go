@@ -0,0 +1,242 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleiotest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Fault is what a FaultTransport does to a request. A fault with a Status
+// or an Err replaces the response; otherwise the request is sent and the
+// response altered.
+type Fault struct {
+	// Delay is waited before anything else. The request's context ends it.
+	Delay time.Duration
+
+	// Err is returned instead of sending the request.
+	Err error
+
+	// Status, Header and Body are returned instead of sending the request.
+	Status int
+	Header http.Header
+	Body   string
+
+	// TruncateBody, when positive, cuts the body of the response after that
+	// many bytes.
+	TruncateBody int
+}
+
+// Unauthorized is the reply of the array to an expired token.
+func Unauthorized() Fault {
+	return jsonFault(http.StatusUnauthorized, "Unauthorized")
+}
+
+// ServiceUnavailable is the reply of the gateway while the MDM cluster
+// switches over.
+func ServiceUnavailable() Fault {
+	return jsonFault(http.StatusServiceUnavailable, "Service Unavailable")
+}
+
+// HTMLError is an error page, as returned by PowerFlex 4.x proxies.
+func HTMLError(status int) Fault {
+	text := http.StatusText(status)
+	return Fault{
+		Status: status,
+		Header: http.Header{"Content-Type": {"text/html;charset=utf-8"}},
+		Body:   fmt.Sprintf("<html><head><title>%d %s</title></head><body><h1>%s</h1></body></html>", status, text, text),
+	}
+}
+
+// Slow delays the request.
+func Slow(d time.Duration) Fault {
+	return Fault{Delay: d}
+}
+
+// TruncatedBody cuts the body of the response after n bytes.
+func TruncatedBody(n int) Fault {
+	return Fault{TruncateBody: n}
+}
+
+// ConnectionReset fails the request as if the connection was reset.
+func ConnectionReset() Fault {
+	return Fault{Err: syscall.ECONNRESET}
+}
+
+func jsonFault(status int, message string) Fault {
+	return Fault{
+		Status: status,
+		Header: http.Header{"Content-Type": {"application/json;charset=UTF-8"}},
+		Body:   fmt.Sprintf(`{"message":%q,"httpStatusCode":%d,"errorCode":0}`, message, status),
+	}
+}
+
+// FaultRule selects the requests a fault is injected into.
+type FaultRule struct {
+	// Method matches the request method; any method when empty.
+	Method string
+	// Path matches the URL path; any path when nil.
+	Path *regexp.Regexp
+
+	// Nth, when positive, skips the first Nth-1 matching requests.
+	Nth int
+	// Times, when positive, is the most requests the fault is injected into.
+	Times int
+	// Probability is the chance that the fault is injected into a matching
+	// request, between 0 and 1. Zero means always.
+	Probability float64
+
+	Fault Fault
+}
+
+// FaultTransport is an http.RoundTripper that injects faults into the
+// requests that match its rules, for use as api.ClientOptions.Transport. The
+// first rule that fires applies; requests no rule fires for are sent by Next.
+//
+// The faults only depend on the seed and on the order of the requests, so a
+// test that sends its requests in the same order sees the same faults.
+type FaultTransport struct {
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	rules    []*faultRule
+	injected int
+}
+
+type faultRule struct {
+	FaultRule
+	matched int
+	fired   int
+}
+
+// NewFaultTransport returns a FaultTransport that sends requests with next,
+// or http.DefaultTransport when it is nil.
+func NewFaultTransport(next http.RoundTripper, seed uint64, rules ...FaultRule) *FaultTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	t := &FaultTransport{
+		next: next,
+		rand: rand.New(rand.NewPCG(seed, seed)),
+	}
+	for _, rule := range rules {
+		t.rules = append(t.rules, &faultRule{FaultRule: rule})
+	}
+	return t
+}
+
+// Injected returns the number of requests a fault was injected into.
+func (t *FaultTransport) Injected() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.injected
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault, ok := t.fault(req)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	if fault.Delay > 0 {
+		timer := time.NewTimer(fault.Delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			closeBody(req)
+			return nil, req.Context().Err()
+		}
+	}
+
+	switch {
+	case fault.Err != nil:
+		closeBody(req)
+		return nil, fault.Err
+	case fault.Status != 0:
+		closeBody(req)
+		return newResponse(req, fault.Status, fault.Header.Clone(), []byte(fault.Body)), nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || fault.TruncateBody <= 0 {
+		return resp, err
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(fault.TruncateBody)))
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Header.Del("Content-Length")
+	return newResponse(req, resp.StatusCode, resp.Header, body), nil
+}
+
+// fault returns the fault of the first rule that fires for req.
+func (t *FaultTransport) fault(req *http.Request) (Fault, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, rule := range t.rules {
+		if rule.Method != "" && rule.Method != req.Method {
+			continue
+		}
+		if rule.Path != nil && !rule.Path.MatchString(req.URL.Path) {
+			continue
+		}
+		rule.matched++
+		if rule.matched < rule.Nth || (rule.Times > 0 && rule.fired >= rule.Times) {
+			continue
+		}
+		if rule.Probability > 0 && t.rand.Float64() >= rule.Probability {
+			continue
+		}
+		rule.fired++
+		t.injected++
+		return rule.Fault, true
+	}
+	return Fault{}, false
+}
+
+func newResponse(req *http.Request, status int, header http.Header, body []byte) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleiotest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/dell/goscaleio"
+	"github.com/dell/goscaleio/api"
+	"github.com/stretchr/testify/assert"
+)
+
+var systemsPath = regexp.MustCompile(`/api/types/System/instances$`)
+
+func newFaultyClient(t *testing.T, srv *Server, transport *FaultTransport, policy *api.RetryPolicy) *goscaleio.Client {
+	client, err := goscaleio.NewClientWithOptions(srv.URL, "", api.ClientOptions{
+		Transport:   transport,
+		RetryPolicy: policy,
+	})
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&goscaleio.ConfigConnect{
+		Endpoint: srv.URL, Username: DefaultUsername, Password: DefaultPassword,
+	})
+	assert.NoError(t, err)
+	return client
+}
+
+func TestFaultTransport(t *testing.T) {
+	srv := NewServer(Config{})
+	defer srv.Close()
+	srv.AddSystem("system")
+
+	cases := map[string]struct {
+		fault   Fault
+		policy  *api.RetryPolicy
+		wantErr string
+	}{
+		"401 mid-session": {fault: Unauthorized()},
+		"503 retried": {
+			fault:  ServiceUnavailable(),
+			policy: &api.RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: []int{http.StatusServiceUnavailable}},
+		},
+		"html":      {fault: HTMLError(http.StatusBadGateway), wantErr: "502 Bad Gateway"},
+		"truncated": {fault: TruncatedBody(10), wantErr: "unexpected EOF"},
+		"reset":     {fault: ConnectionReset(), wantErr: syscall.ECONNRESET.Error()},
+		"slow":      {fault: Slow(10 * time.Millisecond)},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			transport := NewFaultTransport(nil, 1, FaultRule{
+				Method: http.MethodGet, Path: systemsPath, Nth: 2, Times: 1, Fault: tc.fault,
+			})
+			client := newFaultyClient(t, srv, transport, tc.policy)
+
+			_, err := client.GetSystems()
+			assert.NoError(t, err)
+			_, err = client.GetSystems()
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			_, err = client.GetSystems()
+			assert.NoError(t, err)
+			assert.Equal(t, 1, transport.Injected())
+		})
+	}
+}
+
+func TestFaultTransportSeed(t *testing.T) {
+	srv := NewServer(Config{})
+	defer srv.Close()
+	srv.AddSystem("system")
+
+	run := func(seed uint64) string {
+		transport := NewFaultTransport(nil, seed, FaultRule{
+			Path: systemsPath, Probability: 0.5, Fault: HTMLError(http.StatusInternalServerError),
+		})
+		client := newFaultyClient(t, srv, transport, nil)
+		pattern := ""
+		for range 32 {
+			if _, err := client.GetSystems(); err != nil {
+				pattern += "1"
+			} else {
+				pattern += "0"
+			}
+		}
+		return pattern
+	}
+
+	pattern := run(42)
+	assert.Equal(t, pattern, run(42))
+	assert.Contains(t, pattern, "0")
+	assert.Contains(t, pattern, "1")
+}
+
+func TestFaultTransportContext(t *testing.T) {
+	transport := NewFaultTransport(nil, 1, FaultRule{Fault: Slow(time.Minute)})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost", nil)
+	_, err := transport.RoundTrip(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFaultTransportGateway(t *testing.T) {
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/auth/login":
+			fmt.Fprintln(w, `{"access_token":"token"}`)
+		case "/api/version":
+			fmt.Fprintln(w, "4.0")
+		}
+	}))
+	defer gateway.Close()
+
+	transport := NewFaultTransport(nil, 1, FaultRule{
+		Path: regexp.MustCompile(`^/api/version$`), Fault: HTMLError(http.StatusServiceUnavailable),
+	})
+	_, err := goscaleio.NewGatewayWithOptions(context.Background(), gateway.URL, "admin", "password", api.ClientOptions{
+		Transport: transport,
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, transport.Injected())
+
+	_, err = goscaleio.NewGatewayWithOptions(context.Background(), gateway.URL, "admin", "password", api.ClientOptions{
+		Transport: NewFaultTransport(nil, 1),
+	})
+	assert.NoError(t, err)
+}
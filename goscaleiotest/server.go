@@ -0,0 +1,852 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package goscaleiotest provides an in-process fake of the PowerFlex REST
+// API, for testing code that uses goscaleio without an array.
+//
+// The server keeps systems, protection domains, storage pools, volumes,
+// snapshots, SDCs and SDSs in memory. It serves login, with the /rest/auth
+// sessions of the 4.x management platform from version 4.0, the instances
+// of each type, their relationships and the actions that create, rename, resize,
+// map, snapshot and remove them, and rejects invalid requests with the error
+// payloads of the array:
+//
+//	srv := goscaleiotest.NewServer(goscaleiotest.Config{})
+//	defer srv.Close()
+//	systemID := srv.AddSystem("system")
+//	pdID := srv.AddProtectionDomain(systemID, "pd")
+//	srv.AddStoragePool(pdID, "pool")
+//
+//	client, _ := goscaleio.NewClientWithArgs(srv.URL, "", math.MaxInt64, true, false)
+//	_, err := client.Authenticate(&goscaleio.ConfigConnect{
+//		Endpoint: srv.URL, Username: goscaleiotest.DefaultUsername, Password: goscaleiotest.DefaultPassword,
+//	})
+//
+// FaultTransport injects failures, such as expired tokens, error pages and
+// truncated bodies, into the requests of a client.
+package goscaleiotest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	types "github.com/dell/goscaleio/types/v1"
+	"github.com/google/uuid"
+)
+
+// Credentials and version used when Config leaves them empty.
+const (
+	DefaultUsername = "admin"
+	DefaultPassword = "Password123!"
+	DefaultVersion  = "4.5"
+)
+
+// volumeGranularityKb is the unit volume sizes are rounded up to, 8 GiB.
+const volumeGranularityKb = 8 * 1024 * 1024
+
+// Config configures a Server.
+type Config struct {
+	// Username and Password are the accepted credentials.
+	Username string
+	Password string
+	// Version is returned by /api/version, e.g. "3.6" or "4.5".
+	Version string
+}
+
+// Server is a fake PowerFlex REST API. Its methods may be called while the
+// client under test uses it.
+type Server struct {
+	*httptest.Server
+
+	config Config
+
+	mu      sync.Mutex
+	tokens  map[string]bool
+	refresh map[string]bool // refresh tokens of the /rest/auth sessions
+	nextID  uint64
+	objects map[string]map[string]*object // by type, then ID
+}
+
+// object is an instance of one of the emulated types.
+type object struct {
+	value   any               // *types.Volume, *types.Sdc, ...
+	parents map[string]string // IDs of the parents, by type
+}
+
+// kind describes an emulated type.
+type kind struct {
+	parent    string   // type of the direct parent
+	nameScope string   // type of the parent names are unique within
+	children  []string // types listed by the relationships links
+	notFound  string   // error code when the ID does not exist
+	nameInUse string   // error code when the name is taken
+}
+
+var kinds = map[string]kind{
+	"System": {
+		children: []string{"ProtectionDomain", "Sdc"},
+		notFound: "NOT_FOUND",
+	},
+	"ProtectionDomain": {
+		parent: "System", nameScope: "System",
+		children: []string{"StoragePool", "Sds"},
+		notFound: "FD_NOT_FOUND", nameInUse: "FD_NAME_IN_USE",
+	},
+	"StoragePool": {
+		parent: "ProtectionDomain", nameScope: "ProtectionDomain",
+		children: []string{"Volume"},
+		notFound: "STORAGE_POOL_NOT_FOUND", nameInUse: "STORAGE_POOL_NAME_ALREADY_EXISTS",
+	},
+	"Volume": {
+		parent: "StoragePool", nameScope: "System",
+		notFound: "VOL_NOT_FOUND", nameInUse: "VOL_NAME_IN_USE",
+	},
+	"Sdc": {
+		parent: "System", nameScope: "System",
+		children: []string{"Volume"},
+		notFound: "INI_NOT_FOUND", nameInUse: "INI_NAME_IN_USE",
+	},
+	"Sds": {
+		parent: "ProtectionDomain", nameScope: "System",
+		notFound: "TGT_NOT_FOUND", nameInUse: "TGT_NAME_IN_USE",
+	},
+}
+
+// apiError is a failed request, reported to the client as a types.Error.
+type apiError struct {
+	status  int
+	message string
+}
+
+// errorf returns the error of the array with the given code, such as
+// "VOL_NOT_FOUND". The MDM reports most errors with status 500.
+func errorf(status int, code string) *apiError {
+	return &apiError{status: status, message: types.TranslateErrorCodeToErrorMessage(code)}
+}
+
+// NewServer starts a Server with no objects. Call Close when done.
+func NewServer(config Config) *Server {
+	if config.Username == "" {
+		config.Username = DefaultUsername
+	}
+	if config.Password == "" {
+		config.Password = DefaultPassword
+	}
+	if config.Version == "" {
+		config.Version = DefaultVersion
+	}
+
+	s := &Server{
+		config:  config,
+		tokens:  map[string]bool{},
+		refresh: map[string]bool{},
+		objects: map[string]map[string]*object{},
+	}
+	for name := range kinds {
+		s.objects[name] = map[string]*object{}
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddSystem adds a system and returns its ID.
+func (s *Server) AddSystem(name string) string {
+	return s.mustAdd("System", "", &types.System{Name: name, SystemVersionName: "DellEMC PowerFlex Version: R" + s.config.Version})
+}
+
+// AddProtectionDomain adds a protection domain to a system and returns its ID.
+func (s *Server) AddProtectionDomain(systemID, name string) string {
+	return s.mustAdd("ProtectionDomain", systemID, &types.ProtectionDomain{
+		Name: name, SystemID: systemID, ProtectionDomainState: "Active",
+	})
+}
+
+// AddStoragePool adds a storage pool to a protection domain and returns its ID.
+func (s *Server) AddStoragePool(protectionDomainID, name string) string {
+	return s.mustAdd("StoragePool", protectionDomainID, &types.StoragePool{
+		Name: name, ProtectionDomainID: protectionDomainID, DataLayout: "MediumGranularity",
+	})
+}
+
+// AddVolume adds a thin volume to a storage pool and returns its ID. The
+// size is rounded up to a multiple of 8 GiB, as the array does.
+func (s *Server) AddVolume(storagePoolID, name string, sizeInKb int) string {
+	return s.mustAdd("Volume", storagePoolID, &types.Volume{
+		Name: name, StoragePoolID: storagePoolID, SizeInKb: roundSize(sizeInKb),
+		VolumeType: "ThinProvisioned",
+	})
+}
+
+// AddSdc adds an approved SDC to a system and returns its ID.
+func (s *Server) AddSdc(systemID, name, ip string) string {
+	return s.mustAdd("Sdc", systemID, &types.Sdc{
+		Name: name, SdcIP: ip, SdcIPs: []string{ip}, SdcGUID: uuid.NewString(),
+		SdcApproved: true, MdmConnectionState: "Connected", SystemID: systemID,
+	})
+}
+
+// AddSds adds an SDS to a protection domain and returns its ID.
+func (s *Server) AddSds(protectionDomainID, name string) string {
+	return s.mustAdd("Sds", protectionDomainID, &types.Sds{
+		Name: name, ProtectionDomainID: protectionDomainID,
+		SdsState: "Normal", MembershipState: "Joined", MdmConnectionState: "Connected",
+	})
+}
+
+// Volume returns a copy of the volume or snapshot with the given ID.
+func (s *Server) Volume(id string) (types.Volume, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.objects["Volume"][id]
+	if !ok {
+		return types.Volume{}, false
+	}
+	var v types.Volume
+	b, _ := json.Marshal(o.value)
+	_ = json.Unmarshal(b, &v)
+	return v, true
+}
+
+// InvalidateTokens logs out every session, so that the next request of a
+// client fails with 401 and it has to log in again.
+func (s *Server) InvalidateTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.tokens)
+	clear(s.refresh)
+}
+
+func (s *Server) mustAdd(typ, parentID string, value any) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := s.add(typ, parentID, value)
+	if err != nil {
+		panic(fmt.Sprintf("goscaleiotest: adding %s: %s", typ, err.message))
+	}
+	return id
+}
+
+// add stores value, a pointer to a types struct, as a child of parentID.
+func (s *Server) add(typ, parentID string, value any) (string, *apiError) {
+	k := kinds[typ]
+	parents := map[string]string{}
+	if k.parent != "" {
+		parent, ok := s.objects[k.parent][parentID]
+		if !ok {
+			return "", errorf(http.StatusInternalServerError, kinds[k.parent].notFound)
+		}
+		parents[k.parent] = parentID
+		for t, id := range parent.parents {
+			parents[t] = id
+		}
+	}
+
+	o := &object{value: value, parents: parents}
+	if s.nameTaken(typ, o, nameOf(o)) {
+		return "", errorf(http.StatusInternalServerError, k.nameInUse)
+	}
+
+	s.nextID++
+	id := fmt.Sprintf("%016x", s.nextID)
+	field(o, "ID").SetString(id)
+	field(o, "Links").Set(reflect.ValueOf(s.links(typ, id, parents)))
+	if v, ok := value.(*types.Volume); ok && v.VTreeID == "" {
+		v.VTreeID = id
+	}
+	s.objects[typ][id] = o
+	return id, nil
+}
+
+// nameTaken reports whether another object of the type has the name within
+// the scope of the type.
+func (s *Server) nameTaken(typ string, o *object, name string) bool {
+	scope := kinds[typ].nameScope
+	if name == "" || scope == "" {
+		return false
+	}
+	for _, other := range s.objects[typ] {
+		if other != o && other.parents[scope] == o.parents[scope] && nameOf(other) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) links(typ, id string, parents map[string]string) []*types.Link {
+	self := instanceHREF(typ, id)
+	links := []*types.Link{{Rel: "self", HREF: self}}
+	if parent := kinds[typ].parent; parent != "" {
+		links = append(links, &types.Link{
+			Rel:  "/api/parent/relationship/" + strings.ToLower(parent[:1]) + parent[1:] + "Id",
+			HREF: instanceHREF(parent, parents[parent]),
+		})
+	}
+	for _, child := range kinds[typ].children {
+		links = append(links, &types.Link{
+			Rel:  fmt.Sprintf("/api/%s/relationship/%s", typ, child),
+			HREF: fmt.Sprintf("%s/relationships/%s", self, child),
+		})
+	}
+	return links
+}
+
+func instanceHREF(typ, id string) string {
+	return fmt.Sprintf("/api/instances/%s::%s", typ, id)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if action, ok := strings.CutPrefix(r.URL.Path, "/rest/auth/"); ok {
+		s.restAuth(w, r, action)
+		return
+	}
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/"), "/")
+	if path == "login" {
+		s.login(w, r)
+		return
+	}
+	if !s.authorized(r) {
+		writeJSON(w, http.StatusUnauthorized, &types.Error{
+			Message: "Unauthorized", HTTPStatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	resp, err := s.route(r, strings.Split(path, "/"))
+	switch {
+	case err != nil:
+		writeJSON(w, err.status, &types.Error{
+			Message:        err.message,
+			HTTPStatusCode: err.status,
+		})
+	case resp == nil:
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	username, password, ok := r.BasicAuth()
+	if !ok || username != s.config.Username || password != s.config.Password {
+		writeJSON(w, http.StatusUnauthorized, &types.Error{
+			Message: "Unauthorized", HTTPStatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+	token := uuid.NewString()
+	s.tokens[token] = true
+	writeJSON(w, http.StatusOK, token)
+}
+
+// restAuth serves the login, refresh and logout of the sessions of the
+// management platform, which 3.x arrays do not have.
+func (s *Server) restAuth(w http.ResponseWriter, r *http.Request, action string) {
+	major, _, _ := strings.Cut(s.config.Version, ".")
+	if n, _ := strconv.Atoi(major); n < 4 || r.Method != http.MethodPost {
+		writeJSON(w, http.StatusNotFound, &types.Error{
+			Message: "Not Found", HTTPStatusCode: http.StatusNotFound,
+		})
+		return
+	}
+
+	var body struct {
+		Username     string `json:"username"`
+		Password     string `json:"password"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeJSON(w, err.status, &types.Error{Message: err.message, HTTPStatusCode: err.status})
+		return
+	}
+	unauthorized := func() {
+		writeJSON(w, http.StatusUnauthorized, &types.Error{
+			Message: "Unauthorized", HTTPStatusCode: http.StatusUnauthorized,
+		})
+	}
+
+	switch action {
+	case "login":
+		if body.Username != s.config.Username || body.Password != s.config.Password {
+			unauthorized()
+			return
+		}
+		token, refresh := uuid.NewString(), uuid.NewString()
+		s.tokens[token] = true
+		s.refresh[refresh] = true
+		writeJSON(w, http.StatusOK, map[string]string{"access_token": token, "refresh_token": refresh})
+	case "refresh":
+		if !s.refresh[body.RefreshToken] {
+			unauthorized()
+			return
+		}
+		token := uuid.NewString()
+		s.tokens[token] = true
+		writeJSON(w, http.StatusOK, map[string]string{"access_token": token})
+	case "logout":
+		if !s.authorized(r) {
+			unauthorized()
+			return
+		}
+		delete(s.refresh, body.RefreshToken)
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			delete(s.tokens, token)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeJSON(w, http.StatusNotFound, &types.Error{
+			Message: "Not Found", HTTPStatusCode: http.StatusNotFound,
+		})
+	}
+}
+
+// authorized accepts the token as a Bearer token, as sent to 4.x arrays, or
+// as the password of basic auth, as sent to 3.x arrays.
+func (s *Server) authorized(r *http.Request) bool {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return s.tokens[token]
+	}
+	_, token, ok := r.BasicAuth()
+	return ok && s.tokens[token]
+}
+
+func (s *Server) route(r *http.Request, parts []string) (any, *apiError) {
+	get, post := r.Method == http.MethodGet, r.Method == http.MethodPost
+	switch {
+	case len(parts) == 1 && parts[0] == "version" && get:
+		return s.config.Version, nil
+
+	case len(parts) >= 3 && parts[0] == "types" && parts[2] == "instances":
+		typ := parts[1]
+		if _, ok := kinds[typ]; !ok {
+			return nil, notEmulated(r)
+		}
+		switch {
+		case len(parts) == 3 && get:
+			return s.list(typ, func(*object) bool { return true }), nil
+		case len(parts) == 3 && post:
+			return s.create(r, typ)
+		case len(parts) == 5 && parts[3] == "action" && post:
+			return s.typeAction(r, typ, parts[4])
+		}
+
+	case len(parts) >= 2 && parts[0] == "instances":
+		typ, id, ok := strings.Cut(parts[1], "::")
+		if _, known := kinds[typ]; !ok || !known {
+			return nil, notEmulated(r)
+		}
+		o, found := s.objects[typ][id]
+		if !found {
+			return nil, errorf(http.StatusInternalServerError, kinds[typ].notFound)
+		}
+		switch {
+		case len(parts) == 2 && get:
+			return o.value, nil
+		case len(parts) == 4 && parts[2] == "relationships" && get:
+			return s.relationship(typ, id, parts[3], r)
+		case len(parts) == 4 && parts[2] == "action" && post:
+			return s.instanceAction(r, typ, id, o, parts[3])
+		}
+	}
+	return nil, notEmulated(r)
+}
+
+// notEmulated fails requests the server does not implement. The status is
+// not one the array returns, so that such tests fail clearly.
+func notEmulated(r *http.Request) *apiError {
+	return &apiError{
+		status:  http.StatusNotImplemented,
+		message: fmt.Sprintf("goscaleiotest: %s %s is not emulated", r.Method, r.URL.Path),
+	}
+}
+
+// list returns the objects of the type that match, in creation order.
+func (s *Server) list(typ string, match func(*object) bool) []any {
+	ids := make([]string, 0, len(s.objects[typ]))
+	for id, o := range s.objects[typ] {
+		if match(o) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	values := make([]any, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, s.objects[typ][id].value)
+	}
+	return values
+}
+
+func (s *Server) relationship(typ, id, rel string, r *http.Request) (any, *apiError) {
+	if _, ok := kinds[rel]; !ok {
+		return nil, notEmulated(r)
+	}
+	if typ == "Sdc" && rel == "Volume" {
+		return s.list("Volume", func(o *object) bool {
+			return mapping(o.value.(*types.Volume), id) >= 0
+		}), nil
+	}
+	return s.list(rel, func(o *object) bool { return o.parents[typ] == id }), nil
+}
+
+func (s *Server) create(r *http.Request, typ string) (any, *apiError) {
+	var (
+		id  string
+		err *apiError
+	)
+	switch typ {
+	case "ProtectionDomain":
+		var param types.ProtectionDomainParam
+		if err = decode(r, &param); err != nil {
+			return nil, err
+		}
+		systems := s.list("System", func(*object) bool { return true })
+		if len(systems) == 0 {
+			return nil, errorf(http.StatusInternalServerError, "NOT_FOUND")
+		}
+		systemID := systems[0].(*types.System).ID
+		id, err = s.add(typ, systemID, &types.ProtectionDomain{
+			Name: param.Name, SystemID: systemID, ProtectionDomainState: "Active",
+		})
+	case "StoragePool":
+		var param types.StoragePoolParam
+		if err = decode(r, &param); err != nil {
+			return nil, err
+		}
+		id, err = s.add(typ, param.ProtectionDomainID, &types.StoragePool{
+			Name: param.Name, ProtectionDomainID: param.ProtectionDomainID, DataLayout: "MediumGranularity",
+		})
+	case "Volume":
+		var param types.VolumeParam
+		if err = decode(r, &param); err != nil {
+			return nil, err
+		}
+		size, convErr := strconv.Atoi(param.VolumeSizeInKb)
+		if convErr != nil || size <= 0 {
+			return nil, errorf(http.StatusInternalServerError, "VOL_SIZE_ILLEGAL")
+		}
+		volumeType := param.VolumeType
+		if volumeType == "" {
+			volumeType = "ThickProvisioned"
+		}
+		id, err = s.add(typ, param.StoragePoolID, &types.Volume{
+			Name: param.Name, StoragePoolID: param.StoragePoolID, SizeInKb: roundSize(size),
+			VolumeType: volumeType, CompressionMethod: param.CompressionMethod,
+		})
+	case "Sds":
+		var param types.SdsParam
+		if err = decode(r, &param); err != nil {
+			return nil, err
+		}
+		sds := &types.Sds{
+			Name: param.Name, ProtectionDomainID: param.ProtectionDomainID,
+			SdsState: "Normal", MembershipState: "Joined", MdmConnectionState: "Connected",
+		}
+		for _, ip := range param.IPList {
+			sds.IPList = append(sds.IPList, &types.SdsIP{IP: ip.SdsIP.IP, Role: ip.SdsIP.Role})
+		}
+		id, err = s.add(typ, param.ProtectionDomainID, sds)
+	default:
+		return nil, notEmulated(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"id": id}, nil
+}
+
+func (s *Server) typeAction(r *http.Request, typ, action string) (any, *apiError) {
+	switch action {
+	case "queryIdByKey":
+		var param struct {
+			Name string `json:"name"`
+		}
+		if err := decode(r, &param); err != nil {
+			return nil, err
+		}
+		for id, o := range s.objects[typ] {
+			if nameOf(o) == param.Name {
+				return id, nil
+			}
+		}
+		return nil, errorf(http.StatusInternalServerError, "NOT_FOUND")
+	case "queryBySelectedIds":
+		var param struct {
+			IDs []string `json:"ids"`
+		}
+		if err := decode(r, &param); err != nil {
+			return nil, err
+		}
+		values := []any{}
+		for _, id := range param.IDs {
+			if o, ok := s.objects[typ][id]; ok {
+				values = append(values, o.value)
+			}
+		}
+		return values, nil
+	}
+	return nil, notEmulated(r)
+}
+
+func (s *Server) instanceAction(r *http.Request, typ, id string, o *object, action string) (any, *apiError) {
+	switch action {
+	case "setProtectionDomainName", "setStoragePoolName", "setVolumeName", "setSdcName", "setSdsName":
+		var param struct {
+			Name    string `json:"name"`
+			NewName string `json:"newName"`
+			SdcName string `json:"sdcName"`
+		}
+		if err := decode(r, &param); err != nil {
+			return nil, err
+		}
+		newName := cmp.Or(param.NewName, param.Name, param.SdcName)
+		if s.nameTaken(typ, o, newName) {
+			return nil, errorf(http.StatusInternalServerError, kinds[typ].nameInUse)
+		}
+		field(o, "Name").SetString(newName)
+		return nil, nil
+
+	case "removeProtectionDomain":
+		if s.hasChildren("StoragePool", typ, id) {
+			return nil, errorf(http.StatusInternalServerError, "FD_HAS_STORAGE_POOLS")
+		}
+		if s.hasChildren("Sds", typ, id) {
+			return nil, errorf(http.StatusInternalServerError, "FD_HAS_TGTS")
+		}
+		delete(s.objects[typ], id)
+		return nil, nil
+
+	case "removeStoragePool":
+		if s.hasChildren("Volume", typ, id) {
+			return nil, errorf(http.StatusInternalServerError, "STORAGE_POOL_HAS_VOLS")
+		}
+		delete(s.objects[typ], id)
+		return nil, nil
+
+	case "removeSds":
+		delete(s.objects[typ], id)
+		return nil, nil
+
+	case "removeSdc":
+		for _, v := range s.objects["Volume"] {
+			if mapping(v.value.(*types.Volume), id) >= 0 {
+				return nil, errorf(http.StatusInternalServerError, "INI_HAS_MAPPINGS")
+			}
+		}
+		delete(s.objects[typ], id)
+		return nil, nil
+
+	case "snapshotVolumes":
+		if typ != "System" {
+			break
+		}
+		var param types.SnapshotVolumesParam
+		if err := decode(r, &param); err != nil {
+			return nil, err
+		}
+		return s.snapshotVolumes(&param)
+	}
+
+	if typ == "Volume" {
+		return s.volumeAction(r, id, o.value.(*types.Volume), action)
+	}
+	return nil, notEmulated(r)
+}
+
+func (s *Server) volumeAction(r *http.Request, id string, v *types.Volume, action string) (any, *apiError) {
+	switch action {
+	case "setVolumeSize":
+		var param types.SetVolumeSizeParam
+		if err := decode(r, &param); err != nil {
+			return nil, err
+		}
+		size, convErr := strconv.Atoi(param.SizeInGB)
+		if convErr != nil || size*1024*1024 < v.SizeInKb {
+			return nil, errorf(http.StatusInternalServerError, "VOL_SIZE_ILLEGAL")
+		}
+		v.SizeInKb = roundSize(size * 1024 * 1024)
+		return nil, nil
+
+	case "addMappedSdc":
+		var param types.MapVolumeSdcParam
+		if err := decode(r, &param); err != nil {
+			return nil, err
+		}
+		sdc, ok := s.objects["Sdc"][param.SdcID]
+		switch {
+		case v.MappingToAllSdcsEnabled:
+			return nil, errorf(http.StatusInternalServerError, "VOL_ALREADY_MAPPED_TO_ALL_INIS")
+		case strings.EqualFold(param.AllSdcs, "TRUE"):
+			v.MappingToAllSdcsEnabled = true
+			return nil, nil
+		case !ok:
+			return nil, errorf(http.StatusInternalServerError, "INI_NOT_FOUND")
+		case mapping(v, param.SdcID) >= 0:
+			return nil, errorf(http.StatusInternalServerError, "VOL_ALREADY_MAPPED_TO_THIS_INI")
+		case len(v.MappedSdcInfo) > 0 && !strings.EqualFold(param.AllowMultipleMappings, "TRUE"):
+			return nil, errorf(http.StatusInternalServerError, "VOL_ALREADY_MAPPED_TO_AN_INI")
+		}
+		accessMode := param.AccessMode
+		if accessMode == "" {
+			accessMode = "ReadWrite"
+		}
+		info := sdc.value.(*types.Sdc)
+		v.MappedSdcInfo = append(v.MappedSdcInfo, &types.MappedSdcInfo{
+			SdcID: info.ID, SdcIP: info.SdcIP, SdcName: info.Name, AccessMode: accessMode,
+		})
+		return nil, nil
+
+	case "removeMappedSdc":
+		var param types.UnmapVolumeSdcParam
+		if err := decode(r, &param); err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(param.AllSdcs, "TRUE") {
+			v.MappingToAllSdcsEnabled = false
+			v.MappedSdcInfo = nil
+			return nil, nil
+		}
+		if _, ok := s.objects["Sdc"][param.SdcID]; !ok {
+			return nil, errorf(http.StatusInternalServerError, "INI_NOT_FOUND")
+		}
+		i := mapping(v, param.SdcID)
+		if i < 0 {
+			return nil, errorf(http.StatusInternalServerError, "VOL_NOT_MAPPED_TO_INI")
+		}
+		v.MappedSdcInfo = slices.Delete(v.MappedSdcInfo, i, i+1)
+		return nil, nil
+
+	case "removeVolume":
+		var param types.RemoveVolumeParam
+		if err := decode(r, &param); err != nil {
+			return nil, err
+		}
+		if len(v.MappedSdcInfo) > 0 || v.MappingToAllSdcsEnabled {
+			return nil, errorf(http.StatusInternalServerError, "VOL_MAPPED")
+		}
+		switch param.RemoveMode {
+		case "", "ONLY_ME":
+			delete(s.objects["Volume"], id)
+		case "INCLUDING_DESCENDANTS":
+			s.removeDescendants(id)
+			delete(s.objects["Volume"], id)
+		case "DESCENDANTS_ONLY":
+			s.removeDescendants(id)
+		case "WHOLE_VTREE":
+			for vid, o := range s.objects["Volume"] {
+				if o.value.(*types.Volume).VTreeID == v.VTreeID {
+					delete(s.objects["Volume"], vid)
+				}
+			}
+		default:
+			return nil, errorf(http.StatusBadRequest, "INVALID_ARG")
+		}
+		return nil, nil
+	}
+	return nil, notEmulated(r)
+}
+
+func (s *Server) snapshotVolumes(param *types.SnapshotVolumesParam) (any, *apiError) {
+	for _, def := range param.SnapshotDefs {
+		if _, ok := s.objects["Volume"][def.VolumeID]; !ok {
+			return nil, errorf(http.StatusInternalServerError, "VOL_NOT_FOUND")
+		}
+	}
+
+	s.nextID++
+	resp := &types.SnapshotVolumesResp{SnapshotGroupID: fmt.Sprintf("%016x", s.nextID)}
+	for _, def := range param.SnapshotDefs {
+		source := s.objects["Volume"][def.VolumeID].value.(*types.Volume)
+		accessMode := param.AccessMode
+		if accessMode == "" {
+			accessMode = "ReadOnly"
+		}
+		id, err := s.add("Volume", source.StoragePoolID, &types.Volume{
+			Name: def.SnapshotName, StoragePoolID: source.StoragePoolID, SizeInKb: source.SizeInKb,
+			VolumeType: "Snapshot", AncestorVolumeID: source.ID, VTreeID: source.VTreeID,
+			ConsistencyGroupID: resp.SnapshotGroupID, AccessModeLimit: accessMode,
+		})
+		if err != nil {
+			// the array does not create any snapshot when one fails
+			for _, created := range resp.VolumeIDList {
+				delete(s.objects["Volume"], created)
+			}
+			return nil, err
+		}
+		resp.VolumeIDList = append(resp.VolumeIDList, id)
+	}
+	return resp, nil
+}
+
+func (s *Server) removeDescendants(id string) {
+	for vid, o := range s.objects["Volume"] {
+		if o.value.(*types.Volume).AncestorVolumeID == id {
+			s.removeDescendants(vid)
+			delete(s.objects["Volume"], vid)
+		}
+	}
+}
+
+func (s *Server) hasChildren(typ, parentType, parentID string) bool {
+	for _, o := range s.objects[typ] {
+		if o.parents[parentType] == parentID {
+			return true
+		}
+	}
+	return false
+}
+
+// mapping returns the index of the SDC in the mappings of the volume, or -1.
+func mapping(v *types.Volume, sdcID string) int {
+	return slices.IndexFunc(v.MappedSdcInfo, func(m *types.MappedSdcInfo) bool {
+		return m.SdcID == sdcID
+	})
+}
+
+// roundSize rounds a size in KiB up to the volume granularity.
+func roundSize(sizeInKb int) int {
+	return (sizeInKb + volumeGranularityKb - 1) / volumeGranularityKb * volumeGranularityKb
+}
+
+func field(o *object, name string) reflect.Value {
+	return reflect.ValueOf(o.value).Elem().FieldByName(name)
+}
+
+func nameOf(o *object) string {
+	return field(o, "Name").String()
+}
+
+func decode(r *http.Request, v any) *apiError {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errorf(http.StatusBadRequest, "INVALID_ARG")
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
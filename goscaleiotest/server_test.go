@@ -0,0 +1,153 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleiotest
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/dell/goscaleio"
+	types "github.com/dell/goscaleio/types/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(t *testing.T, srv *Server, password string) (*goscaleio.Client, error) {
+	client, err := goscaleio.NewClientWithArgs(srv.URL, "", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&goscaleio.ConfigConnect{
+		Endpoint: srv.URL, Username: DefaultUsername, Password: password,
+	})
+	return client, err
+}
+
+func TestServerVolumeLifecycle(t *testing.T) {
+	srv := NewServer(Config{})
+	defer srv.Close()
+	systemID := srv.AddSystem("system")
+	pdID := srv.AddProtectionDomain(systemID, "pd")
+	srv.AddStoragePool(pdID, "pool")
+	sdcID := srv.AddSdc(systemID, "sdc", "10.0.0.1")
+
+	client, err := newClient(t, srv, DefaultPassword)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultVersion, client.GetConfigConnect().Version)
+
+	system, err := client.FindSystem(systemID, "", "")
+	assert.NoError(t, err)
+	pd, err := system.FindProtectionDomain("", "pd", "")
+	assert.NoError(t, err)
+	pool, err := goscaleio.NewProtectionDomainEx(client, pd).FindStoragePool("", "pool", "")
+	assert.NoError(t, err)
+	sp := goscaleio.NewStoragePoolEx(client, pool)
+
+	resp, err := sp.CreateVolume(&types.VolumeParam{Name: "vol", VolumeSizeInKb: "1048576"})
+	assert.NoError(t, err)
+	_, err = sp.CreateVolume(&types.VolumeParam{Name: "vol", VolumeSizeInKb: "1048576"})
+	assert.ErrorIs(t, err, goscaleio.ErrAlreadyExists)
+
+	vols, err := client.GetVolume("", "", "", "vol", false)
+	assert.NoError(t, err)
+	assert.Len(t, vols, 1)
+	assert.Equal(t, resp.ID, vols[0].ID)
+	assert.Equal(t, 8*1024*1024, vols[0].SizeInKb)
+
+	volume := goscaleio.NewVolume(client)
+	volume.Volume = vols[0]
+	assert.NoError(t, volume.MapVolumeSdc(&types.MapVolumeSdcParam{SdcID: sdcID}))
+	assert.ErrorIs(t, volume.MapVolumeSdc(&types.MapVolumeSdcParam{SdcID: sdcID}), goscaleio.ErrAlreadyMapped)
+	sdc, err := system.GetSdcByID(sdcID)
+	assert.NoError(t, err)
+	mapped, err := sdc.GetVolume()
+	assert.NoError(t, err)
+	assert.Len(t, mapped, 1)
+
+	snap, err := system.CreateSnapshotConsistencyGroup(&types.SnapshotVolumesParam{
+		SnapshotDefs: []*types.SnapshotDef{{VolumeID: resp.ID, SnapshotName: "snap"}},
+	})
+	assert.NoError(t, err)
+	snapshots, err := client.GetVolume("", "", resp.ID, "", false)
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, snap.VolumeIDList[0], snapshots[0].ID)
+	assert.Equal(t, snap.SnapshotGroupID, snapshots[0].ConsistencyGroupID)
+
+	assert.NoError(t, volume.SetVolumeSize("16"))
+	v, _ := srv.Volume(resp.ID)
+	assert.Equal(t, 16*1024*1024, v.SizeInKb)
+
+	assert.Error(t, volume.RemoveVolume("INCLUDING_DESCENDANTS"))
+	assert.NoError(t, volume.UnmapVolumeSdc(&types.UnmapVolumeSdcParam{SdcID: sdcID}))
+	assert.ErrorIs(t, volume.UnmapVolumeSdc(&types.UnmapVolumeSdcParam{SdcID: sdcID}), goscaleio.ErrNotMapped)
+	assert.NoError(t, volume.RemoveVolume("INCLUDING_DESCENDANTS"))
+
+	_, err = client.GetVolume("", resp.ID, "", "", false)
+	assert.ErrorIs(t, err, goscaleio.ErrNotFound)
+	_, ok := srv.Volume(snap.VolumeIDList[0])
+	assert.False(t, ok)
+}
+
+func TestServerAuthentication(t *testing.T) {
+	for _, version := range []string{"3.6", "4.5"} {
+		t.Run(version, func(t *testing.T) {
+			srv := NewServer(Config{Version: version})
+			defer srv.Close()
+			srv.AddSystem("system")
+
+			_, err := newClient(t, srv, "wrong")
+			assert.True(t, errors.Is(err, goscaleio.ErrUnauthorized))
+
+			client, err := newClient(t, srv, DefaultPassword)
+			assert.NoError(t, err)
+			srv.InvalidateTokens()
+			systems, err := client.GetSystems()
+			assert.NoError(t, err)
+			assert.Len(t, systems, 1)
+
+			// 4.x sessions come from /rest/auth and end with their refresh token
+			assert.Equal(t, version == "4.5", len(srv.refresh) == 1)
+			if version == "4.5" {
+				assert.NoError(t, client.Logout())
+				assert.Empty(t, srv.refresh)
+			}
+		})
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	srv := NewServer(Config{})
+	defer srv.Close()
+	systemID := srv.AddSystem("system")
+	pdID := srv.AddProtectionDomain(systemID, "pd")
+	poolID := srv.AddStoragePool(pdID, "pool")
+	srv.AddVolume(poolID, "vol", 1)
+
+	client, err := newClient(t, srv, DefaultPassword)
+	assert.NoError(t, err)
+	system, err := client.FindSystem(systemID, "", "")
+	assert.NoError(t, err)
+
+	pd, err := system.FindProtectionDomain(pdID, "", "")
+	assert.NoError(t, err)
+	err = goscaleio.NewProtectionDomainEx(client, pd).DeleteStoragePool("pool")
+	assert.EqualError(t, err, types.TranslateErrorCodeToErrorMessage("STORAGE_POOL_HAS_VOLS"))
+
+	_, err = system.GetSdcByID("missing")
+	assert.ErrorIs(t, err, goscaleio.ErrNotFound)
+
+	_, err = system.GetMDMClusterDetails()
+	var apiErr *types.Error
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotImplemented, apiErr.HTTPStatusCode)
+}
@@ -13,17 +13,23 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // GetInstance returns an instance
 func (c *Client) GetInstance(systemhref string) ([]*types.System, error) {
-	defer TimeSpent("GetInstance", time.Now())
+	return c.GetInstanceCtx(c.callContext(), systemhref)
+}
+
+// GetInstanceCtx returns an instance
+func (c *Client) GetInstanceCtx(ctx context.Context, systemhref string) ([]*types.System, error) {
+	ctx, span := c.startSpan(ctx, "GetInstance")
+	defer span.end()
 
 	var (
 		err     error
@@ -32,10 +38,10 @@ func (c *Client) GetInstance(systemhref string) ([]*types.System, error) {
 	)
 
 	if systemhref == "" {
-		err = c.getJSONWithRetry(
+		err = c.getJSONWithRetry(ctx,
 			http.MethodGet, "api/types/System/instances", nil, &systems)
 	} else {
-		err = c.getJSONWithRetry(
+		err = c.getJSONWithRetry(ctx,
 			http.MethodGet, systemhref, nil, system)
 	}
 	if err != nil {
@@ -54,7 +60,16 @@ func (c *Client) GetVolume(
 	volumehref, volumeid, ancestorvolumeid, volumename string,
 	getSnapshots bool,
 ) ([]*types.Volume, error) {
-	defer TimeSpent("GetVolume", time.Now())
+	return c.GetVolumeCtx(c.callContext(), volumehref, volumeid, ancestorvolumeid, volumename, getSnapshots)
+}
+
+// GetVolumeCtx returns a volume
+func (c *Client) GetVolumeCtx(ctx context.Context,
+	volumehref, volumeid, ancestorvolumeid, volumename string,
+	getSnapshots bool,
+) ([]*types.Volume, error) {
+	ctx, span := c.startSpan(ctx, "GetVolume")
+	defer span.end()
 
 	var (
 		err     error
@@ -64,8 +79,8 @@ func (c *Client) GetVolume(
 	)
 
 	if volumename != "" {
-		volumeid, err = c.FindVolumeID(volumename)
-		if err != nil && err.Error() == "Not found" {
+		volumeid, err = c.FindVolumeIDCtx(ctx, volumename)
+		if errors.Is(err, ErrNotFound) {
 			return nil, nil
 		}
 		if err != nil {
@@ -82,10 +97,10 @@ func (c *Client) GetVolume(
 	}
 
 	if volumehref == "" && volumeid == "" {
-		err = c.getJSONWithRetry(
+		err = c.getJSONWithRetry(ctx,
 			http.MethodGet, path, nil, &volumes)
 	} else {
-		err = c.getJSONWithRetry(
+		err = c.getJSONWithRetry(ctx,
 			http.MethodGet, path, nil, volume)
 	}
 	if err != nil {
@@ -106,13 +121,36 @@ func (c *Client) GetVolume(
 	return volumes, nil
 }
 
+// QueryVolume returns the volumes that match q
+func (c *Client) QueryVolume(q *QueryBuilder) ([]types.Volume, error) {
+	return c.QueryVolumeCtx(c.callContext(), q)
+}
+
+// QueryVolumeCtx returns the volumes that match q
+func (c *Client) QueryVolumeCtx(ctx context.Context, q *QueryBuilder) ([]types.Volume, error) {
+	ctx, span := c.startSpan(ctx, "QueryVolume")
+	defer span.end()
+
+	return queryMDM[types.Volume](ctx, c, mdmType{
+		name:   "Volume",
+		list:   "/api/types/Volume/instances",
+		byName: true,
+	}, q)
+}
+
 // FindVolumeID returns a VolumeID
 func (c *Client) FindVolumeID(volumename string) (string, error) {
-	return findVolumeIDFunc(c, volumename)
+	return c.FindVolumeIDCtx(c.callContext(), volumename)
+}
+
+// FindVolumeIDCtx returns a VolumeID
+func (c *Client) FindVolumeIDCtx(ctx context.Context, volumename string) (string, error) {
+	return findVolumeIDFunc(ctx, c, volumename)
 }
 
-var findVolumeIDFunc = func(c *Client, volumename string) (string, error) {
-	defer TimeSpent("FindVolumeID", time.Now())
+var findVolumeIDFunc = func(ctx context.Context, c *Client, volumename string) (string, error) {
+	ctx, span := c.startSpan(ctx, "FindVolumeID")
+	defer span.end()
 
 	volumeQeryIDByKeyParam := &types.VolumeQeryIDByKeyParam{
 		Name: volumename,
@@ -120,7 +158,7 @@ var findVolumeIDFunc = func(c *Client, volumename string) (string, error) {
 
 	path := "/api/types/Volume/instances/action/queryIdByKey"
 
-	volumeID, err := c.getStringWithRetry(http.MethodPost, path,
+	volumeID, err := c.getStringWithRetry(ctx, http.MethodPost, path,
 		volumeQeryIDByKeyParam)
 	fmt.Printf("[FindVolumeID] volumeID: %+v\n", volumeID)
 	if err != nil {
@@ -135,11 +173,20 @@ func (c *Client) CreateVolume(
 	volume *types.VolumeParam,
 	storagePoolName, protectionDomain string,
 ) (*types.VolumeResp, error) {
-	defer TimeSpent("CreateVolume", time.Now())
+	return c.CreateVolumeCtx(c.callContext(), volume, storagePoolName, protectionDomain)
+}
+
+// CreateVolumeCtx creates a volume
+func (c *Client) CreateVolumeCtx(ctx context.Context,
+	volume *types.VolumeParam,
+	storagePoolName, protectionDomain string,
+) (*types.VolumeResp, error) {
+	ctx, span := c.startSpan(ctx, "CreateVolume")
+	defer span.end()
 
 	path := "/api/types/Volume/instances"
 
-	storagePool, err := c.FindStoragePool("", storagePoolName, "", protectionDomain)
+	storagePool, err := c.FindStoragePoolCtx(ctx, "", storagePoolName, "", protectionDomain)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +195,7 @@ func (c *Client) CreateVolume(
 	volume.ProtectionDomainID = storagePool.ProtectionDomainID
 
 	vol := &types.VolumeResp{}
-	err = c.getJSONWithRetry(
+	err = c.getJSONWithRetry(ctx,
 		http.MethodPost, path, volume, vol)
 	if err != nil {
 		return nil, err
@@ -161,7 +208,15 @@ func (c *Client) CreateVolume(
 func (c *Client) GetStoragePool(
 	storagepoolhref string,
 ) ([]*types.StoragePool, error) {
-	defer TimeSpent("GetStoragePool", time.Now())
+	return c.GetStoragePoolCtx(c.callContext(), storagepoolhref)
+}
+
+// GetStoragePoolCtx returns a storagepool
+func (c *Client) GetStoragePoolCtx(ctx context.Context,
+	storagepoolhref string,
+) ([]*types.StoragePool, error) {
+	ctx, span := c.startSpan(ctx, "GetStoragePool")
+	defer span.end()
 
 	var (
 		err          error
@@ -170,11 +225,11 @@ func (c *Client) GetStoragePool(
 	)
 
 	if storagepoolhref == "" {
-		err = c.getJSONWithRetry(
+		err = c.getJSONWithRetry(ctx,
 			http.MethodGet, "/api/types/StoragePool/instances",
 			nil, &storagePools)
 	} else {
-		err = c.getJSONWithRetry(
+		err = c.getJSONWithRetry(ctx,
 			http.MethodGet, storagepoolhref, nil, storagePool)
 	}
 	if err != nil {
@@ -191,9 +246,17 @@ func (c *Client) GetStoragePool(
 func (c *Client) FindStoragePool(
 	id, name, href, protectionDomain string,
 ) (*types.StoragePool, error) {
-	defer TimeSpent("FindStoragePool", time.Now())
+	return c.FindStoragePoolCtx(c.callContext(), id, name, href, protectionDomain)
+}
+
+// FindStoragePoolCtx returns a StoragePool
+func (c *Client) FindStoragePoolCtx(ctx context.Context,
+	id, name, href, protectionDomain string,
+) (*types.StoragePool, error) {
+	ctx, span := c.startSpan(ctx, "FindStoragePool")
+	defer span.end()
 
-	storagePools, err := c.GetStoragePool(href)
+	storagePools, err := c.GetStoragePoolCtx(ctx, href)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting storage pool %s", err)
 	}
@@ -225,11 +288,17 @@ func NewSnapshotPolicy(client *Client) *SnapshotPolicy {
 
 // FindSnapshotPolicyID retruns a Snapshot Policy ID based on name
 func (c *Client) FindSnapshotPolicyID(spname string) (string, error) {
-	return findSnapshotPolicyByIDFunc(c, spname)
+	return c.FindSnapshotPolicyIDCtx(c.callContext(), spname)
 }
 
-var findSnapshotPolicyByIDFunc = func(c *Client, spid string) (string, error) {
-	defer TimeSpent("FindSnapshotPolicyID", time.Now())
+// FindSnapshotPolicyIDCtx retruns a Snapshot Policy ID based on name
+func (c *Client) FindSnapshotPolicyIDCtx(ctx context.Context, spname string) (string, error) {
+	return findSnapshotPolicyByIDFunc(ctx, c, spname)
+}
+
+var findSnapshotPolicyByIDFunc = func(ctx context.Context, c *Client, spid string) (string, error) {
+	ctx, span := c.startSpan(ctx, "FindSnapshotPolicyID")
+	defer span.end()
 
 	SnapshotPolicyQueryIDByKeyParam := &types.SnapshotPolicyQueryIDByKeyParam{
 		Name: spid,
@@ -237,7 +306,7 @@ var findSnapshotPolicyByIDFunc = func(c *Client, spid string) (string, error) {
 
 	path := fmt.Sprintf("/api/types/SnapshotPolicy/instances/action/queryIdByKey")
 
-	spID, err := c.getStringWithRetry(
+	spID, err := c.getStringWithRetry(ctx,
 		http.MethodPost, path, SnapshotPolicyQueryIDByKeyParam)
 	if err != nil {
 		return "", err
@@ -250,7 +319,15 @@ var findSnapshotPolicyByIDFunc = func(c *Client, spid string) (string, error) {
 func (c *Client) GetSnapshotPolicy(
 	spname, spid string,
 ) ([]*types.SnapshotPolicy, error) {
-	defer TimeSpent("GetSnapshotPolicy", time.Now())
+	return c.GetSnapshotPolicyCtx(c.callContext(), spname, spid)
+}
+
+// GetSnapshotPolicyCtx returns a list of snapshot policy
+func (c *Client) GetSnapshotPolicyCtx(ctx context.Context,
+	spname, spid string,
+) ([]*types.SnapshotPolicy, error) {
+	ctx, span := c.startSpan(ctx, "GetSnapshotPolicy")
+	defer span.end()
 
 	var (
 		err  error
@@ -260,8 +337,8 @@ func (c *Client) GetSnapshotPolicy(
 	)
 
 	if spname != "" {
-		spid, err = c.FindSnapshotPolicyID(spname)
-		if err != nil && err.Error() == "Not found" {
+		spid, err = c.FindSnapshotPolicyIDCtx(ctx, spname)
+		if errors.Is(err, ErrNotFound) {
 			return nil, nil
 		}
 		if err != nil {
@@ -276,10 +353,10 @@ func (c *Client) GetSnapshotPolicy(
 	}
 
 	if spid == "" {
-		err = c.getJSONWithRetry(
+		err = c.getJSONWithRetry(ctx,
 			http.MethodGet, path, nil, &sps)
 	} else {
-		err = c.getJSONWithRetry(
+		err = c.getJSONWithRetry(ctx,
 			http.MethodGet, path, nil, sp)
 	}
 	if err != nil {
@@ -295,11 +372,17 @@ func (c *Client) GetSnapshotPolicy(
 
 // GetStoragePoolVolumes returns list of volumes connected to storage pool Storagepool by ID
 func (c *Client) GetStoragePoolVolumes(id string) ([]*types.Volume, error) {
-	defer TimeSpent("GetStoragePoolByID", time.Now())
+	return c.GetStoragePoolVolumesCtx(c.callContext(), id)
+}
+
+// GetStoragePoolVolumesCtx returns list of volumes connected to storage pool Storagepool by ID
+func (c *Client) GetStoragePoolVolumesCtx(ctx context.Context, id string) ([]*types.Volume, error) {
+	ctx, span := c.startSpan(ctx, "GetStoragePoolByID")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/StoragePool::%s/relationships/Volume", id)
 	var storagepoolVolumes []*types.Volume
-	err := c.getJSONWithRetry(
+	err := c.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &storagepoolVolumes)
 	if err != nil {
 		return nil, err
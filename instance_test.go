@@ -13,6 +13,7 @@
 package goscaleio
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -241,7 +242,7 @@ func TestGetVolume(t *testing.T) {
 			snapshots:         true,
 			error:             "Unknown - GJWR",
 			setup: func() {
-				getJSONWithRetryFunc = func(_ *Client, _, _ string, _, _ interface{}) error {
+				getJSONWithRetryFunc = func(_ context.Context, _ *Client, _, _ string, _, _ interface{}) error {
 					return errors.New("Unknown - GJWR")
 				}
 			},
@@ -300,8 +301,8 @@ func TestGetVolume(t *testing.T) {
 			snapshots:         true,
 			error:             "",
 			setup: func() {
-				findVolumeIDFunc = func(_ *Client, _ string) (string, error) {
-					return "", errors.New("Not found")
+				findVolumeIDFunc = func(_ context.Context, _ *Client, _ string) (string, error) {
+					return "", &types.Error{Message: "Not found"}
 				}
 			},
 		},
@@ -314,7 +315,7 @@ func TestGetVolume(t *testing.T) {
 			snapshots:         true,
 			error:             "Error: problem finding volume: Unknown - FVIF",
 			setup: func() {
-				findVolumeIDFunc = func(_ *Client, _ string) (string, error) {
+				findVolumeIDFunc = func(_ context.Context, _ *Client, _ string) (string, error) {
 					return "", errors.New("Unknown - FVIF")
 				}
 			},
@@ -547,8 +548,8 @@ func TestGetSnapshotPolicyI(t *testing.T) {
 			snapshotpolicyname: "mock-snapshot-policy-name",
 			error:              "Not found",
 			setup: func() {
-				findSnapshotPolicyByIDFunc = func(_ *Client, _ string) (string, error) {
-					return "", errors.New("Not found")
+				findSnapshotPolicyByIDFunc = func(_ context.Context, _ *Client, _ string) (string, error) {
+					return "", &types.Error{Message: "Not found"}
 				}
 			},
 		},
@@ -558,7 +559,7 @@ func TestGetSnapshotPolicyI(t *testing.T) {
 			snapshotpolicyname: "mock-snapshot-policy-name",
 			error:              "Error: problem finding snapshot policy: Other Error",
 			setup: func() {
-				findSnapshotPolicyByIDFunc = func(_ *Client, _ string) (string, error) {
+				findSnapshotPolicyByIDFunc = func(_ context.Context, _ *Client, _ string) (string, error) {
 					return "", errors.New("Other Error")
 				}
 			},
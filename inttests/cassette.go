@@ -0,0 +1,117 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inttests
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dell/goscaleio/api"
+	"github.com/dell/goscaleio/goscaleiotest"
+	"github.com/joho/godotenv"
+)
+
+// With GOSCALEIO_CASSETTE=record the tests run against the array and its
+// responses are saved, scrubbed, to cassetteDir. With
+// GOSCALEIO_CASSETTE=replay the saved responses are replayed instead, so that
+// the tests run without an array. The tests must run in the order they were
+// recorded in, e.g. with the same -run flag.
+const (
+	cassetteMode    = "GOSCALEIO_CASSETTE"
+	cassetteDir     = "testdata/cassettes"
+	cassetteEnvFile = "GOSCALEIO_TEST.env"
+	replayEndpoint  = "https://powerflex.invalid"
+)
+
+var recorders = map[string]*goscaleiotest.Recorder{}
+
+// loadEnv loads the test settings, from the recorded ones when replaying.
+func loadEnv() {
+	file := envVarsFile
+	if os.Getenv(cassetteMode) == "replay" {
+		file = filepath.Join(cassetteDir, cassetteEnvFile)
+	}
+	if err := godotenv.Load(file); err != nil {
+		log.Printf("%s file not found.", file)
+	}
+}
+
+// withCassette returns opts with the transport of the client whose
+// interactions are kept in the named cassette.
+func withCassette(name string, opts api.ClientOptions) api.ClientOptions {
+	switch os.Getenv(cassetteMode) {
+	case "record":
+		next, err := api.NewTransport(opts)
+		if err != nil {
+			panic(err)
+		}
+		recorder := goscaleiotest.NewRecorder(next)
+		recorders[name] = recorder
+		opts.Transport = recorder
+	case "replay":
+		path := filepath.Join(cassetteDir, name+".jsonl")
+		replayer, err := goscaleiotest.LoadCassette(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Fatalf("no cassette %s to replay: record it with GOSCALEIO_CASSETTE=record, see README.md", path)
+		}
+		if err != nil {
+			log.Fatalf("unable to replay %s: %v", path, err)
+		}
+		opts.Transport = replayer
+	}
+	return opts
+}
+
+// endpoint returns the value of the endpoint variable, which is not used
+// when replaying.
+func endpoint(name string) string {
+	if os.Getenv(cassetteMode) == "replay" && os.Getenv(name) != "" {
+		return replayEndpoint
+	}
+	return os.Getenv(name)
+}
+
+// saveCassettes saves the recorded interactions and the test settings, with
+// passwords and endpoints replaced.
+func saveCassettes() error {
+	if os.Getenv(cassetteMode) != "record" {
+		return nil
+	}
+	for name, recorder := range recorders {
+		if err := recorder.Save(filepath.Join(cassetteDir, name+".jsonl")); err != nil {
+			return err
+		}
+	}
+
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		testVar := strings.HasPrefix(key, "GOSCALEIO_") || strings.HasPrefix(key, "GATEWAY_") ||
+			strings.HasPrefix(key, "NVME_") || key == "USER_PASSWORD"
+		if !testVar || key == cassetteMode {
+			continue
+		}
+		switch {
+		case strings.Contains(key, "PASSWORD"):
+			value = goscaleiotest.Redacted
+		case strings.Contains(key, "ENDPOINT"):
+			value = replayEndpoint
+		}
+		env[key] = value
+	}
+	return godotenv.Write(env, filepath.Join(cassetteDir, cassetteEnvFile))
+}
@@ -13,13 +13,12 @@
 package inttests
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"math"
 	"os"
 
 	"github.com/dell/goscaleio"
-	"github.com/joho/godotenv"
+	"github.com/dell/goscaleio/api"
 )
 
 const (
@@ -36,19 +35,20 @@ var (
 )
 
 func initClient() {
-	err := godotenv.Load(envVarsFile)
-	if err != nil {
-		log.Printf("%s file not found.", envVarsFile)
-	}
+	loadEnv()
 
-	C, err = goscaleio.NewClient()
+	var err error
+	C, err = goscaleio.NewClientWithOptions(endpoint(mainEndpoint), os.Getenv("GOSCALEIO_VERSION"), withCassette("client", api.ClientOptions{
+		Insecure: os.Getenv("GOSCALEIO_INSECURE") == "true",
+		UseCerts: os.Getenv("GOSCALEIO_USECERTS") == "true",
+	}))
 	if err != nil {
 		panic(err)
 	}
 
 	if C.GetToken() == "" {
 		_, err := C.Authenticate(&goscaleio.ConfigConnect{
-			Endpoint: os.Getenv(mainEndpoint),
+			Endpoint: endpoint(mainEndpoint),
 			Username: os.Getenv("GOSCALEIO_USERNAME"),
 			Password: os.Getenv("GOSCALEIO_PASSWORD"),
 		})
@@ -62,24 +62,22 @@ func initClient() {
 // returns true if second client initialized
 func initClient2() bool {
 	var err error
-	endpoint2 := os.Getenv(replicationEnpoint)
+	endpoint2 := endpoint(replicationEnpoint)
 	if endpoint2 == "" {
 		return false
 	}
 
-	C2, err = goscaleio.NewClientWithArgs(
-		endpoint2,
-		os.Getenv("GOSCALEIO_VERSION"),
-		math.MaxInt64,
-		os.Getenv("GOSCALEIO_INSECURE") == "true",
-		os.Getenv("GOSCALEIO_INSECURE") == "true")
+	C2, err = goscaleio.NewClientWithOptions(endpoint2, os.Getenv("GOSCALEIO_VERSION"), withCassette("client2", api.ClientOptions{
+		Insecure: os.Getenv("GOSCALEIO_INSECURE") == "true",
+		UseCerts: os.Getenv("GOSCALEIO_INSECURE") == "true",
+	}))
 	if err != nil {
 		panic(err)
 	}
 
 	if C2.GetToken() == "" {
 		_, err := C2.Authenticate(&goscaleio.ConfigConnect{
-			Endpoint: os.Getenv(replicationEnpoint),
+			Endpoint: endpoint2,
 			Username: os.Getenv("GOSCALEIO_USERNAME2"),
 			Password: os.Getenv("GOSCALEIO_PASSWORD2"),
 		})
@@ -91,12 +89,13 @@ func initClient2() bool {
 }
 
 func initGatewayClient() {
-	err := godotenv.Load(envVarsFile)
-	if err != nil {
-		log.Printf("%s file not found.", envVarsFile)
-	}
+	loadEnv()
 
-	GC, err = goscaleio.NewGateway(os.Getenv("GATEWAY_ENDPOINT"), os.Getenv("GATEWAY_USERNAME"), os.Getenv("GATEWAY_PASSWORD"), os.Getenv("GATEWAY_INSECURE") == "true", os.Getenv("GATEWAY_INSECURE") == "true")
+	var err error
+	GC, err = goscaleio.NewGatewayWithOptions(context.Background(), endpoint("GATEWAY_ENDPOINT"), os.Getenv("GATEWAY_USERNAME"), os.Getenv("GATEWAY_PASSWORD"), withCassette("gateway", api.ClientOptions{
+		Insecure: os.Getenv("GATEWAY_INSECURE") == "true",
+		UseCerts: os.Getenv("GATEWAY_INSECURE") == "true",
+	}))
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,271 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+
+	"github.com/dell/goscaleio/api"
+	types "github.com/dell/goscaleio/types/v1"
+)
+
+// pfmpPageSize is the number of objects requested per page from the PFMP
+// REST endpoints that support offset and limit.
+const pfmpPageSize = 100
+
+// AllSds returns an iterator over all the SDSs in the system, decoded one at
+// a time as the response is read.
+func (s *System) AllSds() iter.Seq2[types.Sds, error] {
+	return s.AllSdsCtx(s.client.callContext())
+}
+
+// AllSdsCtx returns an iterator over all the SDSs in the system, decoded one
+// at a time as the response is read.
+func (s *System) AllSdsCtx(ctx context.Context) iter.Seq2[types.Sds, error] {
+	return streamJSON[types.Sds](ctx, s.client, "AllSds", "/api/types/Sds/instances")
+}
+
+// AllDevices returns an iterator over all the devices in the system, decoded
+// one at a time as the response is read.
+func (s *System) AllDevices() iter.Seq2[types.Device, error] {
+	return s.AllDevicesCtx(s.client.callContext())
+}
+
+// AllDevicesCtx returns an iterator over all the devices in the system,
+// decoded one at a time as the response is read.
+func (s *System) AllDevicesCtx(ctx context.Context) iter.Seq2[types.Device, error] {
+	return streamJSON[types.Device](ctx, s.client, "AllDevices", "/api/types/Device/instances")
+}
+
+// AllVolumes returns an iterator over all the volumes of the storage pool,
+// snapshots included, decoded one at a time as the response is read.
+func (sp *StoragePool) AllVolumes() iter.Seq2[*types.Volume, error] {
+	return sp.AllVolumesCtx(sp.client.callContext())
+}
+
+// AllVolumesCtx returns an iterator over all the volumes of the storage
+// pool, snapshots included, decoded one at a time as the response is read.
+func (sp *StoragePool) AllVolumesCtx(ctx context.Context) iter.Seq2[*types.Volume, error] {
+	link, err := GetLink(sp.StoragePool.Links, "/api/StoragePool/relationship/Volume")
+	if err != nil {
+		return func(yield func(*types.Volume, error) bool) {
+			yield(nil, err)
+		}
+	}
+	return streamJSON[*types.Volume](ctx, sp.client, "AllVolumes", link.HREF)
+}
+
+// AllVTrees returns an iterator over the vtrees present in the cluster,
+// decoded one at a time as the response is read.
+func (c *Client) AllVTrees() iter.Seq2[types.VTreeDetails, error] {
+	return c.AllVTreesCtx(c.callContext())
+}
+
+// AllVTreesCtx returns an iterator over the vtrees present in the cluster,
+// decoded one at a time as the response is read.
+func (c *Client) AllVTreesCtx(ctx context.Context) iter.Seq2[types.VTreeDetails, error] {
+	return streamJSON[types.VTreeDetails](ctx, c, "AllVTrees", "/api/types/VTree/instances")
+}
+
+// AllNodes returns an iterator over all the nodes, requested a page at a
+// time and decoded one at a time as each page is read.
+func (gc *GatewayClient) AllNodes() iter.Seq2[types.NodeDetails, error] {
+	return gc.AllNodesCtx(context.Background())
+}
+
+// AllNodesCtx returns an iterator over all the nodes, requested a page at a
+// time and decoded one at a time as each page is read.
+func (gc *GatewayClient) AllNodesCtx(ctx context.Context) iter.Seq2[types.NodeDetails, error] {
+	return streamPages[types.NodeDetails](ctx, gc, "AllNodes", "/Api/V1/ManagedDevice", pfmpPageSize)
+}
+
+// streamJSON returns an iterator over the elements of the JSON array
+// returned by a GET of uri. The request is sent when the iteration starts,
+// and traced as the call name until it ends. Its slot of Limits.MaxInFlight
+// is released once the response arrives, as the loop may send requests.
+func streamJSON[T any](ctx context.Context, c *Client, name, uri string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, span := c.startSpan(ctx, name)
+		defer span.end()
+		yield = tracedYield(ctx, yield)
+
+		res, err := c.openWithRetry(ctx, http.MethodGet, uri)
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		defer c.closeBody(res)
+		api.ReleaseSlot(res)
+
+		decodeArray(res.Body, yield)
+	}
+}
+
+// streamPages returns an iterator over the elements of the JSON arrays
+// returned by GETs of path with increasing offsets, pageSize elements at a
+// time, until a page comes back short. Endpoints that ignore the paging
+// parameters return everything at once: the iteration also ends when a page
+// starts with the same element as the previous one.
+func streamPages[T any](ctx context.Context, gc *GatewayClient, name, path string, pageSize int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, span := gc.startSpan(ctx, name)
+		defer span.end()
+		yield = tracedYield(ctx, yield)
+
+		var previous json.RawMessage
+		for offset := 0; ; offset += pageSize {
+			res, err := gc.openPage(ctx, fmt.Sprintf("%s?offset=%d&limit=%d", path, offset, pageSize))
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			api.ReleaseSlot(res)
+
+			var first json.RawMessage
+			repeated := false
+			n, ok := decodeArray(res.Body, func(raw json.RawMessage, err error) bool {
+				var v T
+				if err != nil {
+					return yield(v, err)
+				}
+				if first == nil {
+					first = raw
+					if repeated = bytes.Equal(raw, previous); repeated {
+						return false
+					}
+				}
+				if err := json.Unmarshal(raw, &v); err != nil {
+					yield(v, err)
+					return false
+				}
+				return yield(v, nil)
+			})
+			gc.closeBody(res)
+			if repeated || !ok || n != pageSize {
+				return
+			}
+			previous = first
+		}
+	}
+}
+
+// openPage sends a GET of path and returns the 2xx response with its body
+// unread.
+func (gc *GatewayClient) openPage(ctx context.Context, path string) (*http.Response, error) {
+	r := gatewayRequest{method: http.MethodGet, path: path}
+	res, err := gc.open(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		defer gc.closeBody(res)
+		body, err := extractString(res)
+		if err != nil {
+			return nil, err
+		}
+		return nil, gatewayError(res, body)
+	}
+	if err := gc.keepCookie(r, res); err != nil {
+		gc.closeBody(res)
+		return nil, err
+	}
+	return res, nil
+}
+
+// tracedYield records the errors yielded on the call traced in ctx.
+func tracedYield[T any](ctx context.Context, yield func(T, error) bool) func(T, error) bool {
+	return func(v T, err error) bool {
+		api.CallFromContext(ctx).SetError(err)
+		return yield(v, err)
+	}
+}
+
+// decodeArray yields the elements of the JSON array read from r as they are
+// decoded, so that the whole array is never held in memory. A JSON null is
+// an empty array. It returns the number of elements yielded and whether the
+// iteration may go on: false after an error or once yield returns false.
+func decodeArray[T any](r io.Reader, yield func(T, error) bool) (n int, ok bool) {
+	fail := func(err error) (int, bool) {
+		var zero T
+		yield(zero, err)
+		return n, false
+	}
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fail(err)
+	}
+	if tok == nil {
+		return 0, true
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fail(fmt.Errorf("expected a JSON array, got %v", tok))
+	}
+
+	for dec.More() {
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return fail(err)
+		}
+		n++
+		if !yield(v, nil) {
+			return n, false
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fail(err)
+	}
+	return n, true
+}
+
+// openWithRetry sends a request without a body and returns the 2xx response
+// with its body unread. A request rejected with 401 is sent once more after
+// logging in again.
+func (c *Client) openWithRetry(ctx context.Context, method, uri string) (*http.Response, error) {
+	if err := c.requireFor(uri); err != nil {
+		return nil, err
+	}
+	headers := c.requestHeaders(nil)
+
+	c.refreshIfExpiring(ctx)
+	token := c.api.GetToken()
+
+	res, err := c.api.DoAndGetResponseBody(ctx, method, uri, headers, nil, c.version())
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusUnauthorized {
+		c.closeBody(res)
+		if err := c.reauthenticate(ctx, token); err != nil {
+			return nil, err
+		}
+		res, err = c.api.DoAndGetResponseBody(ctx, method, uri, headers, nil, c.version())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		defer c.closeBody(res)
+		return nil, c.api.ParseJSONError(res)
+	}
+	return res, nil
+}
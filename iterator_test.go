@@ -0,0 +1,297 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dell/goscaleio/api"
+	types "github.com/dell/goscaleio/types/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeArray(t *testing.T) {
+	cases := []struct {
+		body    string
+		ids     []string
+		n       int
+		ok      bool
+		wantErr bool
+	}{
+		{`[{"id":"1"},{"id":"2"}]`, []string{"1", "2"}, 2, true, false},
+		{`[]`, nil, 0, true, false},
+		{`null`, nil, 0, true, false},
+		{`{"id":"1"}`, nil, 0, false, true},
+		{`[{"id":"1"},{"id":`, []string{"1"}, 1, false, true},
+		{``, nil, 0, false, true},
+	}
+	for _, c := range cases {
+		var ids []string
+		var errs []error
+		n, ok := decodeArray(strings.NewReader(c.body), func(s types.Sds, err error) bool {
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				ids = append(ids, s.ID)
+			}
+			return true
+		})
+		assert.Equal(t, c.ids, ids, c.body)
+		assert.Equal(t, c.n, n, c.body)
+		assert.Equal(t, c.ok, ok, c.body)
+		assert.Equal(t, c.wantErr, len(errs) == 1, c.body)
+	}
+
+	// breaking out stops the decoding
+	n, ok := decodeArray(strings.NewReader(`[{"id":"1"},{"id":"2"},{"id":"3"}]`), func(types.Sds, error) bool {
+		return false
+	})
+	assert.Equal(t, 1, n)
+	assert.False(t, ok)
+}
+
+func TestClientIterators(t *testing.T) {
+	var logins int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/login" {
+			n := atomic.AddInt32(&logins, 1)
+			fmt.Fprintf(w, `"token-%d"`, n)
+			return
+		}
+		if _, token, _ := r.BasicAuth(); token != fmt.Sprintf("token-%d", atomic.LoadInt32(&logins)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"Unauthorized","httpStatusCode":401,"errorCode":0}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/api/types/Sds/instances":
+			w.Write([]byte(`[{"id":"sds1"},{"id":"sds2"}]`))
+		case "/api/types/Device/instances":
+			w.Write([]byte(`[{"id":"dev1"}]`))
+		case "/api/types/VTree/instances":
+			w.Write([]byte(`[{"id":"vt1"},{"id":"vt2"},{"id":"vt3"}]`))
+		case "/api/instances/StoragePool::sp1/relationships/Volume":
+			w.Write([]byte(`[{"id":"vol1"},{"id":"snap1","ancestorVolumeId":"vol1"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+	assert.NoError(t, err)
+
+	// the session is renewed on a 401
+	client.SetToken("expired")
+
+	system := NewSystem(client)
+	var ids []string
+	for sds, err := range system.AllSds() {
+		assert.NoError(t, err)
+		ids = append(ids, sds.ID)
+	}
+	assert.Equal(t, []string{"sds1", "sds2"}, ids)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&logins))
+
+	ids = nil
+	for device, err := range system.AllDevices() {
+		assert.NoError(t, err)
+		ids = append(ids, device.ID)
+	}
+	assert.Equal(t, []string{"dev1"}, ids)
+
+	ids = nil
+	for vtree, err := range client.AllVTrees() {
+		assert.NoError(t, err)
+		ids = append(ids, vtree.ID)
+		break
+	}
+	assert.Equal(t, []string{"vt1"}, ids)
+
+	pool := NewStoragePoolEx(client, &types.StoragePool{
+		ID: "sp1",
+		Links: []*types.Link{
+			{Rel: "/api/StoragePool/relationship/Volume", HREF: "/api/instances/StoragePool::sp1/relationships/Volume"},
+		},
+	})
+	ids = nil
+	for volume, err := range pool.AllVolumes() {
+		assert.NoError(t, err)
+		ids = append(ids, volume.ID)
+	}
+	assert.Equal(t, []string{"vol1", "snap1"}, ids)
+
+	// a pool without the link and a failed request yield a single error
+	for _, err := range NewStoragePool(client).AllVolumes() {
+		assert.Error(t, err)
+	}
+	count := 0
+	for _, err := range streamJSON[types.Sds](client.callContext(), client, "Missing", "/api/types/Missing/instances") {
+		assert.ErrorContains(t, err, "not found")
+		count++
+	}
+	assert.Equal(t, 1, count)
+}
+
+func TestClientIteratorNestedRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			w.Write([]byte(`"token"`))
+		case "/api/types/Sds/instances":
+			w.Write([]byte(`[{"id":"sds1"},{"id":"sds2"}]`))
+		case "/api/instances/Sds::sds1", "/api/instances/Sds::sds2":
+			fmt.Fprintf(w, `{"id":"%s","name":"sds"}`, strings.TrimPrefix(r.URL.Path, "/api/instances/Sds::"))
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithOptions(ts.URL, "3.6", api.ClientOptions{
+		Insecure: true,
+		Limits:   &api.Limits{Read: api.Limit{MaxInFlight: 1}},
+	})
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+	assert.NoError(t, err)
+
+	// the stream does not hold the only read slot while the loop reads
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	system := NewSystem(client)
+	var names []string
+	for sds, err := range system.AllSdsCtx(ctx) {
+		assert.NoError(t, err)
+		found, err := system.GetSdsByIDCtx(ctx, sds.ID)
+		assert.NoError(t, err)
+		names = append(names, found.ID+"/"+found.Name)
+	}
+	assert.Equal(t, []string{"sds1/sds", "sds2/sds"}, names)
+}
+
+func TestGatewayAllNodes(t *testing.T) {
+	const total = 2*pfmpPageSize + 5
+
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/Api/V1/ManagedDevice" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var nodes []string
+		for i := offset; i < total && i < offset+limit; i++ {
+			nodes = append(nodes, fmt.Sprintf(`{"refId":"%d"}`, i))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(nodes, ","))
+	}))
+	defer ts.Close()
+
+	gc := &GatewayClient{http: ts.Client(), host: ts.URL, version: "4.0", token: "token"}
+
+	count := 0
+	for node, err := range gc.AllNodes() {
+		assert.NoError(t, err)
+		assert.Equal(t, strconv.Itoa(count), node.RefID)
+		count++
+	}
+	assert.Equal(t, total, count)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	// no more pages are requested once the loop is broken out of
+	atomic.StoreInt32(&requests, 0)
+	for range gc.AllNodes() {
+		break
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestGatewayAllNodesUnpaged(t *testing.T) {
+	const total = pfmpPageSize + 1
+
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) > 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"bad request","httpStatusCode":400,"errorCode":0}`))
+			return
+		}
+		// the paging parameters are ignored
+		var nodes []string
+		for i := 0; i < total; i++ {
+			nodes = append(nodes, fmt.Sprintf(`{"refId":"%d"}`, i))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(nodes, ","))
+	}))
+	defer ts.Close()
+
+	gc := &GatewayClient{http: ts.Client(), host: ts.URL, version: "4.0", token: "token"}
+
+	count := 0
+	for _, err := range gc.AllNodes() {
+		assert.NoError(t, err)
+		count++
+	}
+	assert.Equal(t, total, count)
+
+	// an error response ends the iteration with the error
+	count = 0
+	for _, err := range gc.AllNodes() {
+		assert.ErrorContains(t, err, "bad request")
+		count++
+	}
+	assert.Equal(t, 1, count)
+}
+
+func TestGatewayAllNodesUnpagedFullPage(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		// exactly one page, whatever the paging parameters
+		var nodes []string
+		for i := 0; i < pfmpPageSize; i++ {
+			nodes = append(nodes, fmt.Sprintf(`{"refId":"%d"}`, i))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(nodes, ","))
+	}))
+	defer ts.Close()
+
+	gc := &GatewayClient{http: ts.Client(), host: ts.URL, version: "4.0", token: "token"}
+
+	var ids []string
+	for node, err := range gc.AllNodes() {
+		assert.NoError(t, err)
+		ids = append(ids, node.RefID)
+		if len(ids) > pfmpPageSize {
+			break
+		}
+	}
+	assert.Len(t, ids, pfmpPageSize)
+	assert.Equal(t, "99", ids[len(ids)-1])
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
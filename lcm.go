@@ -13,11 +13,11 @@
 package goscaleio
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
@@ -27,9 +27,15 @@ import (
 // Returns 1 if PFMP version > the given version,
 // Returns 0 if PFMP version == the given version.
 func CheckPfmpVersion(client *Client, version string) (int, error) {
-	defer TimeSpent("CheckPfmpVersion", time.Now())
+	return CheckPfmpVersionCtx(client.callContext(), client, version)
+}
+
+// CheckPfmpVersionCtx checks if the PFMP version is greater than the given version
+func CheckPfmpVersionCtx(ctx context.Context, client *Client, version string) (int, error) {
+	ctx, span := client.startSpan(ctx, "CheckPfmpVersion")
+	defer span.end()
 
-	lcmStatus, err := GetPfmpStatus(*client)
+	lcmStatus, err := GetPfmpStatusCtx(ctx, client)
 	if err != nil {
 		return -1, fmt.Errorf("failed to get PFMP version : %v", err)
 	}
@@ -43,12 +49,18 @@ func CheckPfmpVersion(client *Client, version string) (int, error) {
 
 // GetPfmpStatus gets the PFMP status
 func GetPfmpStatus(client Client) (*types.LcmStatus, error) {
-	defer TimeSpent("GetPfmpStatus", time.Now())
+	return GetPfmpStatusCtx(client.callContext(), &client)
+}
+
+// GetPfmpStatusCtx gets the PFMP status
+func GetPfmpStatusCtx(ctx context.Context, client *Client) (*types.LcmStatus, error) {
+	ctx, span := client.startSpan(ctx, "GetPfmpStatus")
+	defer span.end()
 
 	path := "/Api/V1/corelcm/status"
 
 	var status types.LcmStatus
-	err := client.getJSONWithRetry(
+	err := client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &status)
 	if err != nil {
 		return nil, err
@@ -13,6 +13,7 @@
 package log
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"sync"
@@ -45,3 +46,80 @@ func DoLog(
 		l(msg)
 	}
 }
+
+// Logger logs the messages of a client. The zero Logger logs through Log,
+// and only when the level is set to debug, as DoLog does.
+type Logger struct {
+	l *slog.Logger
+}
+
+// NewLogger returns a Logger that logs through l, whose handler decides the
+// levels logged. A nil l gives the zero Logger.
+func NewLogger(l *slog.Logger) Logger {
+	return Logger{l: l}
+}
+
+// Debug logs msg at the debug level.
+func (l Logger) Debug(msg string, args ...any) {
+	l.log(slog.LevelDebug, msg, args...)
+}
+
+// Info logs msg at the info level.
+func (l Logger) Info(msg string, args ...any) {
+	l.log(slog.LevelInfo, msg, args...)
+}
+
+// Error logs msg at the error level.
+func (l Logger) Error(msg string, args ...any) {
+	l.log(slog.LevelError, msg, args...)
+}
+
+// Enabled reports whether messages at level are logged, so that callers can
+// skip building costly messages.
+func (l Logger) Enabled(level slog.Level) bool {
+	if l.l == nil {
+		return debugEnabled() && Log.Enabled(context.Background(), level)
+	}
+	return l.l.Enabled(context.Background(), level)
+}
+
+// WithLevel returns a logger that sends the messages at level and above to
+// the handler of l, whatever the level the handler enables. It gives one
+// client debug logging without changing the level of the others.
+func WithLevel(l *slog.Logger, level slog.Level) *slog.Logger {
+	return slog.New(levelHandler{level: level, Handler: l.Handler()})
+}
+
+// levelHandler overrides the level of the handler it wraps.
+type levelHandler struct {
+	level slog.Level
+	slog.Handler
+}
+
+func (h levelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return levelHandler{level: h.level, Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h levelHandler) WithGroup(name string) slog.Handler {
+	return levelHandler{level: h.level, Handler: h.Handler.WithGroup(name)}
+}
+
+func (l Logger) log(level slog.Level, msg string, args ...any) {
+	if l.l == nil {
+		if debugEnabled() {
+			Log.Log(context.Background(), level, msg, args...)
+		}
+		return
+	}
+	l.l.Log(context.Background(), level, msg, args...)
+}
+
+func debugEnabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return debug
+}
@@ -13,6 +13,7 @@
 package log
 
 import (
+	"bytes"
 	"fmt"
 	"log/slog"
 	"testing"
@@ -103,3 +104,41 @@ func TestSetLogLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	// the handler decides the levels, whatever the global one
+	SetLogLevel(slog.LevelInfo)
+	logger.Debug("debug message")
+	logger.Info("info message")
+	logger.Error("error message", "key", "value")
+	assert.NotContains(t, buf.String(), "debug message")
+	assert.Contains(t, buf.String(), "info message")
+	assert.Contains(t, buf.String(), "key=value")
+	assert.False(t, logger.Enabled(slog.LevelDebug))
+	assert.True(t, logger.Enabled(slog.LevelError))
+
+	// the zero Logger logs only at the debug level, as DoLog does
+	var zero Logger
+	assert.False(t, zero.Enabled(slog.LevelError))
+	SetLogLevel(slog.LevelDebug)
+	defer SetLogLevel(slog.LevelInfo)
+	assert.True(t, zero.Enabled(slog.LevelDebug))
+}
+
+func TestWithLevel(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	logger := NewLogger(WithLevel(base, slog.LevelDebug).With("client", "a"))
+	logger.Debug("debug message")
+	assert.Contains(t, buf.String(), "debug message")
+	assert.Contains(t, buf.String(), "client=a")
+
+	// the handler of the base logger keeps its level
+	base.Debug("base debug message")
+	assert.NotContains(t, buf.String(), "base debug message")
+	assert.False(t, NewLogger(WithLevel(base, slog.LevelError)).Enabled(slog.LevelInfo))
+}
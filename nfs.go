@@ -13,6 +13,7 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -23,6 +24,14 @@ import (
 
 // GetFileInterface gets a FileInterface by id
 func (s *System) GetFileInterface(id string) (*types.FileInterface, error) {
+	return s.GetFileInterfaceCtx(s.client.callContext(), id)
+}
+
+// GetFileInterfaceCtx gets a FileInterface by id
+func (s *System) GetFileInterfaceCtx(ctx context.Context, id string) (*types.FileInterface, error) {
+	ctx, span := s.client.startSpan(ctx, "GetFileInterface")
+	defer span.end()
+
 	if id == "" {
 		return nil, errors.New("id is mandatory, please enter a valid value")
 	}
@@ -30,7 +39,7 @@ func (s *System) GetFileInterface(id string) (*types.FileInterface, error) {
 
 	var resp *types.FileInterface
 
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &resp)
 	if err != nil {
 		return nil, errors.New("could not find the File interface using id")
@@ -41,6 +50,14 @@ func (s *System) GetFileInterface(id string) (*types.FileInterface, error) {
 
 // GetNASByIDName gets a NAS server by name or ID
 func (s *System) GetNASByIDName(id string, name string) (*types.NAS, error) {
+	return s.GetNASByIDNameCtx(s.client.callContext(), id, name)
+}
+
+// GetNASByIDNameCtx gets a NAS server by name or ID
+func (s *System) GetNASByIDNameCtx(ctx context.Context, id string, name string) (*types.NAS, error) {
+	ctx, span := s.client.startSpan(ctx, "GetNASByIDName")
+	defer span.end()
+
 	var nasList []types.NAS
 
 	if name == "" && id == "" {
@@ -52,7 +69,7 @@ func (s *System) GetNASByIDName(id string, name string) (*types.NAS, error) {
 		path := fmt.Sprintf("/rest/v1/nas-servers/%s?select=*", id)
 
 		var resp *types.NAS
-		err := s.client.getJSONWithRetry(
+		err := s.client.getJSONWithRetry(ctx,
 			http.MethodGet, path, nil, &resp)
 		if err != nil {
 			return nil, errors.New("could not find NAS server by id")
@@ -63,7 +80,7 @@ func (s *System) GetNASByIDName(id string, name string) (*types.NAS, error) {
 
 	// Get NAS server by name
 	path := "/rest/v1/nas-servers?select=*"
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &nasList)
 	if err != nil {
 		return nil, fmt.Errorf("could not find NAS server by name: %s, err: %s", name, err.Error())
@@ -81,6 +98,14 @@ func (s *System) GetNASByIDName(id string, name string) (*types.NAS, error) {
 
 // CreateNAS creates a NAS server
 func (s *System) CreateNAS(name string, protectionDomainID string) (*types.CreateNASResponse, error) {
+	return s.CreateNASCtx(s.client.callContext(), name, protectionDomainID)
+}
+
+// CreateNASCtx creates a NAS server
+func (s *System) CreateNASCtx(ctx context.Context, name string, protectionDomainID string) (*types.CreateNASResponse, error) {
+	ctx, span := s.client.startSpan(ctx, "CreateNAS")
+	defer span.end()
+
 	var resp types.CreateNASResponse
 
 	path := "/rest/v1/nas-servers"
@@ -90,7 +115,7 @@ func (s *System) CreateNAS(name string, protectionDomainID string) (*types.Creat
 		ProtectionDomainID: protectionDomainID,
 	}
 
-	err := s.client.getJSONWithRetry(http.MethodPost, path, body, &resp)
+	err := s.client.getJSONWithRetry(ctx, http.MethodPost, path, body, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -100,9 +125,17 @@ func (s *System) CreateNAS(name string, protectionDomainID string) (*types.Creat
 
 // DeleteNAS deletes a NAS server
 func (s *System) DeleteNAS(id string) error {
+	return s.DeleteNASCtx(s.client.callContext(), id)
+}
+
+// DeleteNASCtx deletes a NAS server
+func (s *System) DeleteNASCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "DeleteNAS")
+	defer span.end()
+
 	path := fmt.Sprintf("/rest/v1/nas-servers/%s", id)
 
-	err := s.client.getJSONWithRetry(http.MethodDelete, path, nil, nil)
+	err := s.client.getJSONWithRetry(ctx, http.MethodDelete, path, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -112,13 +145,21 @@ func (s *System) DeleteNAS(id string) error {
 
 // PingNAS pings a NAS server
 func (s *System) PingNAS(id string, ipaddress string) error {
+	return s.PingNASCtx(s.client.callContext(), id, ipaddress)
+}
+
+// PingNASCtx pings a NAS server
+func (s *System) PingNASCtx(ctx context.Context, id string, ipaddress string) error {
+	ctx, span := s.client.startSpan(ctx, "PingNAS")
+	defer span.end()
+
 	path := fmt.Sprintf("rest/v1/nas-servers/%s/ping", id)
 	body := types.PingNASParam{
 		DestinationAddress: ipaddress,
 		IsIPV6:             false,
 	}
 
-	err := s.client.getJSONWithRetry(http.MethodPost, path, body, nil)
+	err := s.client.getJSONWithRetry(ctx, http.MethodPost, path, body, nil)
 	if err != nil {
 		return errors.New("Could not ping NAS server " + id)
 	}
@@ -126,11 +167,20 @@ func (s *System) PingNAS(id string, ipaddress string) error {
 	return nil
 }
 
+// IsNFSEnabled calls IsNFSEnabledCtx with the context set by WithContext.
 func (s *System) IsNFSEnabled() (bool, error) {
+	return s.IsNFSEnabledCtx(s.client.callContext())
+}
+
+// IsNFSEnabledCtx is the context-aware variant of IsNFSEnabled.
+func (s *System) IsNFSEnabledCtx(ctx context.Context) (bool, error) {
+	ctx, span := s.client.startSpan(ctx, "IsNFSEnabled")
+	defer span.end()
+
 	path := "/rest/v1/nfs-servers?select=*"
 	var servers []types.NFSServer
 
-	err := s.client.getJSONWithRetry(http.MethodGet, path, nil, &servers)
+	err := s.client.getJSONWithRetry(ctx, http.MethodGet, path, nil, &servers)
 	if err != nil {
 		return false, err
 	}
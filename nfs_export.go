@@ -13,20 +13,26 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // GetNFSExport lists NFS Exports.
 func (c *Client) GetNFSExport() (nfsList []types.NFSExport, err error) {
-	defer TimeSpent("GetNfsExport", time.Now())
+	return c.GetNFSExportCtx(c.callContext())
+}
+
+// GetNFSExportCtx lists NFS Exports.
+func (c *Client) GetNFSExportCtx(ctx context.Context) (nfsList []types.NFSExport, err error) {
+	ctx, span := c.startSpan(ctx, "GetNfsExport")
+	defer span.end()
 	path := "/rest/v1/nfs-exports?select=*"
 
-	err = c.getJSONWithRetry(
+	err = c.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &nfsList)
 	if err != nil {
 		return nil, err
@@ -37,10 +43,18 @@ func (c *Client) GetNFSExport() (nfsList []types.NFSExport, err error) {
 
 // CreateNFSExport create an NFS Export for a File System.
 func (c *Client) CreateNFSExport(createParams *types.NFSExportCreate) (respnfs *types.NFSExportCreateResponse, err error) {
+	return c.CreateNFSExportCtx(c.callContext(), createParams)
+}
+
+// CreateNFSExportCtx create an NFS Export for a File System.
+func (c *Client) CreateNFSExportCtx(ctx context.Context, createParams *types.NFSExportCreate) (respnfs *types.NFSExportCreateResponse, err error) {
+	ctx, span := c.startSpan(ctx, "CreateNFSExport")
+	defer span.end()
+
 	path := "/rest/v1/nfs-exports"
 
 	var body *types.NFSExportCreate = createParams
-	err = c.getJSONWithRetry(http.MethodPost, path, body, &respnfs)
+	err = c.getJSONWithRetry(ctx, http.MethodPost, path, body, &respnfs)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +64,13 @@ func (c *Client) CreateNFSExport(createParams *types.NFSExportCreate) (respnfs *
 
 // GetNFSExportByIDName returns NFS Export properties by name or ID
 func (c *Client) GetNFSExportByIDName(id string, name string) (respnfs *types.NFSExport, err error) {
-	defer TimeSpent("GetNFSExportByIDName", time.Now())
+	return c.GetNFSExportByIDNameCtx(c.callContext(), id, name)
+}
+
+// GetNFSExportByIDNameCtx returns NFS Export properties by name or ID
+func (c *Client) GetNFSExportByIDNameCtx(ctx context.Context, id string, name string) (respnfs *types.NFSExport, err error) {
+	ctx, span := c.startSpan(ctx, "GetNFSExportByIDName")
+	defer span.end()
 
 	if id == "" && name == "" {
 		return nil, errors.New("NFS export name or ID is mandatory for fetching NFS export details, please enter a valid value")
@@ -60,7 +80,7 @@ func (c *Client) GetNFSExportByIDName(id string, name string) (respnfs *types.NF
 	if id != "" {
 		path := fmt.Sprintf("/rest/v1/nfs-exports/%s?select=*", id)
 
-		err = c.getJSONWithRetry(
+		err = c.getJSONWithRetry(ctx,
 			http.MethodGet, path, nil, &respnfs)
 		if err != nil {
 			return nil, errors.New("couldn't find NFS export by ID")
@@ -70,7 +90,7 @@ func (c *Client) GetNFSExportByIDName(id string, name string) (respnfs *types.NF
 	}
 
 	//	Get NFS export by name
-	nfsList, err := c.GetNFSExport()
+	nfsList, err := c.GetNFSExportCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -86,10 +106,16 @@ func (c *Client) GetNFSExportByIDName(id string, name string) (respnfs *types.NF
 
 // DeleteNFSExport deletes the NFS export
 func (c *Client) DeleteNFSExport(id string) error {
-	defer TimeSpent("DeleteNFSExport", time.Now())
+	return c.DeleteNFSExportCtx(c.callContext(), id)
+}
+
+// DeleteNFSExportCtx deletes the NFS export
+func (c *Client) DeleteNFSExportCtx(ctx context.Context, id string) error {
+	ctx, span := c.startSpan(ctx, "DeleteNFSExport")
+	defer span.end()
 	path := fmt.Sprintf("/rest/v1/nfs-exports/%s", id)
 
-	err := c.getJSONWithRetry(
+	err := c.getJSONWithRetry(ctx,
 		http.MethodDelete, path, nil, nil)
 	if err != nil {
 		return err
@@ -100,10 +126,18 @@ func (c *Client) DeleteNFSExport(id string) error {
 
 // ModifyNFSExport modifies the NFS export properties
 func (c *Client) ModifyNFSExport(ModifyParams *types.NFSExportModify, id string) (err error) {
+	return c.ModifyNFSExportCtx(c.callContext(), ModifyParams, id)
+}
+
+// ModifyNFSExportCtx modifies the NFS export properties
+func (c *Client) ModifyNFSExportCtx(ctx context.Context, ModifyParams *types.NFSExportModify, id string) (err error) {
+	ctx, span := c.startSpan(ctx, "ModifyNFSExport")
+	defer span.end()
+
 	path := fmt.Sprintf("/rest/v1/nfs-exports/%s", id)
 
 	var body *types.NFSExportModify = ModifyParams
-	err = c.getJSONWithRetry(http.MethodPatch, path, body, nil)
+	err = c.getJSONWithRetry(ctx, http.MethodPatch, path, body, nil)
 	if err != nil {
 		return err
 	}
@@ -13,53 +13,39 @@
 package goscaleio
 
 import (
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
+	"reflect"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // GetNodeByID gets the node details based on ID
 func (gc *GatewayClient) GetNodeByID(id string) (*types.NodeDetails, error) {
-	defer TimeSpent("GetNodeByID", time.Now())
+	return gc.GetNodeByIDCtx(context.Background(), id)
+}
+
+// GetNodeByIDCtx gets the node details based on ID
+func (gc *GatewayClient) GetNodeByIDCtx(ctx context.Context, id string) (*types.NodeDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetNodeByID")
+	defer span.end()
 
 	path := fmt.Sprintf("/Api/V1/ManagedDevice/%v", id)
 
 	var node types.NodeDetails
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	responseString, _ := extractString(httpResp)
-
 	if httpResp.StatusCode == 200 {
 		parseError := json.Unmarshal([]byte(responseString), &node)
-
 		if parseError != nil {
 			return nil, fmt.Errorf("Error While Parsing Response Data For Node: %s", parseError)
 		}
@@ -71,41 +57,27 @@ func (gc *GatewayClient) GetNodeByID(id string) (*types.NodeDetails, error) {
 
 // GetAllNodes gets all the node details
 func (gc *GatewayClient) GetAllNodes() ([]types.NodeDetails, error) {
-	defer TimeSpent("GetNodeByID", time.Now())
-
-	path := fmt.Sprintf("/Api/V1/ManagedDevice")
-
-	var nodes []types.NodeDetails
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
+	return gc.GetAllNodesCtx(context.Background())
+}
 
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
+// GetAllNodesCtx gets all the node details
+func (gc *GatewayClient) GetAllNodesCtx(ctx context.Context) ([]types.NodeDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetNodeByID")
+	defer span.end()
 
-	req.Header.Set("Content-Type", "application/json")
+	path := "/Api/V1/ManagedDevice"
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	var nodes []types.NodeDetails
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	responseString, _ := extractString(httpResp)
-
 	if httpResp.StatusCode == 200 {
 		parseError := json.Unmarshal([]byte(responseString), &nodes)
-
 		if parseError != nil {
 			return nil, fmt.Errorf("Error While Parsing Response Data For Node: %s", parseError)
 		}
@@ -117,38 +89,25 @@ func (gc *GatewayClient) GetAllNodes() ([]types.NodeDetails, error) {
 
 // GetNodeByFilters gets the node details based on the provided filter
 func (gc *GatewayClient) GetNodeByFilters(key string, value string) ([]types.NodeDetails, error) {
-	defer TimeSpent("GetNodeByFilters", time.Now())
+	return gc.GetNodeByFiltersCtx(context.Background(), key, value)
+}
+
+// GetNodeByFiltersCtx gets the node details based on the provided filter
+func (gc *GatewayClient) GetNodeByFiltersCtx(ctx context.Context, key string, value string) ([]types.NodeDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetNodeByFilters")
+	defer span.end()
 
 	path := fmt.Sprintf("/Api/V1/ManagedDevice?filter=eq,%v,%v", key, value)
 
 	var nodes []types.NodeDetails
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	responseString, _ := extractString(httpResp)
-
 	if httpResp.StatusCode == 200 {
 		parseError := json.Unmarshal([]byte(responseString), &nodes)
 		if parseError != nil {
@@ -164,65 +123,91 @@ func (gc *GatewayClient) GetNodeByFilters(key string, value string) ([]types.Nod
 	return nodes, nil
 }
 
-// GetNodePoolByID gets the nodepool details based on ID
-func (gc *GatewayClient) GetNodePoolByID(id int) (*types.NodePoolDetails, error) {
-	defer TimeSpent("GetNodePoolByID", time.Now())
+// QueryNode returns the nodes that match q. Equality conditions are sent to
+// the gateway as filters.
+func (gc *GatewayClient) QueryNode(q *QueryBuilder) ([]types.NodeDetails, error) {
+	return gc.QueryNodeCtx(context.Background(), q)
+}
 
-	path := fmt.Sprintf("/Api/V1/nodepool/%v", id)
+// QueryNodeCtx returns the nodes that match q. Equality conditions are sent
+// to the gateway as filters.
+func (gc *GatewayClient) QueryNodeCtx(ctx context.Context, q *QueryBuilder) ([]types.NodeDetails, error) {
+	ctx, span := gc.startSpan(ctx, "QueryNode")
+	defer span.end()
 
-	var nodePool types.NodePoolDetails
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
+	if q == nil {
+		q = Query()
 	}
+	path := "/Api/V1/ManagedDevice" + q.gatewayFilter(reflect.TypeOf(types.NodeDetails{}))
 
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, gatewayError(httpResp, responseString)
+	}
 
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
+	var nodes []types.NodeDetails
+	if err := json.Unmarshal([]byte(responseString), &nodes); err != nil {
+		return nil, fmt.Errorf("Error While Parsing Response Data For Node: %s", err)
 	}
+	return applyQuery(q, nodes), nil
+}
 
-	req.Header.Set("Content-Type", "application/json")
+// GetNodePoolByID gets the nodepool details based on ID
+func (gc *GatewayClient) GetNodePoolByID(id int) (*types.NodePoolDetails, error) {
+	return gc.GetNodePoolByIDCtx(context.Background(), id)
+}
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
-	}
+// GetNodePoolByIDCtx gets the nodepool details based on ID
+func (gc *GatewayClient) GetNodePoolByIDCtx(ctx context.Context, id int) (*types.NodePoolDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetNodePoolByID")
+	defer span.end()
 
-	responseString, _ := extractString(httpResp)
+	path := fmt.Sprintf("/Api/V1/nodepool/%v", id)
+
+	var nodePool types.NodePoolDetails
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	if httpResp.StatusCode == 200 && responseString != "" {
 		parseError := json.Unmarshal([]byte(responseString), &nodePool)
 		if parseError != nil {
 			return nil, fmt.Errorf("Error While Parsing Response Data For Nodepool: %s", parseError)
 		}
-
 	} else {
 		return nil, fmt.Errorf("Couldn't find nodes with the given filter")
 	}
-
 	return &nodePool, nil
 }
 
 // GetNodePoolByName gets the nodepool details based on name
 func (gc *GatewayClient) GetNodePoolByName(name string) (*types.NodePoolDetails, error) {
-	defer TimeSpent("GetNodePoolByName", time.Now())
+	return gc.GetNodePoolByNameCtx(context.Background(), name)
+}
+
+// GetNodePoolByNameCtx gets the nodepool details based on name
+func (gc *GatewayClient) GetNodePoolByNameCtx(ctx context.Context, name string) (*types.NodePoolDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetNodePoolByName")
+	defer span.end()
 
-	nodePools, err := gc.GetAllNodePools()
+	nodePools, err := gc.GetAllNodePoolsCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, nodePool := range nodePools.NodePoolDetails {
 		if nodePool.GroupName == name {
-			return gc.GetNodePoolByID(nodePool.GroupSeqID)
+			return gc.GetNodePoolByIDCtx(ctx, nodePool.GroupSeqID)
 		}
 	}
 	return nil, errors.New("no node pool found with name " + name)
@@ -230,41 +215,27 @@ func (gc *GatewayClient) GetNodePoolByName(name string) (*types.NodePoolDetails,
 
 // GetAllNodePools gets all the nodepool details
 func (gc *GatewayClient) GetAllNodePools() (*types.NodePoolDetailsFilter, error) {
-	defer TimeSpent("GetAllNodePools", time.Now())
-
-	path := fmt.Sprintf("/Api/V1/nodepool")
-
-	var nodePools types.NodePoolDetailsFilter
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
+	return gc.GetAllNodePoolsCtx(context.Background())
+}
 
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
+// GetAllNodePoolsCtx gets all the nodepool details
+func (gc *GatewayClient) GetAllNodePoolsCtx(ctx context.Context) (*types.NodePoolDetailsFilter, error) {
+	ctx, span := gc.startSpan(ctx, "GetAllNodePools")
+	defer span.end()
 
-	req.Header.Set("Content-Type", "application/json")
+	path := "/Api/V1/nodepool"
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	var nodePools types.NodePoolDetailsFilter
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	responseString, _ := extractString(httpResp)
-
 	if httpResp.StatusCode == 200 {
 		parseError := json.Unmarshal([]byte(responseString), &nodePools)
-
 		if parseError != nil {
 			return nil, fmt.Errorf("Error While Parsing Response Data For Nodepool: %s", parseError)
 		}
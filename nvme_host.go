@@ -13,9 +13,9 @@
 package goscaleio
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
@@ -36,13 +36,24 @@ func NewNvmeHost(client *Client, nvmeHost *types.NvmeHost) *NvmeHost {
 
 // GetAllNvmeHosts returns all NvmeHost list
 func (s *System) GetAllNvmeHosts() ([]types.NvmeHost, error) {
-	defer TimeSpent("GetAllNvmeHosts", time.Now())
+	return s.GetAllNvmeHostsCtx(s.client.callContext())
+}
+
+// GetAllNvmeHostsCtx returns all NvmeHost list
+func (s *System) GetAllNvmeHostsCtx(ctx context.Context) ([]types.NvmeHost, error) {
+	ctx, span := s.client.startSpan(ctx, "GetAllNvmeHosts")
+	defer span.end()
+
+	// NVMe hosts are SDCs of the NVMeHost type, which older arrays lack
+	if err := s.client.require(FeatureNVMeTCP); err != nil {
+		return nil, err
+	}
 
 	path := fmt.Sprintf("/api/instances/System::%v/relationships/Sdc",
 		s.System.ID)
 
 	var allHosts []types.NvmeHost
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &allHosts)
 	if err != nil {
 		return nil, err
@@ -60,12 +71,22 @@ func (s *System) GetAllNvmeHosts() ([]types.NvmeHost, error) {
 
 // GetNvmeHostByID returns an NVMe host searched by id
 func (s *System) GetNvmeHostByID(id string) (*types.NvmeHost, error) {
-	defer TimeSpent("GetNvmeHostByID", time.Now())
+	return s.GetNvmeHostByIDCtx(s.client.callContext(), id)
+}
+
+// GetNvmeHostByIDCtx returns an NVMe host searched by id
+func (s *System) GetNvmeHostByIDCtx(ctx context.Context, id string) (*types.NvmeHost, error) {
+	ctx, span := s.client.startSpan(ctx, "GetNvmeHostByID")
+	defer span.end()
+
+	if err := s.client.require(FeatureNVMeTCP); err != nil {
+		return nil, err
+	}
 
 	path := fmt.Sprintf("api/instances/Sdc::%v", id)
 
 	var nvmeHost types.NvmeHost
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &nvmeHost)
 	if err != nil {
 		return nil, err
@@ -76,12 +97,18 @@ func (s *System) GetNvmeHostByID(id string) (*types.NvmeHost, error) {
 
 // CreateNvmeHost creates a new NVMe host
 func (s *System) CreateNvmeHost(nvmeHostParam types.NvmeHostParam) (*types.NvmeHostResp, error) {
-	defer TimeSpent("CreateNvmeHost", time.Now())
+	return s.CreateNvmeHostCtx(s.client.callContext(), nvmeHostParam)
+}
+
+// CreateNvmeHostCtx creates a new NVMe host
+func (s *System) CreateNvmeHostCtx(ctx context.Context, nvmeHostParam types.NvmeHostParam) (*types.NvmeHostResp, error) {
+	ctx, span := s.client.startSpan(ctx, "CreateNvmeHost")
+	defer span.end()
 
 	path := "/api/types/Host/instances"
 	nvmeHostResp := &types.NvmeHostResp{}
 
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, nvmeHostParam, nvmeHostResp)
 	if err != nil {
 		return nil, err
@@ -92,14 +119,24 @@ func (s *System) CreateNvmeHost(nvmeHostParam types.NvmeHostParam) (*types.NvmeH
 
 // ChangeNvmeHostName changes the name of the Nvme host.
 func (s *System) ChangeNvmeHostName(id, name string) error {
-	defer TimeSpent("ChangeNvmeHostName", time.Now())
+	return s.ChangeNvmeHostNameCtx(s.client.callContext(), id, name)
+}
+
+// ChangeNvmeHostNameCtx changes the name of the Nvme host.
+func (s *System) ChangeNvmeHostNameCtx(ctx context.Context, id, name string) error {
+	ctx, span := s.client.startSpan(ctx, "ChangeNvmeHostName")
+	defer span.end()
+
+	if err := s.client.require(FeatureNVMeTCP); err != nil {
+		return err
+	}
 
 	path := fmt.Sprintf("/api/instances/Sdc::%v/action/setSdcName", id)
 
 	body := types.ChangeNvmeHostNameParam{
 		SdcName: name,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, nil)
 	if err != nil {
 		return err
@@ -110,14 +147,20 @@ func (s *System) ChangeNvmeHostName(id, name string) error {
 
 // ChangeNvmeHostMaxNumPaths changes the max number paths of the Nvme host.
 func (s *System) ChangeNvmeHostMaxNumPaths(id string, maxNumPaths int) error {
-	defer TimeSpent("ChangeNvmeHostMaxNumPaths", time.Now())
+	return s.ChangeNvmeHostMaxNumPathsCtx(s.client.callContext(), id, maxNumPaths)
+}
+
+// ChangeNvmeHostMaxNumPathsCtx changes the max number paths of the Nvme host.
+func (s *System) ChangeNvmeHostMaxNumPathsCtx(ctx context.Context, id string, maxNumPaths int) error {
+	ctx, span := s.client.startSpan(ctx, "ChangeNvmeHostMaxNumPaths")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Host::%v/action/modifyMaxNumPaths", id)
 
 	body := types.ChangeNvmeMaxNumPathsParam{
 		MaxNumPaths: types.IntString(maxNumPaths),
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, nil)
 	if err != nil {
 		return err
@@ -128,14 +171,20 @@ func (s *System) ChangeNvmeHostMaxNumPaths(id string, maxNumPaths int) error {
 
 // ChangeNvmeHostMaxNumSysPorts changes the max number of sys ports of the Nvme host.
 func (s *System) ChangeNvmeHostMaxNumSysPorts(id string, maxNumSysPorts int) error {
-	defer TimeSpent("ChangeNvmeHostMaxNumPaths", time.Now())
+	return s.ChangeNvmeHostMaxNumSysPortsCtx(s.client.callContext(), id, maxNumSysPorts)
+}
+
+// ChangeNvmeHostMaxNumSysPortsCtx changes the max number of sys ports of the Nvme host.
+func (s *System) ChangeNvmeHostMaxNumSysPortsCtx(ctx context.Context, id string, maxNumSysPorts int) error {
+	ctx, span := s.client.startSpan(ctx, "ChangeNvmeHostMaxNumPaths")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Host::%v/action/modifyMaxNumSysPorts", id)
 
 	body := types.ChangeNvmeHostMaxNumSysPortsParam{
 		MaxNumSysPorts: types.IntString(maxNumSysPorts),
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, nil)
 	if err != nil {
 		return err
@@ -146,12 +195,22 @@ func (s *System) ChangeNvmeHostMaxNumSysPorts(id string, maxNumSysPorts int) err
 
 // DeleteNvmeHost deletes the NVMe host
 func (s *System) DeleteNvmeHost(id string) error {
-	defer TimeSpent("DeleteNvmeHost", time.Now())
+	return s.DeleteNvmeHostCtx(s.client.callContext(), id)
+}
+
+// DeleteNvmeHostCtx deletes the NVMe host
+func (s *System) DeleteNvmeHostCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "DeleteNvmeHost")
+	defer span.end()
+
+	if err := s.client.require(FeatureNVMeTCP); err != nil {
+		return err
+	}
 
 	path := fmt.Sprintf("/api/instances/Sdc::%v/action/removeSdc", id)
 
 	param := &types.EmptyPayload{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, param, nil)
 	if err != nil {
 		return err
@@ -161,11 +220,17 @@ func (s *System) DeleteNvmeHost(id string) error {
 
 // GetHostNvmeControllers returns all attached NVMe controllers
 func (s *System) GetHostNvmeControllers(host types.NvmeHost) ([]types.NvmeController, error) {
-	defer TimeSpent("GetHostNvmeControllers", time.Now())
+	return s.GetHostNvmeControllersCtx(s.client.callContext(), host)
+}
+
+// GetHostNvmeControllersCtx returns all attached NVMe controllers
+func (s *System) GetHostNvmeControllersCtx(ctx context.Context, host types.NvmeHost) ([]types.NvmeController, error) {
+	ctx, span := s.client.startSpan(ctx, "GetHostNvmeControllers")
+	defer span.end()
 	path := fmt.Sprintf("api/instances/Host::%v/relationships/NvmeController", host.ID)
 
 	var nvmeControllers []types.NvmeController
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &nvmeControllers)
 	return nvmeControllers, err
 }
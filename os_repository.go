@@ -13,9 +13,9 @@
 package goscaleio
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
@@ -24,10 +24,16 @@ const osRepoPath = "/api/v1/OSRepository"
 
 // GetAllOSRepositories Gets all OS Repositories
 func (s *System) GetAllOSRepositories() ([]types.OSRepository, error) {
-	defer TimeSpent("GetAllOSRepositories", time.Now())
+	return s.GetAllOSRepositoriesCtx(s.client.callContext())
+}
+
+// GetAllOSRepositoriesCtx Gets all OS Repositories
+func (s *System) GetAllOSRepositoriesCtx(ctx context.Context) ([]types.OSRepository, error) {
+	ctx, span := s.client.startSpan(ctx, "GetAllOSRepositories")
+	defer span.end()
 
 	var osRepositories []types.OSRepository
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, osRepoPath, nil, &osRepositories)
 	if err != nil {
 		return nil, err
@@ -37,11 +43,17 @@ func (s *System) GetAllOSRepositories() ([]types.OSRepository, error) {
 
 // GetOSRepositoryByID Gets OS Repository by ID
 func (s *System) GetOSRepositoryByID(id string) (*types.OSRepository, error) {
-	defer TimeSpent("GetOSRepositoryByID", time.Now())
+	return s.GetOSRepositoryByIDCtx(s.client.callContext(), id)
+}
+
+// GetOSRepositoryByIDCtx Gets OS Repository by ID
+func (s *System) GetOSRepositoryByIDCtx(ctx context.Context, id string) (*types.OSRepository, error) {
+	ctx, span := s.client.startSpan(ctx, "GetOSRepositoryByID")
+	defer span.end()
 
 	pathWithID := fmt.Sprintf("%v/%v", osRepoPath, id)
 	var osRepository types.OSRepository
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, pathWithID, nil, &osRepository)
 	if err != nil {
 		return nil, err
@@ -52,7 +64,13 @@ func (s *System) GetOSRepositoryByID(id string) (*types.OSRepository, error) {
 
 // CreateOSRepository Creates OS Repository
 func (s *System) CreateOSRepository(createOSRepository *types.OSRepository) (*types.OSRepository, error) {
-	defer TimeSpent("CreateOSRepository", time.Now())
+	return s.CreateOSRepositoryCtx(s.client.callContext(), createOSRepository)
+}
+
+// CreateOSRepositoryCtx Creates OS Repository
+func (s *System) CreateOSRepositoryCtx(ctx context.Context, createOSRepository *types.OSRepository) (*types.OSRepository, error) {
+	ctx, span := s.client.startSpan(ctx, "CreateOSRepository")
+	defer span.end()
 	var createResponse types.OSRepository
 	if createOSRepository == nil {
 		return &createResponse, fmt.Errorf("createOSRepository cannot be nil")
@@ -63,7 +81,7 @@ func (s *System) CreateOSRepository(createOSRepository *types.OSRepository) (*ty
 		"sourcePath": createOSRepository.SourcePath,
 		"imageType":  createOSRepository.ImageType,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, osRepoPath, bodyData, &createResponse)
 	if err != nil {
 		return nil, err
@@ -74,9 +92,15 @@ func (s *System) CreateOSRepository(createOSRepository *types.OSRepository) (*ty
 
 // RemoveOSRepository Removes OS Repository
 func (s *System) RemoveOSRepository(id string) error {
-	defer TimeSpent("RemoveOSRepository", time.Now())
+	return s.RemoveOSRepositoryCtx(s.client.callContext(), id)
+}
+
+// RemoveOSRepositoryCtx Removes OS Repository
+func (s *System) RemoveOSRepositoryCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "RemoveOSRepository")
+	defer span.end()
 	pathWithID := fmt.Sprintf("%v/%v", osRepoPath, id)
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodDelete, pathWithID, nil, nil)
 	if err != nil {
 		return err
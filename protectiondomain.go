@@ -13,11 +13,11 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
@@ -46,7 +46,13 @@ func NewProtectionDomainEx(client *Client, pd *types.ProtectionDomain) *Protecti
 
 // CreateProtectionDomain creates a ProtectionDomain
 func (s *System) CreateProtectionDomain(name string) (string, error) {
-	defer TimeSpent("CreateProtectionDomain", time.Now())
+	return s.CreateProtectionDomainCtx(s.client.callContext(), name)
+}
+
+// CreateProtectionDomainCtx creates a ProtectionDomain
+func (s *System) CreateProtectionDomainCtx(ctx context.Context, name string) (string, error) {
+	ctx, span := s.client.startSpan(ctx, "CreateProtectionDomain")
+	defer span.end()
 
 	protectionDomainParam := &types.ProtectionDomainParam{
 		Name: name,
@@ -55,7 +61,7 @@ func (s *System) CreateProtectionDomain(name string) (string, error) {
 	path := fmt.Sprintf("/api/types/ProtectionDomain/instances")
 
 	pd := types.ProtectionDomainResp{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, protectionDomainParam, &pd)
 	if err != nil {
 		return "", err
@@ -66,8 +72,14 @@ func (s *System) CreateProtectionDomain(name string) (string, error) {
 
 // GetProtectionDomainEx fetches a ProtectionDomain by ID with embedded client
 func (s *System) GetProtectionDomainEx(id string) (*ProtectionDomain, error) {
-	defer TimeSpent("GetProtectionDomainEx", time.Now())
-	pdResp, err := s.FindProtectionDomainByID(id)
+	return s.GetProtectionDomainExCtx(s.client.callContext(), id)
+}
+
+// GetProtectionDomainExCtx fetches a ProtectionDomain by ID with embedded client
+func (s *System) GetProtectionDomainExCtx(ctx context.Context, id string) (*ProtectionDomain, error) {
+	ctx, span := s.client.startSpan(ctx, "GetProtectionDomainEx")
+	defer span.end()
+	pdResp, err := s.FindProtectionDomainByIDCtx(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -76,8 +88,16 @@ func (s *System) GetProtectionDomainEx(id string) (*ProtectionDomain, error) {
 
 // DeleteProtectionDomain will delete a protection domain
 func (s *System) DeleteProtectionDomain(name string) error {
+	return s.DeleteProtectionDomainCtx(s.client.callContext(), name)
+}
+
+// DeleteProtectionDomainCtx will delete a protection domain
+func (s *System) DeleteProtectionDomainCtx(ctx context.Context, name string) error {
+	ctx, span := s.client.startSpan(ctx, "DeleteProtectionDomain")
+	defer span.end()
+
 	// get the protection domain
-	domain, err := s.FindProtectionDomain("", name, "")
+	domain, err := s.FindProtectionDomainCtx(ctx, "", name, "")
 	if err != nil {
 		return err
 	}
@@ -91,7 +111,7 @@ func (s *System) DeleteProtectionDomain(name string) error {
 
 	path := fmt.Sprintf("%v/action/removeProtectionDomain", link.HREF)
 
-	err = s.client.getJSONWithRetry(
+	err = s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, protectionDomainParam, nil)
 	if err != nil {
 		return err
@@ -102,6 +122,14 @@ func (s *System) DeleteProtectionDomain(name string) error {
 
 // Delete (ProtectionDomain) will delete a protection domain
 func (pd *ProtectionDomain) Delete() error {
+	return pd.DeleteCtx(pd.client.callContext())
+}
+
+// DeleteCtx (ProtectionDomain) will delete a protection domain
+func (pd *ProtectionDomain) DeleteCtx(ctx context.Context) error {
+	ctx, span := pd.client.startSpan(ctx, "Delete")
+	defer span.end()
+
 	link, err := GetLink(pd.ProtectionDomain.Links, "self")
 	if err != nil {
 		return err
@@ -111,7 +139,7 @@ func (pd *ProtectionDomain) Delete() error {
 
 	path := fmt.Sprintf("%v/action/removeProtectionDomain", link.HREF)
 
-	err = pd.client.getJSONWithRetry(
+	err = pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, protectionDomainParam, nil)
 	if err != nil {
 		return err
@@ -124,7 +152,15 @@ func (pd *ProtectionDomain) Delete() error {
 func (s *System) GetProtectionDomain(
 	pdhref string,
 ) ([]*types.ProtectionDomain, error) {
-	defer TimeSpent("GetprotectionDomain", time.Now())
+	return s.GetProtectionDomainCtx(s.client.callContext(), pdhref)
+}
+
+// GetProtectionDomainCtx returns a ProtectionDomain
+func (s *System) GetProtectionDomainCtx(ctx context.Context,
+	pdhref string,
+) ([]*types.ProtectionDomain, error) {
+	ctx, span := s.client.startSpan(ctx, "GetprotectionDomain")
+	defer span.end()
 
 	var (
 		err error
@@ -141,10 +177,10 @@ func (s *System) GetProtectionDomain(
 			return nil, err
 		}
 
-		err = s.client.getJSONWithRetry(
+		err = s.client.getJSONWithRetry(ctx,
 			http.MethodGet, link.HREF, nil, &pds)
 	} else {
-		err = s.client.getJSONWithRetry(
+		err = s.client.getJSONWithRetry(ctx,
 			http.MethodGet, pdhref, nil, pd)
 	}
 	if err != nil {
@@ -161,9 +197,17 @@ func (s *System) GetProtectionDomain(
 func (s *System) FindProtectionDomain(
 	id, name, href string,
 ) (*types.ProtectionDomain, error) {
-	defer TimeSpent("FindProtectionDomain", time.Now())
+	return s.FindProtectionDomainCtx(s.client.callContext(), id, name, href)
+}
+
+// FindProtectionDomainCtx returns a ProtectionDomain
+func (s *System) FindProtectionDomainCtx(ctx context.Context,
+	id, name, href string,
+) (*types.ProtectionDomain, error) {
+	ctx, span := s.client.startSpan(ctx, "FindProtectionDomain")
+	defer span.end()
 
-	pds, err := s.GetProtectionDomain(href)
+	pds, err := s.GetProtectionDomainCtx(ctx, href)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting protection domains %s", err)
 	}
@@ -179,10 +223,16 @@ func (s *System) FindProtectionDomain(
 
 // FindProtectionDomainByID returns the ProtectionDomain having a particular ID
 func (s *System) FindProtectionDomainByID(id string) (*types.ProtectionDomain, error) {
-	defer TimeSpent("FindProtectionDomainByID", time.Now())
+	return s.FindProtectionDomainByIDCtx(s.client.callContext(), id)
+}
+
+// FindProtectionDomainByIDCtx returns the ProtectionDomain having a particular ID
+func (s *System) FindProtectionDomainByIDCtx(ctx context.Context, id string) (*types.ProtectionDomain, error) {
+	ctx, span := s.client.startSpan(ctx, "FindProtectionDomainByID")
+	defer span.end()
 
 	href := fmt.Sprintf("/api/instances/ProtectionDomain::%s", id)
-	pds, err := s.GetProtectionDomain(href)
+	pds, err := s.GetProtectionDomainCtx(ctx, href)
 	if err != nil {
 		return nil, fmt.Errorf("error getting protection domain by id: %s", err)
 	}
@@ -194,37 +244,57 @@ func (s *System) FindProtectionDomainByID(id string) (*types.ProtectionDomain, e
 
 // FindProtectionDomainByName returns the ProtectionDomain having a particular name
 func (s *System) FindProtectionDomainByName(name string) (*types.ProtectionDomain, error) {
-	defer TimeSpent("FindProtectionDomainByName", time.Now())
+	return s.FindProtectionDomainByNameCtx(s.client.callContext(), name)
+}
+
+// FindProtectionDomainByNameCtx returns the ProtectionDomain having a particular name
+func (s *System) FindProtectionDomainByNameCtx(ctx context.Context, name string) (*types.ProtectionDomain, error) {
+	ctx, span := s.client.startSpan(ctx, "FindProtectionDomainByName")
+	defer span.end()
 
 	var id string
 	path := "/api/types/ProtectionDomain/instances/action/queryIdByKey"
 	body := map[string]string{
 		"name": name,
 	}
-	err := s.client.getJSONWithRetry(http.MethodPost, path, body, &id)
+	err := s.client.getJSONWithRetry(ctx, http.MethodPost, path, body, &id)
 	if err != nil {
 		return nil, fmt.Errorf("error getting protection domain by name: %s", err)
 	}
-	return s.FindProtectionDomainByID(id)
+	return s.FindProtectionDomainByIDCtx(ctx, id)
 }
 
 // SetName sets the name of the pd
 func (pd *ProtectionDomain) SetName(name string) error {
+	return pd.SetNameCtx(pd.client.callContext(), name)
+}
+
+// SetNameCtx sets the name of the pd
+func (pd *ProtectionDomain) SetNameCtx(ctx context.Context, name string) error {
+	ctx, span := pd.client.startSpan(ctx, "SetName")
+	defer span.end()
+
 	path := "/api/instances/ProtectionDomain::%s/action/setProtectionDomainName"
 	nameParam := types.ProtectionDomainParam{
 		Name: name,
 	}
-	return pd.setParam(path, nameParam)
+	return pd.setParam(ctx, path, nameParam)
 }
 
 // Refresh reads and stores current values of the pd
 func (pd *ProtectionDomain) Refresh() error {
-	defer TimeSpent("Refresh Protection Domain", time.Now())
+	return pd.RefreshCtx(pd.client.callContext())
+}
+
+// RefreshCtx reads and stores current values of the pd
+func (pd *ProtectionDomain) RefreshCtx(ctx context.Context) error {
+	ctx, span := pd.client.startSpan(ctx, "Refresh Protection Domain")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/ProtectionDomain::%s", pd.ProtectionDomain.ID)
 
 	pdResp := types.ProtectionDomain{}
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, &types.EmptyPayload{}, &pdResp)
 	if err != nil {
 		return err
@@ -235,65 +305,137 @@ func (pd *ProtectionDomain) Refresh() error {
 
 // SetRfcacheParams sets the Read Flash Cache params of the pd
 func (pd *ProtectionDomain) SetRfcacheParams(params types.PDRfCacheParams) error {
+	return pd.SetRfcacheParamsCtx(pd.client.callContext(), params)
+}
+
+// SetRfcacheParamsCtx sets the Read Flash Cache params of the pd
+func (pd *ProtectionDomain) SetRfcacheParamsCtx(ctx context.Context, params types.PDRfCacheParams) error {
+	ctx, span := pd.client.startSpan(ctx, "SetRfcacheParams")
+	defer span.end()
+
 	path := "/api/instances/ProtectionDomain::%s/action/setRfcacheParameters"
-	return pd.setParam(path, params)
+	return pd.setParam(ctx, path, params)
 }
 
 // SetSdsNetworkLimits sets IOPS limits on all SDS under the pd
 func (pd *ProtectionDomain) SetSdsNetworkLimits(params types.SdsNetworkLimitParams) error {
+	return pd.SetSdsNetworkLimitsCtx(pd.client.callContext(), params)
+}
+
+// SetSdsNetworkLimitsCtx sets IOPS limits on all SDS under the pd
+func (pd *ProtectionDomain) SetSdsNetworkLimitsCtx(ctx context.Context, params types.SdsNetworkLimitParams) error {
+	ctx, span := pd.client.startSpan(ctx, "SetSdsNetworkLimits")
+	defer span.end()
+
 	path := "/api/instances/ProtectionDomain::%s/action/setSdsNetworkLimits"
-	return pd.setParam(path, params)
+	return pd.setParam(ctx, path, params)
 }
 
-func (pd *ProtectionDomain) setParam(path string, param any) error {
+func (pd *ProtectionDomain) setParam(ctx context.Context, path string, param any) error {
 	link := fmt.Sprintf(path, pd.ProtectionDomain.ID)
-	return pd.client.getJSONWithRetry(http.MethodPost, link, param, nil)
+	return pd.client.getJSONWithRetry(ctx, http.MethodPost, link, param, nil)
 }
 
 // Activate activates the Protection domain
 func (pd *ProtectionDomain) Activate(forceActivate bool) error {
+	return pd.ActivateCtx(pd.client.callContext(), forceActivate)
+}
+
+// ActivateCtx activates the Protection domain
+func (pd *ProtectionDomain) ActivateCtx(ctx context.Context, forceActivate bool) error {
+	ctx, span := pd.client.startSpan(ctx, "Activate")
+	defer span.end()
+
 	path := "/api/instances/ProtectionDomain::%s/action/activateProtectionDomain"
-	return pd.setParam(path, map[string]string{
+	return pd.setParam(ctx, path, map[string]string{
 		"forceActivate": types.GetBoolType(forceActivate),
 	})
 }
 
 // InActivate disables the Protection domain
 func (pd *ProtectionDomain) InActivate(forceShutDown bool) error {
+	return pd.InActivateCtx(pd.client.callContext(), forceShutDown)
+}
+
+// InActivateCtx disables the Protection domain
+func (pd *ProtectionDomain) InActivateCtx(ctx context.Context, forceShutDown bool) error {
+	ctx, span := pd.client.startSpan(ctx, "InActivate")
+	defer span.end()
+
 	path := "/api/instances/ProtectionDomain::%s/action/inactivateProtectionDomain"
-	return pd.setParam(path, map[string]string{
+	return pd.setParam(ctx, path, map[string]string{
 		"forceShutdown": types.GetBoolType(forceShutDown),
 	})
 }
 
 // EnableRfcache enables SDS Read Flash cache for entire Protection Domain
 func (pd *ProtectionDomain) EnableRfcache() error {
+	return pd.EnableRfcacheCtx(pd.client.callContext())
+}
+
+// EnableRfcacheCtx enables SDS Read Flash cache for entire Protection Domain
+func (pd *ProtectionDomain) EnableRfcacheCtx(ctx context.Context) error {
+	ctx, span := pd.client.startSpan(ctx, "EnableRfcache")
+	defer span.end()
+
 	path := "/api/instances/ProtectionDomain::%s/action/enableSdsRfcache"
-	return pd.setParam(path, &types.EmptyPayload{})
+	return pd.setParam(ctx, path, &types.EmptyPayload{})
 }
 
 // DisableRfcache disables SDS Read Flash cache for entire Protection Domain
 func (pd *ProtectionDomain) DisableRfcache() error {
+	return pd.DisableRfcacheCtx(pd.client.callContext())
+}
+
+// DisableRfcacheCtx disables SDS Read Flash cache for entire Protection Domain
+func (pd *ProtectionDomain) DisableRfcacheCtx(ctx context.Context) error {
+	ctx, span := pd.client.startSpan(ctx, "DisableRfcache")
+	defer span.end()
+
 	path := "/api/instances/ProtectionDomain::%s/action/disableSdsRfcache"
-	return pd.setParam(path, &types.EmptyPayload{})
+	return pd.setParam(ctx, path, &types.EmptyPayload{})
 }
 
 // DisableFGLMcache disables Fine Granularity Metadata cache for the Protection Domain
 func (pd *ProtectionDomain) DisableFGLMcache() error {
+	return pd.DisableFGLMcacheCtx(pd.client.callContext())
+}
+
+// DisableFGLMcacheCtx disables Fine Granularity Metadata cache for the Protection Domain
+func (pd *ProtectionDomain) DisableFGLMcacheCtx(ctx context.Context) error {
+	ctx, span := pd.client.startSpan(ctx, "DisableFGLMcache")
+	defer span.end()
+
 	path := "/api/instances/ProtectionDomain::%s/action/disableFglMetadataCache"
-	return pd.setParam(path, &types.EmptyPayload{})
+	return pd.setParam(ctx, path, &types.EmptyPayload{})
 }
 
 // EnableFGLMcache enables Fine Granularity Metadata cache for the Protection Domain
 func (pd *ProtectionDomain) EnableFGLMcache() error {
+	return pd.EnableFGLMcacheCtx(pd.client.callContext())
+}
+
+// EnableFGLMcacheCtx enables Fine Granularity Metadata cache for the Protection Domain
+func (pd *ProtectionDomain) EnableFGLMcacheCtx(ctx context.Context) error {
+	ctx, span := pd.client.startSpan(ctx, "EnableFGLMcache")
+	defer span.end()
+
 	path := "/api/instances/ProtectionDomain::%s/action/enableFglMetadataCache"
-	return pd.setParam(path, &types.EmptyPayload{})
+	return pd.setParam(ctx, path, &types.EmptyPayload{})
 }
 
 // SetDefaultFGLMcacheSize sets the default FGL Metadata for all SDSs under the Protection Domain
 func (pd *ProtectionDomain) SetDefaultFGLMcacheSize(cacheSizeInMB int) error {
+	return pd.SetDefaultFGLMcacheSizeCtx(pd.client.callContext(), cacheSizeInMB)
+}
+
+// SetDefaultFGLMcacheSizeCtx sets the default FGL Metadata for all SDSs under the Protection Domain
+func (pd *ProtectionDomain) SetDefaultFGLMcacheSizeCtx(ctx context.Context, cacheSizeInMB int) error {
+	ctx, span := pd.client.startSpan(ctx, "SetDefaultFGLMcacheSize")
+	defer span.end()
+
 	path := "/api/instances/ProtectionDomain::%s/action/setDefaultFglMetadataCacheSize"
-	return pd.setParam(path, map[string]string{
+	return pd.setParam(ctx, path, map[string]string{
 		"cacheSizeInMB": strconv.Itoa(cacheSizeInMB),
 	})
 }
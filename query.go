@@ -0,0 +1,303 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Op is the comparison a query condition applies to a field.
+type Op string
+
+const (
+	// Eq matches a field equal to the value.
+	Eq Op = "eq"
+	// Ne matches a field not equal to the value.
+	Ne Op = "ne"
+	// In matches a field equal to one of the elements of the value, a slice.
+	In Op = "in"
+	// Contains matches a field whose text contains the value.
+	Contains Op = "contains"
+)
+
+type condition struct {
+	field string
+	op    Op
+	value interface{}
+}
+
+// QueryBuilder selects the objects returned by list calls such as
+// System.QuerySdc. Conditions on the ID or the name are sent to the server
+// when the endpoint can look objects up by them; every condition is then
+// checked on the client, so the results are the same either way.
+type QueryBuilder struct {
+	conditions []condition
+	fields     []string
+}
+
+// Query returns a query that matches every object.
+func Query() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// Where adds a condition every result must meet. field is the JSON name
+// ("name") or the Go name ("Name") of the field, matched case-insensitively.
+func (q *QueryBuilder) Where(field string, op Op, value interface{}) *QueryBuilder {
+	q.conditions = append(q.conditions, condition{field: field, op: op, value: value})
+	return q
+}
+
+// Fields keeps only the given fields in the results and leaves the others
+// zero. The selection is sent as the fields parameter of the MDM list
+// requests, along with the fields the conditions need, and applied on the
+// client as well for the endpoints that return whole objects.
+func (q *QueryBuilder) Fields(fields ...string) *QueryBuilder {
+	q.fields = append(q.fields, fields...)
+	return q
+}
+
+// Match reports whether v, a struct or a pointer to one, meets every
+// condition of q. A condition on a field v does not have never matches.
+func (q *QueryBuilder) Match(v interface{}) bool {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return false
+	}
+	for _, c := range q.conditions {
+		i, ok := fieldIndex(rv.Type(), c.field)
+		if !ok || !c.match(rv.Field(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// clone returns a copy of q that can be extended without changing q.
+func (q *QueryBuilder) clone() *QueryBuilder {
+	if q == nil {
+		return Query()
+	}
+	return &QueryBuilder{
+		conditions: slices.Clone(q.conditions),
+		fields:     slices.Clone(q.fields),
+	}
+}
+
+// lookup returns the ID, the IDs or the name the server can find the
+// results of q by, in that order of preference.
+func (q *QueryBuilder) lookup() (id string, ids []string, name string) {
+	for _, c := range q.conditions {
+		switch {
+		case strings.EqualFold(c.field, "id") && c.op == Eq && id == "":
+			id = fmt.Sprint(c.value)
+		case strings.EqualFold(c.field, "id") && c.op == In && ids == nil:
+			ids = c.values()
+		case strings.EqualFold(c.field, "name") && c.op == Eq && name == "":
+			name = fmt.Sprint(c.value)
+		}
+	}
+	return id, ids, name
+}
+
+// gatewayFilter returns the gateway filter parameters for the equality
+// conditions of q on fields of t, e.g. "?filter=eq,ipAddress,1.1.1.1".
+func (q *QueryBuilder) gatewayFilter(t reflect.Type) string {
+	var filters []string
+	for _, c := range q.conditions {
+		if c.op != Eq {
+			continue
+		}
+		i, ok := fieldIndex(t, c.field)
+		if !ok {
+			continue
+		}
+		key := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if key == "" {
+			continue
+		}
+		filters = append(filters, "filter=eq,"+url.QueryEscape(key)+","+url.QueryEscape(fmt.Sprint(c.value)))
+	}
+	if len(filters) == 0 {
+		return ""
+	}
+	return "?" + strings.Join(filters, "&")
+}
+
+// fieldsParam returns the fields parameter selecting the fields of t that
+// Fields and the conditions of q name, e.g. "fields=id,name", or "" when q
+// selects every field.
+func (q *QueryBuilder) fieldsParam(t reflect.Type) string {
+	if len(q.fields) == 0 {
+		return ""
+	}
+	var keys []string
+	names := slices.Clone(q.fields)
+	for _, c := range q.conditions {
+		names = append(names, c.field)
+	}
+	for _, name := range names {
+		i, ok := fieldIndex(t, name)
+		if !ok {
+			continue
+		}
+		key := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	return "fields=" + strings.Join(keys, ",")
+}
+
+// project zeroes the fields of v, a pointer to a struct, not selected by
+// Fields.
+func (q *QueryBuilder) project(v reflect.Value) {
+	if len(q.fields) == 0 {
+		return
+	}
+	rv := v.Elem()
+	keep := make([]bool, rv.NumField())
+	for _, f := range q.fields {
+		if i, ok := fieldIndex(rv.Type(), f); ok {
+			keep[i] = true
+		}
+	}
+	for i, k := range keep {
+		if !k && rv.Field(i).CanSet() {
+			rv.Field(i).SetZero()
+		}
+	}
+}
+
+func (c condition) values() []string {
+	rv := reflect.ValueOf(c.value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []string{fmt.Sprint(c.value)}
+	}
+	values := make([]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		values = append(values, fmt.Sprint(rv.Index(i).Interface()))
+	}
+	return values
+}
+
+func (c condition) match(field reflect.Value) bool {
+	s := fmt.Sprint(field.Interface())
+	switch c.op {
+	case Eq:
+		return s == fmt.Sprint(c.value)
+	case Ne:
+		return s != fmt.Sprint(c.value)
+	case In:
+		return slices.Contains(c.values(), s)
+	case Contains:
+		return strings.Contains(s, fmt.Sprint(c.value))
+	}
+	return false
+}
+
+// fieldIndex returns the index of the exported field of t with the given Go
+// or JSON name.
+func fieldIndex(t reflect.Type, name string) (int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if strings.EqualFold(f.Name, name) || (tag != "" && strings.EqualFold(tag, name)) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// applyQuery returns the items that match q, reduced to its fields.
+func applyQuery[T any](q *QueryBuilder, items []T) []T {
+	var matched []T
+	for i := range items {
+		if q.Match(&items[i]) {
+			q.project(reflect.ValueOf(&items[i]))
+			matched = append(matched, items[i])
+		}
+	}
+	return matched
+}
+
+// mdmType describes how the objects of an MDM type are listed and looked up.
+type mdmType struct {
+	// name is the REST type name, as in /api/types/<name>/instances.
+	name string
+	// list is the path listing every object the query may return.
+	list string
+	// byName is whether queryIdByKey accepts a name for the type.
+	byName bool
+}
+
+// queryMDM returns the objects of type m that match q. The objects are
+// fetched by ID, by queryBySelectedIds or by name when q allows it, and
+// listed otherwise.
+func queryMDM[T any](ctx context.Context, c *Client, m mdmType, q *QueryBuilder) ([]T, error) {
+	if q == nil {
+		q = Query()
+	}
+
+	var (
+		items []T
+		err   error
+	)
+	id, ids, name := q.lookup()
+	if id == "" && ids == nil && name != "" && m.byName {
+		path := fmt.Sprintf("/api/types/%s/instances/action/queryIdByKey", m.name)
+		id, err = c.getStringWithRetry(ctx, http.MethodPost, path, map[string]string{"name": name})
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case id != "":
+		var item T
+		path := fmt.Sprintf("/api/instances/%s::%s", m.name, id)
+		err = c.getJSONWithRetry(ctx, http.MethodGet, path, nil, &item)
+		items = []T{item}
+	case ids != nil:
+		items, err = queryBySelectedIDs[T](ctx, c, m.name, ids)
+	default:
+		path := m.list
+		if fields := q.fieldsParam(reflect.TypeOf(items).Elem()); fields != "" {
+			path += "?" + fields
+		}
+		err = c.getJSONWithRetry(ctx, http.MethodGet, path, nil, &items)
+	}
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return applyQuery(q, items), nil
+}
@@ -0,0 +1,232 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	types "github.com/dell/goscaleio/types/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryMatch(t *testing.T) {
+	vol := &types.Volume{ID: "v1", Name: "data-01", SizeInKb: 8192, VolumeType: "ThinProvisioned"}
+
+	cases := map[string]struct {
+		q    *QueryBuilder
+		want bool
+	}{
+		"empty":             {Query(), true},
+		"json name":         {Query().Where("name", Eq, "data-01"), true},
+		"go name":           {Query().Where("Name", Eq, "data-01"), true},
+		"case insensitive":  {Query().Where("VOLUMETYPE", Eq, "ThinProvisioned"), true},
+		"int field":         {Query().Where("sizeInKb", Eq, 8192), true},
+		"ne":                {Query().Where("name", Ne, "data-01"), false},
+		"in":                {Query().Where("id", In, []string{"v0", "v1"}), true},
+		"not in":            {Query().Where("id", In, []string{"v0"}), false},
+		"contains":          {Query().Where("name", Contains, "data"), true},
+		"all conditions":    {Query().Where("name", Contains, "data").Where("id", Eq, "v2"), false},
+		"unknown field":     {Query().Where("color", Eq, "blue"), false},
+		"unknown operation": {Query().Where("name", Op("gt"), "a"), false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.q.Match(vol))
+		})
+	}
+
+	assert.False(t, Query().Match("not a struct"))
+}
+
+func TestQueryFields(t *testing.T) {
+	vols := applyQuery(Query().Fields("id", "Name"), []types.Volume{
+		{ID: "v1", Name: "data-01", SizeInKb: 8192},
+	})
+	assert.Equal(t, []types.Volume{{ID: "v1", Name: "data-01"}}, vols)
+
+	volumeType := reflect.TypeOf(types.Volume{})
+	assert.Equal(t, "", Query().Where("name", Eq, "data-01").fieldsParam(volumeType))
+	assert.Equal(t, "fields=id,name", Query().Fields("ID", "id", "color").Where("Name", Eq, "data-01").fieldsParam(volumeType))
+}
+
+func TestQueryGatewayFilter(t *testing.T) {
+	nodeType := reflect.TypeOf(types.NodeDetails{})
+	assert.Equal(t, "", Query().gatewayFilter(nodeType))
+	assert.Equal(t, "?filter=eq,ipAddress,1.1.1.1&filter=eq,state,READY",
+		Query().Where("IPAddress", Eq, "1.1.1.1").Where("state", Eq, "READY").
+			Where("model", Contains, "VMware").Where("color", Eq, "blue").gatewayFilter(nodeType))
+}
+
+func TestQueryVolume(t *testing.T) {
+	var (
+		paths  []string
+		fields string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/api/types/Volume/instances":
+			fields = r.URL.Query().Get("fields")
+			json.NewEncoder(w).Encode([]types.Volume{{ID: "v1", Name: "data-01"}, {ID: "v2", Name: "logs-01"}})
+		case "/api/types/Volume/instances/action/queryIdByKey":
+			var key map[string]string
+			json.NewDecoder(r.Body).Decode(&key)
+			if key["name"] != "data-01" {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"message":"Not found","httpStatusCode":500,"errorCode":3}`))
+				return
+			}
+			w.Write([]byte(`"v1"`))
+		case "/api/instances/Volume::v1":
+			json.NewEncoder(w).Encode(types.Volume{ID: "v1", Name: "data-01"})
+		case "/api/types/Volume/instances/action/queryBySelectedIds":
+			var ids map[string][]string
+			json.NewDecoder(r.Body).Decode(&ids)
+			assert.Equal(t, []string{"v1", "v2"}, ids["ids"])
+			json.NewEncoder(w).Encode([]types.Volume{{ID: "v1", Name: "data-01"}, {ID: "v2", Name: "logs-01"}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not found","httpStatusCode":404,"errorCode":0}`))
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		q     *QueryBuilder
+		paths []string
+		ids   []string
+	}{
+		"list": {
+			Query().Where("name", Contains, "logs"),
+			[]string{"GET /api/types/Volume/instances"},
+			[]string{"v2"},
+		},
+		"by name": {
+			Query().Where("name", Eq, "data-01"),
+			[]string{"POST /api/types/Volume/instances/action/queryIdByKey", "GET /api/instances/Volume::v1"},
+			[]string{"v1"},
+		},
+		"by missing name": {
+			Query().Where("name", Eq, "missing"),
+			[]string{"POST /api/types/Volume/instances/action/queryIdByKey"},
+			nil,
+		},
+		"by id": {
+			Query().Where("id", Eq, "v1").Where("name", Eq, "data-01"),
+			[]string{"GET /api/instances/Volume::v1"},
+			[]string{"v1"},
+		},
+		"by missing id": {
+			Query().Where("id", Eq, "v3"),
+			[]string{"GET /api/instances/Volume::v3"},
+			nil,
+		},
+		"by ids": {
+			Query().Where("id", In, []string{"v1", "v2"}).Where("name", Ne, "data-01"),
+			[]string{"POST /api/types/Volume/instances/action/queryBySelectedIds"},
+			[]string{"v2"},
+		},
+		"by no ids": {
+			Query().Where("id", In, []string{}),
+			nil,
+			nil,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			paths = nil
+			vols, err := client.QueryVolume(tc.q)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.paths, paths)
+
+			var ids []string
+			for _, v := range vols {
+				ids = append(ids, v.ID)
+			}
+			assert.Equal(t, tc.ids, ids)
+		})
+	}
+
+	// the selected fields are sent along with the ones the conditions need,
+	// and the results are trimmed when the array ignores them
+	vols, err := client.QueryVolume(Query().Where("name", Contains, "logs").Fields("id", "SizeInKb"))
+	assert.NoError(t, err)
+	assert.Equal(t, "id,sizeInKb,name", fields)
+	assert.Equal(t, []types.Volume{{ID: "v2"}}, vols)
+}
+
+func TestQuerySds(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/types/Sds/instances/action/queryIdByKey":
+			w.Write([]byte(`"s1"`))
+		case "/api/instances/Sds::s1":
+			json.NewEncoder(w).Encode(types.Sds{ID: "s1", Name: "sds-1", ProtectionDomainID: "pd2"})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"no route handled","httpStatusCode":400,"errorCode":0}`))
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+
+	q := Query().Where("name", Eq, "sds-1")
+	pd := NewProtectionDomainEx(client, &types.ProtectionDomain{ID: "pd1"})
+	sdss, err := pd.QuerySds(q)
+	assert.NoError(t, err)
+	assert.Empty(t, sdss)
+
+	// the protection domain condition is not added to q
+	pd = NewProtectionDomainEx(client, &types.ProtectionDomain{ID: "pd2"})
+	sdss, err = pd.QuerySds(q)
+	assert.NoError(t, err)
+	assert.Len(t, sdss, 1)
+
+	_, err = pd.QuerySds(Query())
+	assert.ErrorContains(t, err, "no route handled")
+}
+
+func TestQueryNode(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/Api/V1/ManagedDevice" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found","httpStatusCode":404,"errorCode":0}`))
+			return
+		}
+		assert.Equal(t, []string{"eq,deviceType,SoftwareOnlyServer"}, r.URL.Query()["filter"])
+		json.NewEncoder(w).Encode([]types.NodeDetails{
+			{RefID: "1", DeviceType: "SoftwareOnlyServer", Model: "VMware Virtual Platform"},
+			{RefID: "2", DeviceType: "SoftwareOnlyServer", Model: "PowerEdge R650"},
+		})
+	}))
+	defer ts.Close()
+
+	gc := &GatewayClient{http: ts.Client(), host: ts.URL, version: "4.0", token: "token"}
+	nodes, err := gc.QueryNode(Query().Where("deviceType", Eq, "SoftwareOnlyServer").Where("model", Contains, "PowerEdge").Fields("refId"))
+	assert.NoError(t, err)
+	assert.Equal(t, []types.NodeDetails{{RefID: "2"}}, nodes)
+
+	gc.host = ts.URL + "/missing"
+	_, err = gc.QueryNode(nil)
+	assert.ErrorContains(t, err, "not found")
+}
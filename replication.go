@@ -18,10 +18,10 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
@@ -52,29 +52,47 @@ func NewPeerMDM(client *Client, peerMDM *types.PeerMDM) *PeerMDM {
 
 // GetPeerMDMs returns a list of peer MDMs know to the System
 func (c *Client) GetPeerMDMs() ([]*types.PeerMDM, error) {
-	defer TimeSpent("GetPeerMDMs", time.Now())
+	return c.GetPeerMDMsCtx(c.callContext())
+}
+
+// GetPeerMDMsCtx returns a list of peer MDMs know to the System
+func (c *Client) GetPeerMDMsCtx(ctx context.Context) ([]*types.PeerMDM, error) {
+	ctx, span := c.startSpan(ctx, "GetPeerMDMs")
+	defer span.end()
 
 	path := "/api/types/PeerMdm/instances"
 	var peerMdms []*types.PeerMDM
 
-	err := c.getJSONWithRetry(http.MethodGet, path, nil, &peerMdms)
+	err := c.getJSONWithRetry(ctx, http.MethodGet, path, nil, &peerMdms)
 	return peerMdms, err
 }
 
 // GetPeerMDM returns a specific peer MDM
 func (c *Client) GetPeerMDM(id string) (*types.PeerMDM, error) {
-	defer TimeSpent("GetPeerMDM", time.Now())
+	return c.GetPeerMDMCtx(c.callContext(), id)
+}
+
+// GetPeerMDMCtx returns a specific peer MDM
+func (c *Client) GetPeerMDMCtx(ctx context.Context, id string) (*types.PeerMDM, error) {
+	ctx, span := c.startSpan(ctx, "GetPeerMDM")
+	defer span.end()
 
 	path := "/api/instances/PeerMdm::" + id
 	var peerMdm *types.PeerMDM
 
-	err := c.getJSONWithRetry(http.MethodGet, path, nil, &peerMdm)
+	err := c.getJSONWithRetry(ctx, http.MethodGet, path, nil, &peerMdm)
 	return peerMdm, err
 }
 
 // ModifyPeerMdmIP updates a Peer MDM Ips
 func (c *Client) ModifyPeerMdmIP(id string, ips []string) error {
-	defer TimeSpent("ModifyPeerMdmIP", time.Now())
+	return c.ModifyPeerMdmIPCtx(c.callContext(), id, ips)
+}
+
+// ModifyPeerMdmIPCtx updates a Peer MDM Ips
+func (c *Client) ModifyPeerMdmIPCtx(ctx context.Context, id string, ips []string) error {
+	ctx, span := c.startSpan(ctx, "ModifyPeerMdmIP")
+	defer span.end()
 	// Format into the strucutre that the API expects
 	var ipMap []map[string]interface{}
 	for _, ip := range ips {
@@ -85,7 +103,7 @@ func (c *Client) ModifyPeerMdmIP(id string, ips []string) error {
 	}
 	path := "/api/instances/PeerMdm::" + id + "/action/modifyPeerMdmIp"
 
-	if err := c.getJSONWithRetry(http.MethodPost, path, param, nil); err != nil {
+	if err := c.getJSONWithRetry(ctx, http.MethodPost, path, param, nil); err != nil {
 		fmt.Printf("c.getJSONWithRetry(http.MethodPost, path, param, nil) returned %s", err)
 		return err
 	}
@@ -95,11 +113,17 @@ func (c *Client) ModifyPeerMdmIP(id string, ips []string) error {
 
 // ModifyPeerMdmName updates a Peer MDM Name
 func (c *Client) ModifyPeerMdmName(id string, name *types.ModifyPeerMDMNameParam) error {
-	defer TimeSpent("ModifyPeerMdmName", time.Now())
+	return c.ModifyPeerMdmNameCtx(c.callContext(), id, name)
+}
+
+// ModifyPeerMdmNameCtx updates a Peer MDM Name
+func (c *Client) ModifyPeerMdmNameCtx(ctx context.Context, id string, name *types.ModifyPeerMDMNameParam) error {
+	ctx, span := c.startSpan(ctx, "ModifyPeerMdmName")
+	defer span.end()
 
 	path := "/api/instances/PeerMdm::" + id + "/action/modifyPeerMdmName"
 
-	if err := c.getJSONWithRetry(http.MethodPost, path, name, nil); err != nil {
+	if err := c.getJSONWithRetry(ctx, http.MethodPost, path, name, nil); err != nil {
 		fmt.Printf("c.getJSONWithRetry(http.MethodPost, path, name, nil) returned %s", err)
 		return err
 	}
@@ -109,11 +133,17 @@ func (c *Client) ModifyPeerMdmName(id string, name *types.ModifyPeerMDMNameParam
 
 // ModifyPeerMdmPort updates a Peer MDM Port
 func (c *Client) ModifyPeerMdmPort(id string, port *types.ModifyPeerMDMPortParam) error {
-	defer TimeSpent("ModifyPeerMdmPort", time.Now())
+	return c.ModifyPeerMdmPortCtx(c.callContext(), id, port)
+}
+
+// ModifyPeerMdmPortCtx updates a Peer MDM Port
+func (c *Client) ModifyPeerMdmPortCtx(ctx context.Context, id string, port *types.ModifyPeerMDMPortParam) error {
+	ctx, span := c.startSpan(ctx, "ModifyPeerMdmPort")
+	defer span.end()
 
 	path := "/api/instances/PeerMdm::" + id + "/action/modifyPeerMdmPort"
 
-	if err := c.getJSONWithRetry(http.MethodPost, path, port, nil); err != nil {
+	if err := c.getJSONWithRetry(ctx, http.MethodPost, path, port, nil); err != nil {
 		fmt.Printf("c.getJSONWithRetry(http.MethodPost, path, port, nil) returned %s", err)
 		return err
 	}
@@ -123,11 +153,17 @@ func (c *Client) ModifyPeerMdmPort(id string, port *types.ModifyPeerMDMPortParam
 
 // ModifyPeerMdmPerformanceParameters updates a Peer MDM Performance Parameters
 func (c *Client) ModifyPeerMdmPerformanceParameters(id string, param *types.ModifyPeerMdmPerformanceParametersParam) error {
-	defer TimeSpent("ModifyPeerMdmPerformanceParameters", time.Now())
+	return c.ModifyPeerMdmPerformanceParametersCtx(c.callContext(), id, param)
+}
+
+// ModifyPeerMdmPerformanceParametersCtx updates a Peer MDM Performance Parameters
+func (c *Client) ModifyPeerMdmPerformanceParametersCtx(ctx context.Context, id string, param *types.ModifyPeerMdmPerformanceParametersParam) error {
+	ctx, span := c.startSpan(ctx, "ModifyPeerMdmPerformanceParameters")
+	defer span.end()
 
 	path := "/api/instances/PeerMdm::" + id + "/action/setPeerMdmPerformanceParameters"
 
-	if err := c.getJSONWithRetry(http.MethodPost, path, param, nil); err != nil {
+	if err := c.getJSONWithRetry(ctx, http.MethodPost, path, param, nil); err != nil {
 		fmt.Printf("c.getJSONWithRetry(http.MethodPost, path, param, nil) returned %s", err)
 		return err
 	}
@@ -137,7 +173,13 @@ func (c *Client) ModifyPeerMdmPerformanceParameters(id string, param *types.Modi
 
 // AddPeerMdm Adds a Peer MDM
 func (c *Client) AddPeerMdm(param *types.AddPeerMdm) (*types.PeerMDM, error) {
-	defer TimeSpent("AddPeerMdm", time.Now())
+	return c.AddPeerMdmCtx(c.callContext(), param)
+}
+
+// AddPeerMdmCtx Adds a Peer MDM
+func (c *Client) AddPeerMdmCtx(ctx context.Context, param *types.AddPeerMdm) (*types.PeerMDM, error) {
+	ctx, span := c.startSpan(ctx, "AddPeerMdm")
+	defer span.end()
 	if param.PeerSystemID == "" || len(param.PeerSystemIps) == 0 {
 		return nil, errors.New("PeerSystemID and PeerSystemIps are required")
 	}
@@ -154,7 +196,7 @@ func (c *Client) AddPeerMdm(param *types.AddPeerMdm) (*types.PeerMDM, error) {
 		Name:          param.Name,
 	}
 
-	if err := c.getJSONWithRetry(http.MethodPost, path, paramCreate, peerMdm); err != nil {
+	if err := c.getJSONWithRetry(ctx, http.MethodPost, path, paramCreate, peerMdm); err != nil {
 		fmt.Printf("c.getJSONWithRetry(http.MethodPost, path, paramCreate, peerMdm) returned %s", err)
 		return nil, err
 	}
@@ -164,11 +206,17 @@ func (c *Client) AddPeerMdm(param *types.AddPeerMdm) (*types.PeerMDM, error) {
 
 // RemovePeerMdm removes a Peer MDM
 func (c *Client) RemovePeerMdm(id string) error {
-	defer TimeSpent("RemovePeerMdm", time.Now())
+	return c.RemovePeerMdmCtx(c.callContext(), id)
+}
+
+// RemovePeerMdmCtx removes a Peer MDM
+func (c *Client) RemovePeerMdmCtx(ctx context.Context, id string) error {
+	ctx, span := c.startSpan(ctx, "RemovePeerMdm")
+	defer span.end()
 
 	path := "/api/instances/PeerMdm::" + id + "/action/removePeerMdm"
 	params := types.EmptyPayload{}
-	if err := c.getJSONWithRetry(http.MethodPost, path, params, nil); err != nil {
+	if err := c.getJSONWithRetry(ctx, http.MethodPost, path, params, nil); err != nil {
 		fmt.Printf("c.getJSONWithRetry(http.MethodPost, path, params, nil) returned %s", err)
 		return err
 	}
@@ -208,29 +256,47 @@ func NewReplicationPair(client *Client) *ReplicationPair {
 
 // GetReplicationConsistencyGroups returns a list of the ReplicationConsistencyGroups
 func (c *Client) GetReplicationConsistencyGroups() ([]*types.ReplicationConsistencyGroup, error) {
-	defer TimeSpent("GetReplicationConsistencyGroups", time.Now())
+	return c.GetReplicationConsistencyGroupsCtx(c.callContext())
+}
+
+// GetReplicationConsistencyGroupsCtx returns a list of the ReplicationConsistencyGroups
+func (c *Client) GetReplicationConsistencyGroupsCtx(ctx context.Context) ([]*types.ReplicationConsistencyGroup, error) {
+	ctx, span := c.startSpan(ctx, "GetReplicationConsistencyGroups")
+	defer span.end()
 
 	uri := "/api/types/ReplicationConsistencyGroup/instances"
 	var rcgs []*types.ReplicationConsistencyGroup
 
-	err := c.getJSONWithRetry(http.MethodGet, uri, nil, &rcgs)
+	err := c.getJSONWithRetry(ctx, http.MethodGet, uri, nil, &rcgs)
 	return rcgs, err
 }
 
 // GetReplicationConsistencyGroupByID returns a specified ReplicationConsistencyGroup
 func (c *Client) GetReplicationConsistencyGroupByID(groupID string) (*types.ReplicationConsistencyGroup, error) {
-	defer TimeSpent("GetReplicationConsistencyGroupById", time.Now())
+	return c.GetReplicationConsistencyGroupByIDCtx(c.callContext(), groupID)
+}
+
+// GetReplicationConsistencyGroupByIDCtx returns a specified ReplicationConsistencyGroup
+func (c *Client) GetReplicationConsistencyGroupByIDCtx(ctx context.Context, groupID string) (*types.ReplicationConsistencyGroup, error) {
+	ctx, span := c.startSpan(ctx, "GetReplicationConsistencyGroupById")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + groupID
 	var group *types.ReplicationConsistencyGroup
 
-	err := c.getJSONWithRetry(http.MethodGet, uri, nil, &group)
+	err := c.getJSONWithRetry(ctx, http.MethodGet, uri, nil, &group)
 	return group, err
 }
 
 // CreateReplicationConsistencyGroup creates a ReplicationConsistencyGroup on the array
 func (c *Client) CreateReplicationConsistencyGroup(rcg *types.ReplicationConsistencyGroupCreatePayload) (*types.ReplicationConsistencyGroupResp, error) {
-	defer TimeSpent("CreateReplicationConsistencyGroup", time.Now())
+	return c.CreateReplicationConsistencyGroupCtx(c.callContext(), rcg)
+}
+
+// CreateReplicationConsistencyGroupCtx creates a ReplicationConsistencyGroup on the array
+func (c *Client) CreateReplicationConsistencyGroupCtx(ctx context.Context, rcg *types.ReplicationConsistencyGroupCreatePayload) (*types.ReplicationConsistencyGroupResp, error) {
+	ctx, span := c.startSpan(ctx, "CreateReplicationConsistencyGroup")
+	defer span.end()
 
 	if rcg.RpoInSeconds == "" || rcg.ProtectionDomainID == "" || rcg.RemoteProtectionDomainID == "" {
 		return nil, errors.New("RpoInSeconds, ProtectionDomainId, and RemoteProtectionDomainId are required")
@@ -243,7 +309,7 @@ func (c *Client) CreateReplicationConsistencyGroup(rcg *types.ReplicationConsist
 	path := "/api/types/ReplicationConsistencyGroup/instances"
 	rcgResp := &types.ReplicationConsistencyGroupResp{}
 
-	err := c.getJSONWithRetry(http.MethodPost, path, rcg, rcgResp)
+	err := c.getJSONWithRetry(ctx, http.MethodPost, path, rcg, rcgResp)
 	if err != nil {
 		fmt.Printf("c.getJSONWithRetry(http.MethodPost, path, rcg, rcgResp) returned %s", err)
 		return nil, err
@@ -254,7 +320,14 @@ func (c *Client) CreateReplicationConsistencyGroup(rcg *types.ReplicationConsist
 // RemoveReplicationConsistencyGroup removes a replication consistency group
 // At this point I don't know when forceIgnoreConsistency might be required.
 func (rcg *ReplicationConsistencyGroup) RemoveReplicationConsistencyGroup(forceIgnoreConsistency bool) error {
-	defer TimeSpent("RemoveReplicationConsistencyGroup", time.Now())
+	return rcg.RemoveReplicationConsistencyGroupCtx(rcg.client.callContext(), forceIgnoreConsistency)
+}
+
+// RemoveReplicationConsistencyGroupCtx removes a replication consistency group
+// At this point I don't know when forceIgnoreConsistency might be required.
+func (rcg *ReplicationConsistencyGroup) RemoveReplicationConsistencyGroupCtx(ctx context.Context, forceIgnoreConsistency bool) error {
+	ctx, span := rcg.client.startSpan(ctx, "RemoveReplicationConsistencyGroup")
+	defer span.end()
 
 	link, err := GetLink(rcg.ReplicationConsistencyGroup.Links, "self")
 	if err != nil {
@@ -267,35 +340,53 @@ func (rcg *ReplicationConsistencyGroup) RemoveReplicationConsistencyGroup(forceI
 		removeRCGParam.ForceIgnoreConsistency = "True"
 	}
 
-	err = rcg.client.getJSONWithRetry(http.MethodPost, path, removeRCGParam, nil)
+	err = rcg.client.getJSONWithRetry(ctx, http.MethodPost, path, removeRCGParam, nil)
 	return err
 }
 
 // FreezeReplicationConsistencyGroup sets the ReplicationConsistencyGroup into a freeze state
 func (rcg *ReplicationConsistencyGroup) FreezeReplicationConsistencyGroup(id string) error {
-	defer TimeSpent("FreezeReplicationConsistencyGroup", time.Now())
+	return rcg.FreezeReplicationConsistencyGroupCtx(rcg.client.callContext(), id)
+}
+
+// FreezeReplicationConsistencyGroupCtx sets the ReplicationConsistencyGroup into a freeze state
+func (rcg *ReplicationConsistencyGroup) FreezeReplicationConsistencyGroupCtx(ctx context.Context, id string) error {
+	ctx, span := rcg.client.startSpan(ctx, "FreezeReplicationConsistencyGroup")
+	defer span.end()
 
 	params := types.EmptyPayload{}
 	path := "/api/instances/ReplicationConsistencyGroup::" + id + "/action/freezeApplyReplicationConsistencyGroup"
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, path, params, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, path, params, nil)
 	return err
 }
 
 // UnfreezeReplicationConsistencyGroup sets the ReplicationConsistencyGroup into a Unfreeze state
 func (rcg *ReplicationConsistencyGroup) UnfreezeReplicationConsistencyGroup() error {
-	defer TimeSpent("UnfreezeReplicationConsistencyGroup", time.Now())
+	return rcg.UnfreezeReplicationConsistencyGroupCtx(rcg.client.callContext())
+}
+
+// UnfreezeReplicationConsistencyGroupCtx sets the ReplicationConsistencyGroup into a Unfreeze state
+func (rcg *ReplicationConsistencyGroup) UnfreezeReplicationConsistencyGroupCtx(ctx context.Context) error {
+	ctx, span := rcg.client.startSpan(ctx, "UnfreezeReplicationConsistencyGroup")
+	defer span.end()
 
 	params := types.EmptyPayload{}
 	path := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/unfreezeApplyReplicationConsistencyGroup"
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, path, params, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, path, params, nil)
 	return err
 }
 
 // CreateReplicationPair creates a ReplicationPair on the desired ReplicaitonConsistencyGroup
 func (c *Client) CreateReplicationPair(rp *types.QueryReplicationPair) (*types.ReplicationPair, error) {
-	defer TimeSpent("CreateReplicationPair", time.Now())
+	return c.CreateReplicationPairCtx(c.callContext(), rp)
+}
+
+// CreateReplicationPairCtx creates a ReplicationPair on the desired ReplicaitonConsistencyGroup
+func (c *Client) CreateReplicationPairCtx(ctx context.Context, rp *types.QueryReplicationPair) (*types.ReplicationPair, error) {
+	ctx, span := c.startSpan(ctx, "CreateReplicationPair")
+	defer span.end()
 
 	if rp.CopyType == "" || rp.SourceVolumeID == "" || rp.DestinationVolumeID == "" || rp.ReplicationConsistencyGroupID == "" {
 		return nil, errors.New("CopyType, SourceVolumeID, DestinationVolumeID, and ReplicationConsistencyGroupID are required")
@@ -304,7 +395,7 @@ func (c *Client) CreateReplicationPair(rp *types.QueryReplicationPair) (*types.R
 	path := "/api/types/ReplicationPair/instances"
 	rpResp := &types.ReplicationPair{}
 
-	if err := c.getJSONWithRetry(http.MethodPost, path, rp, rpResp); err != nil {
+	if err := c.getJSONWithRetry(ctx, http.MethodPost, path, rp, rpResp); err != nil {
 		fmt.Printf("c.getJSONWithRetry(http.MethodPost, path, rp, rpResp) returned %s", err)
 		return nil, err
 	}
@@ -314,7 +405,13 @@ func (c *Client) CreateReplicationPair(rp *types.QueryReplicationPair) (*types.R
 
 // RemoveReplicationPair removes the desired replication pair.
 func (rp *ReplicationPair) RemoveReplicationPair(force bool) (*types.ReplicationPair, error) {
-	defer TimeSpent("RemoveReplicationPair", time.Now())
+	return rp.RemoveReplicationPairCtx(rp.client.callContext(), force)
+}
+
+// RemoveReplicationPairCtx removes the desired replication pair.
+func (rp *ReplicationPair) RemoveReplicationPairCtx(ctx context.Context, force bool) (*types.ReplicationPair, error) {
+	ctx, span := rp.client.startSpan(ctx, "RemoveReplicationPair")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationPair::" + rp.ReplicaitonPair.ID + "/action/removeReplicationPair"
 	resp := &types.ReplicationPair{}
@@ -325,7 +422,7 @@ func (rp *ReplicationPair) RemoveReplicationPair(force bool) (*types.Replication
 		param.Force = "true"
 	}
 
-	if err := rp.client.getJSONWithRetry(http.MethodPost, uri, param, resp); err != nil {
+	if err := rp.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, resp); err != nil {
 		fmt.Printf("c.getJSONWithRetry(http.MethodPost, path, rp, pair) returned %s", err)
 		return nil, err
 	}
@@ -335,246 +432,378 @@ func (rp *ReplicationPair) RemoveReplicationPair(force bool) (*types.Replication
 
 // GetReplicationPairStatistics returns the statistics of the desired ReplicaitonPair.
 func (rp *ReplicationPair) GetReplicationPairStatistics() (*types.QueryReplicationPairStatistics, error) {
-	defer TimeSpent("GetReplicationPairStatistics", time.Now())
+	return rp.GetReplicationPairStatisticsCtx(rp.client.callContext())
+}
+
+// GetReplicationPairStatisticsCtx returns the statistics of the desired ReplicaitonPair.
+func (rp *ReplicationPair) GetReplicationPairStatisticsCtx(ctx context.Context) (*types.QueryReplicationPairStatistics, error) {
+	ctx, span := rp.client.startSpan(ctx, "GetReplicationPairStatistics")
+	defer span.end()
 
 	path := "/api/instances/ReplicationPair::" + rp.ReplicaitonPair.ID + "/relationships/Statistics"
 	rpResp := &types.QueryReplicationPairStatistics{}
 
-	err := rp.client.getJSONWithRetry(http.MethodGet, path, nil, &rpResp)
+	err := rp.client.getJSONWithRetry(ctx, http.MethodGet, path, nil, &rpResp)
 	return rpResp, err
 }
 
 // GetAllReplicationPairs returns a list all replication pairs on the system.
 func (c *Client) GetAllReplicationPairs() ([]*types.ReplicationPair, error) {
-	defer TimeSpent("GetReplicationPairs", time.Now())
+	return c.GetAllReplicationPairsCtx(c.callContext())
+}
+
+// GetAllReplicationPairsCtx returns a list all replication pairs on the system.
+func (c *Client) GetAllReplicationPairsCtx(ctx context.Context) ([]*types.ReplicationPair, error) {
+	ctx, span := c.startSpan(ctx, "GetReplicationPairs")
+	defer span.end()
 
 	path := "/api/types/ReplicationPair/instances"
 
 	var pairs []*types.ReplicationPair
-	err := c.getJSONWithRetry(http.MethodGet, path, nil, &pairs)
+	err := c.getJSONWithRetry(ctx, http.MethodGet, path, nil, &pairs)
 	return pairs, err
 }
 
 // GetReplicationPair returns a specific replication pair on the system.
 func (c *Client) GetReplicationPair(id string) (*types.ReplicationPair, error) {
-	defer TimeSpent("GetReplicationPair", time.Now())
+	return c.GetReplicationPairCtx(c.callContext(), id)
+}
+
+// GetReplicationPairCtx returns a specific replication pair on the system.
+func (c *Client) GetReplicationPairCtx(ctx context.Context, id string) (*types.ReplicationPair, error) {
+	ctx, span := c.startSpan(ctx, "GetReplicationPair")
+	defer span.end()
 
 	path := "/api/instances/ReplicationPair::" + id
 
 	var pair *types.ReplicationPair
-	err := c.getJSONWithRetry(http.MethodGet, path, nil, &pair)
+	err := c.getJSONWithRetry(ctx, http.MethodGet, path, nil, &pair)
 	return pair, err
 }
 
 // PausePairInitialCopy pauses the initial copy of the replication pair.
 func (c *Client) PausePairInitialCopy(id string) (*types.ReplicationPair, error) {
-	defer TimeSpent("PausePairInitialCopy", time.Now())
+	return c.PausePairInitialCopyCtx(c.callContext(), id)
+}
+
+// PausePairInitialCopyCtx pauses the initial copy of the replication pair.
+func (c *Client) PausePairInitialCopyCtx(ctx context.Context, id string) (*types.ReplicationPair, error) {
+	ctx, span := c.startSpan(ctx, "PausePairInitialCopy")
+	defer span.end()
 
 	path := "/api/instances/ReplicationPair::" + id + "/action/pausePairInitialCopy"
 
 	var pair *types.ReplicationPair
-	err := c.getJSONWithRetry(http.MethodPost, path, types.EmptyPayload{}, &pair)
+	err := c.getJSONWithRetry(ctx, http.MethodPost, path, types.EmptyPayload{}, &pair)
 	return pair, err
 }
 
 // ResumePairInitialCopy resumes the initial copy of the replication pair.
 func (c *Client) ResumePairInitialCopy(id string) (*types.ReplicationPair, error) {
-	defer TimeSpent("ResumePairInitialCopy", time.Now())
+	return c.ResumePairInitialCopyCtx(c.callContext(), id)
+}
+
+// ResumePairInitialCopyCtx resumes the initial copy of the replication pair.
+func (c *Client) ResumePairInitialCopyCtx(ctx context.Context, id string) (*types.ReplicationPair, error) {
+	ctx, span := c.startSpan(ctx, "ResumePairInitialCopy")
+	defer span.end()
 
 	path := "/api/instances/ReplicationPair::" + id + "/action/resumePairInitialCopy"
 
 	var pair *types.ReplicationPair
-	err := c.getJSONWithRetry(http.MethodPost, path, types.EmptyPayload{}, &pair)
+	err := c.getJSONWithRetry(ctx, http.MethodPost, path, types.EmptyPayload{}, &pair)
 	return pair, err
 }
 
 // GetReplicationPairs returns a list of replication pairs associated to the rcg.
 func (rcg *ReplicationConsistencyGroup) GetReplicationPairs() ([]*types.ReplicationPair, error) {
-	defer TimeSpent("GetReplicationPairs", time.Now())
+	return rcg.GetReplicationPairsCtx(rcg.client.callContext())
+}
+
+// GetReplicationPairsCtx returns a list of replication pairs associated to the rcg.
+func (rcg *ReplicationConsistencyGroup) GetReplicationPairsCtx(ctx context.Context) ([]*types.ReplicationPair, error) {
+	ctx, span := rcg.client.startSpan(ctx, "GetReplicationPairs")
+	defer span.end()
 
 	path := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/relationships/ReplicationPair"
 
 	var pairs []*types.ReplicationPair
-	err := rcg.client.getJSONWithRetry(http.MethodGet, path, nil, &pairs)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodGet, path, nil, &pairs)
 	return pairs, err
 }
 
 // CreateReplicationConsistencyGroupSnapshot creates a snapshot of the ReplicationConsistencyGroup on the target array.
 func (rcg *ReplicationConsistencyGroup) CreateReplicationConsistencyGroupSnapshot() (*types.CreateReplicationConsistencyGroupSnapshotResp, error) {
-	defer TimeSpent("GetReplicationPairs", time.Now())
+	return rcg.CreateReplicationConsistencyGroupSnapshotCtx(rcg.client.callContext())
+}
+
+// CreateReplicationConsistencyGroupSnapshotCtx creates a snapshot of the ReplicationConsistencyGroup on the target array.
+func (rcg *ReplicationConsistencyGroup) CreateReplicationConsistencyGroupSnapshotCtx(ctx context.Context) (*types.CreateReplicationConsistencyGroupSnapshotResp, error) {
+	ctx, span := rcg.client.startSpan(ctx, "GetReplicationPairs")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/createReplicationConsistencyGroupSnapshots"
 
 	resp := &types.CreateReplicationConsistencyGroupSnapshotResp{}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, types.EmptyPayload{}, resp)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, types.EmptyPayload{}, resp)
 	return resp, err
 }
 
 // ExecuteFailoverOnReplicationGroup sets the ReplicationconsistencyGroup into a failover state.
 func (rcg *ReplicationConsistencyGroup) ExecuteFailoverOnReplicationGroup() error {
-	defer TimeSpent("ExecuteFailoverOnReplicationGroup", time.Now())
+	return rcg.ExecuteFailoverOnReplicationGroupCtx(rcg.client.callContext())
+}
+
+// ExecuteFailoverOnReplicationGroupCtx sets the ReplicationconsistencyGroup into a failover state.
+func (rcg *ReplicationConsistencyGroup) ExecuteFailoverOnReplicationGroupCtx(ctx context.Context) error {
+	ctx, span := rcg.client.startSpan(ctx, "ExecuteFailoverOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/failoverReplicationConsistencyGroup"
 	param := types.EmptyPayload{}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // ExecuteSwitchoverOnReplicationGroup sets the ReplicationconsistencyGroup into a switchover state.
 func (rcg *ReplicationConsistencyGroup) ExecuteSwitchoverOnReplicationGroup(_ bool) error {
-	defer TimeSpent("ExecuteSwitchoverOnReplicationGroup", time.Now())
+	return rcg.ExecuteSwitchoverOnReplicationGroupCtx(rcg.client.callContext(), false)
+}
+
+// ExecuteSwitchoverOnReplicationGroupCtx sets the ReplicationconsistencyGroup into a switchover state.
+func (rcg *ReplicationConsistencyGroup) ExecuteSwitchoverOnReplicationGroupCtx(ctx context.Context, _ bool) error {
+	ctx, span := rcg.client.startSpan(ctx, "ExecuteSwitchoverOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/switchoverReplicationConsistencyGroup"
 	// API is incorrect. No params needed.
 	param := types.EmptyPayload{}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // ExecuteRestoreOnReplicationGroup restores the ReplicationConsistencyGroup from a failover/switchover state.
 func (rcg *ReplicationConsistencyGroup) ExecuteRestoreOnReplicationGroup() error {
-	defer TimeSpent("ExecuteRestoreOnReplicationGroup", time.Now())
+	return rcg.ExecuteRestoreOnReplicationGroupCtx(rcg.client.callContext())
+}
+
+// ExecuteRestoreOnReplicationGroupCtx restores the ReplicationConsistencyGroup from a failover/switchover state.
+func (rcg *ReplicationConsistencyGroup) ExecuteRestoreOnReplicationGroupCtx(ctx context.Context) error {
+	ctx, span := rcg.client.startSpan(ctx, "ExecuteRestoreOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/restoreReplicationConsistencyGroup"
 	param := types.EmptyPayload{}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // ExecuteReverseOnReplicationGroup reverses the direction of replication from a failover/switchover state.
 func (rcg *ReplicationConsistencyGroup) ExecuteReverseOnReplicationGroup() error {
-	defer TimeSpent("ExecuteReverseOnReplicationGroup", time.Now())
+	return rcg.ExecuteReverseOnReplicationGroupCtx(rcg.client.callContext())
+}
+
+// ExecuteReverseOnReplicationGroupCtx reverses the direction of replication from a failover/switchover state.
+func (rcg *ReplicationConsistencyGroup) ExecuteReverseOnReplicationGroupCtx(ctx context.Context) error {
+	ctx, span := rcg.client.startSpan(ctx, "ExecuteReverseOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/reverseReplicationConsistencyGroup"
 	param := types.EmptyPayload{}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // ExecutePauseOnReplicationGroup pauses the replication of the ConsistencyGroup.
 func (rcg *ReplicationConsistencyGroup) ExecutePauseOnReplicationGroup() error {
-	defer TimeSpent("ExecutePauseOnReplicationGroup", time.Now())
+	return rcg.ExecutePauseOnReplicationGroupCtx(rcg.client.callContext())
+}
+
+// ExecutePauseOnReplicationGroupCtx pauses the replication of the ConsistencyGroup.
+func (rcg *ReplicationConsistencyGroup) ExecutePauseOnReplicationGroupCtx(ctx context.Context) error {
+	ctx, span := rcg.client.startSpan(ctx, "ExecutePauseOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/pauseReplicationConsistencyGroup"
 	param := types.PauseReplicationConsistencyGroup{
 		PauseMode: string(types.StopDataTransfer),
 	}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // ExecuteResumeOnReplicationGroup resumes the ConsistencyGroup when it is in a Paused state.
 func (rcg *ReplicationConsistencyGroup) ExecuteResumeOnReplicationGroup() error {
-	defer TimeSpent("ExecuteResumeOnReplicationGroup", time.Now())
+	return rcg.ExecuteResumeOnReplicationGroupCtx(rcg.client.callContext())
+}
+
+// ExecuteResumeOnReplicationGroupCtx resumes the ConsistencyGroup when it is in a Paused state.
+func (rcg *ReplicationConsistencyGroup) ExecuteResumeOnReplicationGroupCtx(ctx context.Context) error {
+	ctx, span := rcg.client.startSpan(ctx, "ExecuteResumeOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/resumeReplicationConsistencyGroup"
 	param := types.EmptyPayload{}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // ExecuteSyncOnReplicationGroup forces a synce on the ConsistencyGroup.
 func (rcg *ReplicationConsistencyGroup) ExecuteSyncOnReplicationGroup() (*types.SynchronizationResponse, error) {
-	defer TimeSpent("ExecuteSyncOnReplicationGroup", time.Now())
+	return rcg.ExecuteSyncOnReplicationGroupCtx(rcg.client.callContext())
+}
+
+// ExecuteSyncOnReplicationGroupCtx forces a synce on the ConsistencyGroup.
+func (rcg *ReplicationConsistencyGroup) ExecuteSyncOnReplicationGroupCtx(ctx context.Context) (*types.SynchronizationResponse, error) {
+	ctx, span := rcg.client.startSpan(ctx, "ExecuteSyncOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/syncNowReplicationConsistencyGroup"
 	param := types.EmptyPayload{}
 	resp := &types.SynchronizationResponse{}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, resp)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, resp)
 	return resp, err
 }
 
 // SetRPOOnReplicationGroup on the ConsistencyGroup.
 func (rcg *ReplicationConsistencyGroup) SetRPOOnReplicationGroup(param types.SetRPOReplicationConsistencyGroup) error {
-	defer TimeSpent("SetRPOOnReplicationGroup", time.Now())
+	return rcg.SetRPOOnReplicationGroupCtx(rcg.client.callContext(), param)
+}
+
+// SetRPOOnReplicationGroupCtx on the ConsistencyGroup.
+func (rcg *ReplicationConsistencyGroup) SetRPOOnReplicationGroupCtx(ctx context.Context, param types.SetRPOReplicationConsistencyGroup) error {
+	ctx, span := rcg.client.startSpan(ctx, "SetRPOOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/ModifyReplicationConsistencyGroupRpo"
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // SetTargetVolumeAccessModeOnReplicationGroup on the ConsistencyGroup.
 func (rcg *ReplicationConsistencyGroup) SetTargetVolumeAccessModeOnReplicationGroup(param types.SetTargetVolumeAccessModeOnReplicationGroup) error {
-	defer TimeSpent("SetTargetVolumeAccessModeOnReplicationGroup", time.Now())
+	return rcg.SetTargetVolumeAccessModeOnReplicationGroupCtx(rcg.client.callContext(), param)
+}
+
+// SetTargetVolumeAccessModeOnReplicationGroupCtx on the ConsistencyGroup.
+func (rcg *ReplicationConsistencyGroup) SetTargetVolumeAccessModeOnReplicationGroupCtx(ctx context.Context, param types.SetTargetVolumeAccessModeOnReplicationGroup) error {
+	ctx, span := rcg.client.startSpan(ctx, "SetTargetVolumeAccessModeOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/modifyReplicationConsistencyGroupTargetVolumeAccessMode"
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // SetNewNameOnReplicationGroup on the ConsistencyGroup.
 func (rcg *ReplicationConsistencyGroup) SetNewNameOnReplicationGroup(param types.SetNewNameOnReplicationGroup) error {
-	defer TimeSpent("SetNewNameOnReplicationGroup", time.Now())
+	return rcg.SetNewNameOnReplicationGroupCtx(rcg.client.callContext(), param)
+}
+
+// SetNewNameOnReplicationGroupCtx on the ConsistencyGroup.
+func (rcg *ReplicationConsistencyGroup) SetNewNameOnReplicationGroupCtx(ctx context.Context, param types.SetNewNameOnReplicationGroup) error {
+	ctx, span := rcg.client.startSpan(ctx, "SetNewNameOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/renameReplicationConsistencyGroup"
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // ExecuteConsistentOnReplicationGroup on the ConsistencyGroup.
 func (rcg *ReplicationConsistencyGroup) ExecuteConsistentOnReplicationGroup() error {
-	defer TimeSpent("ExecuteConsistentOnReplicationGroup", time.Now())
+	return rcg.ExecuteConsistentOnReplicationGroupCtx(rcg.client.callContext())
+}
+
+// ExecuteConsistentOnReplicationGroupCtx on the ConsistencyGroup.
+func (rcg *ReplicationConsistencyGroup) ExecuteConsistentOnReplicationGroupCtx(ctx context.Context) error {
+	ctx, span := rcg.client.startSpan(ctx, "ExecuteConsistentOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/setReplicationConsistencyGroupConsistent"
 	param := types.EmptyPayload{}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // ExecuteInconsistentOnReplicationGroup on the ConsistencyGroup.
 func (rcg *ReplicationConsistencyGroup) ExecuteInconsistentOnReplicationGroup() error {
-	defer TimeSpent("ExecuteInconsistentOnReplicationGroup", time.Now())
+	return rcg.ExecuteInconsistentOnReplicationGroupCtx(rcg.client.callContext())
+}
+
+// ExecuteInconsistentOnReplicationGroupCtx on the ConsistencyGroup.
+func (rcg *ReplicationConsistencyGroup) ExecuteInconsistentOnReplicationGroupCtx(ctx context.Context) error {
+	ctx, span := rcg.client.startSpan(ctx, "ExecuteInconsistentOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/setReplicationConsistencyGroupInconsistent"
 	param := types.EmptyPayload{}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // ExecuteActivateOnReplicationGroup on the ConsistencyGroup.
 func (rcg *ReplicationConsistencyGroup) ExecuteActivateOnReplicationGroup() error {
-	defer TimeSpent("ExecuteActivateOnReplicationGroup", time.Now())
+	return rcg.ExecuteActivateOnReplicationGroupCtx(rcg.client.callContext())
+}
+
+// ExecuteActivateOnReplicationGroupCtx on the ConsistencyGroup.
+func (rcg *ReplicationConsistencyGroup) ExecuteActivateOnReplicationGroupCtx(ctx context.Context) error {
+	ctx, span := rcg.client.startSpan(ctx, "ExecuteActivateOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/activateReplicationConsistencyGroup"
 	param := types.EmptyPayload{}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // ExecuteTerminateOnReplicationGroup on the ConsistencyGroup.
 func (rcg *ReplicationConsistencyGroup) ExecuteTerminateOnReplicationGroup() error {
-	defer TimeSpent("ExecuteTerminateOnReplicationGroup", time.Now())
+	return rcg.ExecuteTerminateOnReplicationGroupCtx(rcg.client.callContext())
+}
+
+// ExecuteTerminateOnReplicationGroupCtx on the ConsistencyGroup.
+func (rcg *ReplicationConsistencyGroup) ExecuteTerminateOnReplicationGroupCtx(ctx context.Context) error {
+	ctx, span := rcg.client.startSpan(ctx, "ExecuteTerminateOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/terminateReplicationConsistencyGroup"
 	param := types.EmptyPayload{}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
 
 // GetSyncStateOnReplicationGroup returns the sync status of the ReplicaitonConsistencyGroup.
 func (rcg *ReplicationConsistencyGroup) GetSyncStateOnReplicationGroup(syncKey string) error {
-	defer TimeSpent("ExecuteSyncOnReplicationGroup", time.Now())
+	return rcg.GetSyncStateOnReplicationGroupCtx(rcg.client.callContext(), syncKey)
+}
+
+// GetSyncStateOnReplicationGroupCtx returns the sync status of the ReplicaitonConsistencyGroup.
+func (rcg *ReplicationConsistencyGroup) GetSyncStateOnReplicationGroupCtx(ctx context.Context, syncKey string) error {
+	ctx, span := rcg.client.startSpan(ctx, "ExecuteSyncOnReplicationGroup")
+	defer span.end()
 
 	uri := "/api/instances/ReplicationConsistencyGroup::" + rcg.ReplicationConsistencyGroup.ID + "/action/querySyncNowReplicationConsistencyGroup"
 	param := types.QuerySyncNowRequest{
 		SyncNowKey: syncKey,
 	}
 
-	err := rcg.client.getJSONWithRetry(http.MethodPost, uri, param, nil)
+	err := rcg.client.getJSONWithRetry(ctx, http.MethodPost, uri, param, nil)
 	return err
 }
@@ -18,22 +18,28 @@
 package goscaleio
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // GetResourceCredentials returns all the resource credentials
 func (s *System) GetResourceCredentials() (*types.ResourceCredentials, error) {
-	defer TimeSpent("GetResourceCredentials", time.Now())
+	return s.GetResourceCredentialsCtx(s.client.callContext())
+}
+
+// GetResourceCredentialsCtx returns all the resource credentials
+func (s *System) GetResourceCredentialsCtx(ctx context.Context) (*types.ResourceCredentials, error) {
+	ctx, span := s.client.startSpan(ctx, "GetResourceCredentials")
+	defer span.end()
 
 	path := fmt.Sprintf(
 		"/api/v1/Credential")
 
 	var credentialsResult types.ResourceCredentials
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &credentialsResult)
 	if err != nil {
 		return nil, err
@@ -44,13 +50,19 @@ func (s *System) GetResourceCredentials() (*types.ResourceCredentials, error) {
 
 // GetResourceCredential returns a specific credential using resource credential ID
 func (s *System) GetResourceCredential(id string) (*types.ResourceCredential, error) {
-	defer TimeSpent("GetResourceCredential", time.Now())
+	return s.GetResourceCredentialCtx(s.client.callContext(), id)
+}
+
+// GetResourceCredentialCtx returns a specific credential using resource credential ID
+func (s *System) GetResourceCredentialCtx(ctx context.Context, id string) (*types.ResourceCredential, error) {
+	ctx, span := s.client.startSpan(ctx, "GetResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf(
 		"/api/v1/Credential/%v", id)
 
 	var credentialResult types.ResourceCredential
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &credentialResult)
 	if err != nil {
 		return nil, err
@@ -94,6 +106,11 @@ func validateNodeCred(body types.ServerCredential) (*types.ServerCredential, err
 
 // CreateNodeResourceCredential creates a new Resource Credential
 func (s *System) CreateNodeResourceCredential(body types.ServerCredential) (*types.ResourceCredential, error) {
+	return s.CreateNodeResourceCredentialCtx(s.client.callContext(), body)
+}
+
+// CreateNodeResourceCredentialCtx creates a new Resource Credential
+func (s *System) CreateNodeResourceCredentialCtx(ctx context.Context, body types.ServerCredential) (*types.ResourceCredential, error) {
 	valBody, errVal := validateNodeCred(body)
 	if errVal != nil {
 		return nil, errVal
@@ -101,12 +118,13 @@ func (s *System) CreateNodeResourceCredential(body types.ServerCredential) (*typ
 	fullBody := types.NodeCredentialWrapper{
 		ServerCredential: *valBody,
 	}
-	defer TimeSpent("CreateNodeResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "CreateNodeResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential")
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPost, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPost, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -116,6 +134,11 @@ func (s *System) CreateNodeResourceCredential(body types.ServerCredential) (*typ
 
 // ModifyNodeResourceCredential creates a new Resource Credential
 func (s *System) ModifyNodeResourceCredential(body types.ServerCredential, id string) (*types.ResourceCredential, error) {
+	return s.ModifyNodeResourceCredentialCtx(s.client.callContext(), body, id)
+}
+
+// ModifyNodeResourceCredentialCtx creates a new Resource Credential
+func (s *System) ModifyNodeResourceCredentialCtx(ctx context.Context, body types.ServerCredential, id string) (*types.ResourceCredential, error) {
 	valBody, errVal := validateNodeCred(body)
 	if errVal != nil {
 		return nil, errVal
@@ -124,12 +147,13 @@ func (s *System) ModifyNodeResourceCredential(body types.ServerCredential, id st
 	fullBody := types.NodeCredentialWrapper{
 		ServerCredential: *valBody,
 	}
-	defer TimeSpent("ModifyNodeResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "ModifyNodeResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential/%v", id)
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPut, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPut, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +163,11 @@ func (s *System) ModifyNodeResourceCredential(body types.ServerCredential, id st
 
 // CreateSwitchResourceCredential creates a new Resource Credential
 func (s *System) CreateSwitchResourceCredential(body types.IomCredential) (*types.ResourceCredential, error) {
+	return s.CreateSwitchResourceCredentialCtx(s.client.callContext(), body)
+}
+
+// CreateSwitchResourceCredentialCtx creates a new Resource Credential
+func (s *System) CreateSwitchResourceCredentialCtx(ctx context.Context, body types.IomCredential) (*types.ResourceCredential, error) {
 	// Validations
 	if (body.SSHPrivateKey != "" && body.KeyPairName == "") || (body.SSHPrivateKey == "" && body.KeyPairName != "") {
 		return nil, fmt.Errorf("If using an SSHPrivateKey then both KeyPairName and SSHPrivateKey must be set")
@@ -152,12 +181,13 @@ func (s *System) CreateSwitchResourceCredential(body types.IomCredential) (*type
 	fullBody := types.SwitchCredentialWrapper{
 		IomCredential: body,
 	}
-	defer TimeSpent("CreateSwitchResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "CreateSwitchResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential")
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPost, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPost, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -167,6 +197,11 @@ func (s *System) CreateSwitchResourceCredential(body types.IomCredential) (*type
 
 // ModifySwitchResourceCredential creates a new Resource Credential
 func (s *System) ModifySwitchResourceCredential(body types.IomCredential, id string) (*types.ResourceCredential, error) {
+	return s.ModifySwitchResourceCredentialCtx(s.client.callContext(), body, id)
+}
+
+// ModifySwitchResourceCredentialCtx creates a new Resource Credential
+func (s *System) ModifySwitchResourceCredentialCtx(ctx context.Context, body types.IomCredential, id string) (*types.ResourceCredential, error) {
 	// Validations
 	if (body.SSHPrivateKey != "" && body.KeyPairName == "") || (body.SSHPrivateKey == "" && body.KeyPairName != "") {
 		return nil, fmt.Errorf("If using an SSHPrivateKey then both KeyPairName and SSHPrivateKey must be set")
@@ -180,12 +215,13 @@ func (s *System) ModifySwitchResourceCredential(body types.IomCredential, id str
 	fullBody := types.SwitchCredentialWrapper{
 		IomCredential: body,
 	}
-	defer TimeSpent("ModifySwitchResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "ModifySwitchResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential/%v", id)
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPut, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPut, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -195,15 +231,21 @@ func (s *System) ModifySwitchResourceCredential(body types.IomCredential, id str
 
 // CreateVCenterResourceCredential creates a new Resource Credential
 func (s *System) CreateVCenterResourceCredential(body types.VCenterCredential) (*types.ResourceCredential, error) {
+	return s.CreateVCenterResourceCredentialCtx(s.client.callContext(), body)
+}
+
+// CreateVCenterResourceCredentialCtx creates a new Resource Credential
+func (s *System) CreateVCenterResourceCredentialCtx(ctx context.Context, body types.VCenterCredential) (*types.ResourceCredential, error) {
 	fullBody := types.VCenterCredentialWrapper{
 		VCenterCredential: body,
 	}
-	defer TimeSpent("CreateVCenterResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "CreateVCenterResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential")
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPost, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPost, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -213,15 +255,21 @@ func (s *System) CreateVCenterResourceCredential(body types.VCenterCredential) (
 
 // ModifyVCenterResourceCredential creates a new Resource Credential
 func (s *System) ModifyVCenterResourceCredential(body types.VCenterCredential, id string) (*types.ResourceCredential, error) {
+	return s.ModifyVCenterResourceCredentialCtx(s.client.callContext(), body, id)
+}
+
+// ModifyVCenterResourceCredentialCtx creates a new Resource Credential
+func (s *System) ModifyVCenterResourceCredentialCtx(ctx context.Context, body types.VCenterCredential, id string) (*types.ResourceCredential, error) {
 	fullBody := types.VCenterCredentialWrapper{
 		VCenterCredential: body,
 	}
-	defer TimeSpent("ModifyVCenterResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "ModifyVCenterResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential/%v", id)
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPut, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPut, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -231,6 +279,11 @@ func (s *System) ModifyVCenterResourceCredential(body types.VCenterCredential, i
 
 // CreateElementManagerResourceCredential creates a new Resource Credential
 func (s *System) CreateElementManagerResourceCredential(body types.EMCredential) (*types.ResourceCredential, error) {
+	return s.CreateElementManagerResourceCredentialCtx(s.client.callContext(), body)
+}
+
+// CreateElementManagerResourceCredentialCtx creates a new Resource Credential
+func (s *System) CreateElementManagerResourceCredentialCtx(ctx context.Context, body types.EMCredential) (*types.ResourceCredential, error) {
 	// Validations
 	// Set to default SNMPv2CommunityString if empty, set the SNMPv2Protocol to "SSH"
 	if body.SNMPv2CommunityString == "" {
@@ -241,12 +294,13 @@ func (s *System) CreateElementManagerResourceCredential(body types.EMCredential)
 	fullBody := types.ElementManagerCredentialWrapper{
 		EMCredential: body,
 	}
-	defer TimeSpent("CreateElementManagerResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "CreateElementManagerResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential")
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPost, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPost, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -256,6 +310,11 @@ func (s *System) CreateElementManagerResourceCredential(body types.EMCredential)
 
 // ModifyElementManagerResourceCredential creates a new Resource Credential
 func (s *System) ModifyElementManagerResourceCredential(body types.EMCredential, id string) (*types.ResourceCredential, error) {
+	return s.ModifyElementManagerResourceCredentialCtx(s.client.callContext(), body, id)
+}
+
+// ModifyElementManagerResourceCredentialCtx creates a new Resource Credential
+func (s *System) ModifyElementManagerResourceCredentialCtx(ctx context.Context, body types.EMCredential, id string) (*types.ResourceCredential, error) {
 	// Validations
 	// Set to default SNMPv2CommunityString if empty, set the SNMPv2Protocol to "SSH"
 	if body.SNMPv2CommunityString == "" {
@@ -266,12 +325,13 @@ func (s *System) ModifyElementManagerResourceCredential(body types.EMCredential,
 	fullBody := types.ElementManagerCredentialWrapper{
 		EMCredential: body,
 	}
-	defer TimeSpent("ModifyElementManagerResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "ModifyElementManagerResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential/%v", id)
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPut, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPut, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -281,15 +341,21 @@ func (s *System) ModifyElementManagerResourceCredential(body types.EMCredential,
 
 // CreateScaleIOResourceCredential creates a new Resource Credential
 func (s *System) CreateScaleIOResourceCredential(body types.ScaleIOCredential) (*types.ResourceCredential, error) {
+	return s.CreateScaleIOResourceCredentialCtx(s.client.callContext(), body)
+}
+
+// CreateScaleIOResourceCredentialCtx creates a new Resource Credential
+func (s *System) CreateScaleIOResourceCredentialCtx(ctx context.Context, body types.ScaleIOCredential) (*types.ResourceCredential, error) {
 	fullBody := types.GatewayCredentialWrapper{
 		ScaleIOCredential: body,
 	}
-	defer TimeSpent("CreateScaleIOResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "CreateScaleIOResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential")
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPost, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPost, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -299,15 +365,21 @@ func (s *System) CreateScaleIOResourceCredential(body types.ScaleIOCredential) (
 
 // ModifyScaleIOResourceCredential creates a new Resource Credential
 func (s *System) ModifyScaleIOResourceCredential(body types.ScaleIOCredential, id string) (*types.ResourceCredential, error) {
+	return s.ModifyScaleIOResourceCredentialCtx(s.client.callContext(), body, id)
+}
+
+// ModifyScaleIOResourceCredentialCtx creates a new Resource Credential
+func (s *System) ModifyScaleIOResourceCredentialCtx(ctx context.Context, body types.ScaleIOCredential, id string) (*types.ResourceCredential, error) {
 	fullBody := types.GatewayCredentialWrapper{
 		ScaleIOCredential: body,
 	}
-	defer TimeSpent("ModifyScaleIOResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "ModifyScaleIOResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential/%v", id)
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPut, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPut, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -317,15 +389,21 @@ func (s *System) ModifyScaleIOResourceCredential(body types.ScaleIOCredential, i
 
 // CreatePresentationServerResourceCredential creates a new Resource Credential
 func (s *System) CreatePresentationServerResourceCredential(body types.PSCredential) (*types.ResourceCredential, error) {
+	return s.CreatePresentationServerResourceCredentialCtx(s.client.callContext(), body)
+}
+
+// CreatePresentationServerResourceCredentialCtx creates a new Resource Credential
+func (s *System) CreatePresentationServerResourceCredentialCtx(ctx context.Context, body types.PSCredential) (*types.ResourceCredential, error) {
 	fullBody := types.PresentationServerCredentialWrapper{
 		PSCredential: body,
 	}
-	defer TimeSpent("CreatePresentationServerResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "CreatePresentationServerResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential")
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPost, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPost, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -335,15 +413,21 @@ func (s *System) CreatePresentationServerResourceCredential(body types.PSCredent
 
 // ModifyPresentationServerResourceCredential creates a new Resource Credential
 func (s *System) ModifyPresentationServerResourceCredential(body types.PSCredential, id string) (*types.ResourceCredential, error) {
+	return s.ModifyPresentationServerResourceCredentialCtx(s.client.callContext(), body, id)
+}
+
+// ModifyPresentationServerResourceCredentialCtx creates a new Resource Credential
+func (s *System) ModifyPresentationServerResourceCredentialCtx(ctx context.Context, body types.PSCredential, id string) (*types.ResourceCredential, error) {
 	fullBody := types.PresentationServerCredentialWrapper{
 		PSCredential: body,
 	}
-	defer TimeSpent("ModifyPresentationServerResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "ModifyPresentationServerResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential/%v", id)
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPut, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPut, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -353,6 +437,11 @@ func (s *System) ModifyPresentationServerResourceCredential(body types.PSCredent
 
 // CreateOsAdminResourceCredential creates a new Resource Credential
 func (s *System) CreateOsAdminResourceCredential(body types.OSAdminCredential) (*types.ResourceCredential, error) {
+	return s.CreateOsAdminResourceCredentialCtx(s.client.callContext(), body)
+}
+
+// CreateOsAdminResourceCredentialCtx creates a new Resource Credential
+func (s *System) CreateOsAdminResourceCredentialCtx(ctx context.Context, body types.OSAdminCredential) (*types.ResourceCredential, error) {
 	// Validations
 	if (body.SSHPrivateKey != "" && body.KeyPairName == "") || (body.SSHPrivateKey == "" && body.KeyPairName != "") {
 		return nil, fmt.Errorf("If using an SSHPrivateKey then both KeyPairName and SSHPrivateKey must be set")
@@ -364,12 +453,13 @@ func (s *System) CreateOsAdminResourceCredential(body types.OSAdminCredential) (
 	fullBody := types.OsAdminCredentialWrapper{
 		OSAdminCredential: body,
 	}
-	defer TimeSpent("CreateOsAdminResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "CreateOsAdminResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential")
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPost, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPost, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -379,6 +469,11 @@ func (s *System) CreateOsAdminResourceCredential(body types.OSAdminCredential) (
 
 // ModifyOsAdminResourceCredential creates a new Resource Credential
 func (s *System) ModifyOsAdminResourceCredential(body types.OSAdminCredential, id string) (*types.ResourceCredential, error) {
+	return s.ModifyOsAdminResourceCredentialCtx(s.client.callContext(), body, id)
+}
+
+// ModifyOsAdminResourceCredentialCtx creates a new Resource Credential
+func (s *System) ModifyOsAdminResourceCredentialCtx(ctx context.Context, body types.OSAdminCredential, id string) (*types.ResourceCredential, error) {
 	// Validations
 	if (body.SSHPrivateKey != "" && body.KeyPairName == "") || (body.SSHPrivateKey == "" && body.KeyPairName != "") {
 		return nil, fmt.Errorf("If using an SSHPrivateKey then both KeyPairName and SSHPrivateKey must be set")
@@ -391,12 +486,13 @@ func (s *System) ModifyOsAdminResourceCredential(body types.OSAdminCredential, i
 	fullBody := types.OsAdminCredentialWrapper{
 		OSAdminCredential: body,
 	}
-	defer TimeSpent("ModifyOsAdminResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "ModifyOsAdminResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential/%v", id)
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPut, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPut, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -406,6 +502,11 @@ func (s *System) ModifyOsAdminResourceCredential(body types.OSAdminCredential, i
 
 // CreateOsUserResourceCredential creates a new Resource Credential
 func (s *System) CreateOsUserResourceCredential(body types.OSUserCredential) (*types.ResourceCredential, error) {
+	return s.CreateOsUserResourceCredentialCtx(s.client.callContext(), body)
+}
+
+// CreateOsUserResourceCredentialCtx creates a new Resource Credential
+func (s *System) CreateOsUserResourceCredentialCtx(ctx context.Context, body types.OSUserCredential) (*types.ResourceCredential, error) {
 	// Validations
 	if (body.SSHPrivateKey != "" && body.KeyPairName == "") || (body.SSHPrivateKey == "" && body.KeyPairName != "") {
 		return nil, fmt.Errorf("If using an SSHPrivateKey then both KeyPairName and SSHPrivateKey must be set")
@@ -413,12 +514,13 @@ func (s *System) CreateOsUserResourceCredential(body types.OSUserCredential) (*t
 	fullBody := types.OsUserCredentialWrapper{
 		OSUserCredential: body,
 	}
-	defer TimeSpent("CreateOsUserResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "CreateOsUserResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential")
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPost, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPost, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -428,6 +530,11 @@ func (s *System) CreateOsUserResourceCredential(body types.OSUserCredential) (*t
 
 // ModifyOsUserResourceCredential creates a new Resource Credential
 func (s *System) ModifyOsUserResourceCredential(body types.OSUserCredential, id string) (*types.ResourceCredential, error) {
+	return s.ModifyOsUserResourceCredentialCtx(s.client.callContext(), body, id)
+}
+
+// ModifyOsUserResourceCredentialCtx creates a new Resource Credential
+func (s *System) ModifyOsUserResourceCredentialCtx(ctx context.Context, body types.OSUserCredential, id string) (*types.ResourceCredential, error) {
 	// Validations
 	if (body.SSHPrivateKey != "" && body.KeyPairName == "") || (body.SSHPrivateKey == "" && body.KeyPairName != "") {
 		return nil, fmt.Errorf("If using an SSHPrivateKey then both KeyPairName and SSHPrivateKey must be set")
@@ -435,12 +542,13 @@ func (s *System) ModifyOsUserResourceCredential(body types.OSUserCredential, id
 	fullBody := types.OsUserCredentialWrapper{
 		OSUserCredential: body,
 	}
-	defer TimeSpent("ModifyOsUserResourceCredential", time.Now())
+	ctx, span := s.client.startSpan(ctx, "ModifyOsUserResourceCredential")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/v1/Credential/%v", id)
 
 	var credentialResult types.ResourceCredential
-	_, err := s.client.xmlRequest(http.MethodPut, path, fullBody, &credentialResult)
+	_, err := s.client.xmlRequest(ctx, http.MethodPut, path, fullBody, &credentialResult)
 	if err != nil {
 		return nil, err
 	}
@@ -448,11 +556,20 @@ func (s *System) ModifyOsUserResourceCredential(body types.OSUserCredential, id
 	return &credentialResult, nil
 }
 
+// DeleteResourceCredential calls DeleteResourceCredentialCtx with the context set by WithContext.
 func (s *System) DeleteResourceCredential(id string) error {
+	return s.DeleteResourceCredentialCtx(s.client.callContext(), id)
+}
+
+// DeleteResourceCredentialCtx is the context-aware variant of DeleteResourceCredential.
+func (s *System) DeleteResourceCredentialCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "DeleteResourceCredential")
+	defer span.end()
+
 	path := fmt.Sprintf(
 		"/api/v1/Credential/%v", id)
 	param := &types.EmptyPayload{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodDelete, path, param, nil)
 	if err != nil {
 		return err
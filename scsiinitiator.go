@@ -13,23 +13,29 @@
 package goscaleio
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // GetScsiInitiator returns a ScsiInitiator
 func (s *System) GetScsiInitiator() ([]types.ScsiInitiator, error) {
-	defer TimeSpent("GetScsiInitiator", time.Now())
+	return s.GetScsiInitiatorCtx(s.client.callContext())
+}
+
+// GetScsiInitiatorCtx returns a ScsiInitiator
+func (s *System) GetScsiInitiatorCtx(ctx context.Context) ([]types.ScsiInitiator, error) {
+	ctx, span := s.client.startSpan(ctx, "GetScsiInitiator")
+	defer span.end()
 
 	path := fmt.Sprintf(
 		"/api/instances/System::%v/relationships/ScsiInitiator",
 		s.System.ID)
 
 	var si []types.ScsiInitiator
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &si)
 	if err != nil {
 		return nil, err
@@ -13,11 +13,11 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"os/exec"
-	"reflect"
 	"strings"
 	"time"
 
@@ -44,13 +44,19 @@ func NewSdc(client *Client, sdc *types.Sdc) *Sdc {
 
 // GetSdc returns a Sdc
 func (s *System) GetSdc() ([]types.Sdc, error) {
-	defer TimeSpent("GetSdc", time.Now())
+	return s.GetSdcCtx(s.client.callContext())
+}
+
+// GetSdcCtx returns a Sdc
+func (s *System) GetSdcCtx(ctx context.Context) ([]types.Sdc, error) {
+	ctx, span := s.client.startSpan(ctx, "GetSdc")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/System::%v/relationships/Sdc",
 		s.System.ID)
 
 	var sdcs []types.Sdc
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &sdcs)
 	if err != nil {
 		return nil, err
@@ -61,12 +67,18 @@ func (s *System) GetSdc() ([]types.Sdc, error) {
 
 // GetSdcByID returns a Sdc searched by id
 func (s *System) GetSdcByID(id string) (*Sdc, error) {
-	defer TimeSpent("GetSdcByID", time.Now())
+	return s.GetSdcByIDCtx(s.client.callContext(), id)
+}
+
+// GetSdcByIDCtx returns a Sdc searched by id
+func (s *System) GetSdcByIDCtx(ctx context.Context, id string) (*Sdc, error) {
+	ctx, span := s.client.startSpan(ctx, "GetSdcByID")
+	defer span.end()
 
 	path := fmt.Sprintf("api/instances/Sdc::%v", id)
 
 	var sdc types.Sdc
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &sdc)
 	if err != nil {
 		return nil, err
@@ -75,10 +87,45 @@ func (s *System) GetSdcByID(id string) (*Sdc, error) {
 	return NewSdc(s.client, &sdc), nil
 }
 
+// GetSdcsByIDs returns the Sdcs with the given IDs, fetched in batches
+func (s *System) GetSdcsByIDs(ids []string) ([]types.Sdc, error) {
+	return s.GetSdcsByIDsCtx(s.client.callContext(), ids)
+}
+
+// GetSdcsByIDsCtx returns the Sdcs with the given IDs, fetched in batches
+func (s *System) GetSdcsByIDsCtx(ctx context.Context, ids []string) ([]types.Sdc, error) {
+	ctx, span := s.client.startSpan(ctx, "GetSdcsByIDs")
+	defer span.end()
+
+	return queryBySelectedIDs[types.Sdc](ctx, s.client, "Sdc", ids)
+}
+
+// GetSdcStatisticsByIDs returns the statistics of the Sdcs with the given
+// IDs, by Sdc ID, fetched in batches
+func (s *System) GetSdcStatisticsByIDs(ids []string) (map[string]*types.SdcStatistics, error) {
+	return s.GetSdcStatisticsByIDsCtx(s.client.callContext(), ids)
+}
+
+// GetSdcStatisticsByIDsCtx returns the statistics of the Sdcs with the given
+// IDs, by Sdc ID, fetched in batches
+func (s *System) GetSdcStatisticsByIDsCtx(ctx context.Context, ids []string) (map[string]*types.SdcStatistics, error) {
+	ctx, span := s.client.startSpan(ctx, "GetSdcStatisticsByIDs")
+	defer span.end()
+
+	return statisticsByIDs[types.SdcStatistics](ctx, s.client, "Sdc", sdcStatisticsProperties, ids)
+}
+
 // ChangeSdcName returns a Sdc after changing its name
 // https://developer.dell.com/apis/4008/versions/4.0/PowerFlex_REST_API.json/paths/~1api~1instances~1Sdc::%7Bid%7D~1action~1setSdcName/post
 func (s *System) ChangeSdcName(idOfSdc, name string) (*Sdc, error) {
-	defer TimeSpent("ChangeSdcName", time.Now())
+	return s.ChangeSdcNameCtx(s.client.callContext(), idOfSdc, name)
+}
+
+// ChangeSdcNameCtx returns a Sdc after changing its name
+// https://developer.dell.com/apis/4008/versions/4.0/PowerFlex_REST_API.json/paths/~1api~1instances~1Sdc::%7Bid%7D~1action~1setSdcName/post
+func (s *System) ChangeSdcNameCtx(ctx context.Context, idOfSdc, name string) (*Sdc, error) {
+	ctx, span := s.client.startSpan(ctx, "ChangeSdcName")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdc::%v/action/setSdcName", idOfSdc)
 
@@ -87,7 +134,7 @@ func (s *System) ChangeSdcName(idOfSdc, name string) (*Sdc, error) {
 	var body types.ChangeSdcNameParam = types.ChangeSdcNameParam{
 		SdcName: name,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, &sdc)
 	if err != nil {
 		return nil, err
@@ -98,7 +145,13 @@ func (s *System) ChangeSdcName(idOfSdc, name string) (*Sdc, error) {
 
 // ChangeSdcPerfProfile returns a Sdc after changing its PerfProfile
 func (s *System) ChangeSdcPerfProfile(idOfSdc, perfProfile string) (*Sdc, error) {
-	defer TimeSpent("ChangeSdcPerfProfile", time.Now())
+	return s.ChangeSdcPerfProfileCtx(s.client.callContext(), idOfSdc, perfProfile)
+}
+
+// ChangeSdcPerfProfileCtx returns a Sdc after changing its PerfProfile
+func (s *System) ChangeSdcPerfProfileCtx(ctx context.Context, idOfSdc, perfProfile string) (*Sdc, error) {
+	ctx, span := s.client.startSpan(ctx, "ChangeSdcPerfProfile")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdc::%v/action/setSdcPerformanceParameters", idOfSdc)
 
@@ -107,7 +160,7 @@ func (s *System) ChangeSdcPerfProfile(idOfSdc, perfProfile string) (*Sdc, error)
 	var body types.ChangeSdcPerfProfile = types.ChangeSdcPerfProfile{
 		PerfProfile: perfProfile,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, &sdc)
 	if err != nil {
 		return nil, err
@@ -118,27 +171,51 @@ func (s *System) ChangeSdcPerfProfile(idOfSdc, perfProfile string) (*Sdc, error)
 
 // FindSdc returns a Sdc
 func (s *System) FindSdc(field, value string) (*Sdc, error) {
-	defer TimeSpent("FindSdc", time.Now())
+	return s.FindSdcCtx(s.client.callContext(), field, value)
+}
+
+// FindSdcCtx returns a Sdc
+func (s *System) FindSdcCtx(ctx context.Context, field, value string) (*Sdc, error) {
+	ctx, span := s.client.startSpan(ctx, "FindSdc")
+	defer span.end()
 
-	sdcs, err := s.GetSdc()
+	sdcs, err := s.QuerySdcCtx(ctx, Query().Where(field, Eq, value))
 	if err != nil {
 		return nil, err
 	}
-
-	for i, sdc := range sdcs {
-		valueOf := reflect.ValueOf(sdc)
-		switch {
-		case reflect.Indirect(valueOf).FieldByName(field).String() == value:
-			return NewSdc(s.client, &sdcs[i]), nil
-		}
+	if len(sdcs) == 0 {
+		return nil, errors.New("Couldn't find SDC")
 	}
 
-	return nil, errors.New("Couldn't find SDC")
+	return NewSdc(s.client, &sdcs[0]), nil
+}
+
+// QuerySdc returns the Sdcs of the system that match q
+func (s *System) QuerySdc(q *QueryBuilder) ([]types.Sdc, error) {
+	return s.QuerySdcCtx(s.client.callContext(), q)
+}
+
+// QuerySdcCtx returns the Sdcs of the system that match q
+func (s *System) QuerySdcCtx(ctx context.Context, q *QueryBuilder) ([]types.Sdc, error) {
+	ctx, span := s.client.startSpan(ctx, "QuerySdc")
+	defer span.end()
+
+	return queryMDM[types.Sdc](ctx, s.client, mdmType{
+		name:   "Sdc",
+		list:   fmt.Sprintf("/api/instances/System::%v/relationships/Sdc", s.System.ID),
+		byName: true,
+	}, q)
 }
 
 // GetStatistics returns a Sdc statistcs
 func (sdc *Sdc) GetStatistics() (*types.SdcStatistics, error) {
-	defer TimeSpent("GetStatistics", time.Now())
+	return sdc.GetStatisticsCtx(sdc.client.callContext())
+}
+
+// GetStatisticsCtx returns a Sdc statistcs
+func (sdc *Sdc) GetStatisticsCtx(ctx context.Context) (*types.SdcStatistics, error) {
+	ctx, span := sdc.client.startSpan(ctx, "GetStatistics")
+	defer span.end()
 
 	link, err := GetLink(sdc.Sdc.Links, "/api/Sdc/relationship/Statistics")
 	if err != nil {
@@ -146,7 +223,7 @@ func (sdc *Sdc) GetStatistics() (*types.SdcStatistics, error) {
 	}
 
 	var stats types.SdcStatistics
-	err = sdc.client.getJSONWithRetry(
+	err = sdc.client.getJSONWithRetry(ctx,
 		http.MethodGet, link.HREF, nil, &stats)
 	if err != nil {
 		return nil, err
@@ -157,7 +234,13 @@ func (sdc *Sdc) GetStatistics() (*types.SdcStatistics, error) {
 
 // GetVolume returns a volume
 func (sdc *Sdc) GetVolume() ([]*types.Volume, error) {
-	defer TimeSpent("GetVolume", time.Now())
+	return sdc.GetVolumeCtx(sdc.client.callContext())
+}
+
+// GetVolumeCtx returns a volume
+func (sdc *Sdc) GetVolumeCtx(ctx context.Context) ([]*types.Volume, error) {
+	ctx, span := sdc.client.startSpan(ctx, "GetVolume")
+	defer span.end()
 
 	link, err := GetLink(sdc.Sdc.Links, "/api/Sdc/relationship/Volume")
 	if err != nil {
@@ -165,7 +248,7 @@ func (sdc *Sdc) GetVolume() ([]*types.Volume, error) {
 	}
 
 	var vols []*types.Volume
-	err = sdc.client.getJSONWithRetry(
+	err = sdc.client.getJSONWithRetry(ctx,
 		http.MethodGet, link.HREF, nil, &vols)
 	if err != nil {
 		return nil, err
@@ -174,14 +257,21 @@ func (sdc *Sdc) GetVolume() ([]*types.Volume, error) {
 	return vols, nil
 }
 
+// GetVolumeMetrics calls GetVolumeMetricsCtx with the context set by WithContext.
 func (sdc *Sdc) GetVolumeMetrics() ([]*types.SdcVolumeMetrics, error) {
-	defer TimeSpent("GetVolume", time.Now())
+	return sdc.GetVolumeMetricsCtx(sdc.client.callContext())
+}
+
+// GetVolumeMetricsCtx is the context-aware variant of GetVolumeMetrics.
+func (sdc *Sdc) GetVolumeMetricsCtx(ctx context.Context) ([]*types.SdcVolumeMetrics, error) {
+	ctx, span := sdc.client.startSpan(ctx, "GetVolume")
+	defer span.end()
 
 	sdcID := sdc.Sdc.ID
 	path := fmt.Sprintf("/api/instances/Sdc::%s/action/queryVolumeSdcBwc", sdcID)
 	body := struct{}{}
 	var metrics []*types.SdcVolumeMetrics
-	err := sdc.client.getJSONWithRetry(http.MethodPost, path, body, &metrics)
+	err := sdc.client.getJSONWithRetry(ctx, http.MethodPost, path, body, &metrics)
 	if err != nil {
 		return nil, err
 	}
@@ -191,10 +281,16 @@ func (sdc *Sdc) GetVolumeMetrics() ([]*types.SdcVolumeMetrics, error) {
 
 // FindVolumes returns volumes
 func (sdc *Sdc) FindVolumes() ([]*Volume, error) {
-	defer TimeSpent("FindVolumes", time.Now())
+	return sdc.FindVolumesCtx(sdc.client.callContext())
+}
+
+// FindVolumesCtx returns volumes
+func (sdc *Sdc) FindVolumesCtx(ctx context.Context) ([]*Volume, error) {
+	ctx, span := sdc.client.startSpan(ctx, "FindVolumes")
+	defer span.end()
 
 	var rlt []*Volume
-	vols, err := sdc.GetVolume()
+	vols, err := sdc.GetVolumeCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -230,12 +326,20 @@ func GetSdcLocalGUID() (string, error) {
 func (v *Volume) MapVolumeSdc(
 	mapVolumeSdcParam *types.MapVolumeSdcParam,
 ) error {
-	defer TimeSpent("MapVolumeSdc", time.Now())
+	return v.MapVolumeSdcCtx(v.client.callContext(), mapVolumeSdcParam)
+}
+
+// MapVolumeSdcCtx maps a volume to Sdc
+func (v *Volume) MapVolumeSdcCtx(ctx context.Context,
+	mapVolumeSdcParam *types.MapVolumeSdcParam,
+) error {
+	ctx, span := v.client.startSpan(ctx, "MapVolumeSdc")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Volume::%s/action/addMappedSdc",
 		v.Volume.ID)
 
-	err := v.client.getJSONWithRetry(
+	err := v.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, mapVolumeSdcParam, nil)
 	if err != nil {
 		return err
@@ -248,12 +352,20 @@ func (v *Volume) MapVolumeSdc(
 func (v *Volume) UnmapVolumeSdc(
 	unmapVolumeSdcParam *types.UnmapVolumeSdcParam,
 ) error {
-	defer TimeSpent("UnmapVolumeSdc", time.Now())
+	return v.UnmapVolumeSdcCtx(v.client.callContext(), unmapVolumeSdcParam)
+}
+
+// UnmapVolumeSdcCtx unmaps a volume from Sdc
+func (v *Volume) UnmapVolumeSdcCtx(ctx context.Context,
+	unmapVolumeSdcParam *types.UnmapVolumeSdcParam,
+) error {
+	ctx, span := v.client.startSpan(ctx, "UnmapVolumeSdc")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Volume::%s/action/removeMappedSdc",
 		v.Volume.ID)
 
-	err := v.client.getJSONWithRetry(
+	err := v.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, unmapVolumeSdcParam, nil)
 	if err != nil {
 		return err
@@ -266,13 +378,21 @@ func (v *Volume) UnmapVolumeSdc(
 func (v *Volume) SetMappedSdcLimits(
 	setMappedSdcLimitsParam *types.SetMappedSdcLimitsParam,
 ) error {
-	defer TimeSpent("SetMappedSdcLimits", time.Now())
+	return v.SetMappedSdcLimitsCtx(v.client.callContext(), setMappedSdcLimitsParam)
+}
+
+// SetMappedSdcLimitsCtx sets Sdc mapped limits
+func (v *Volume) SetMappedSdcLimitsCtx(ctx context.Context,
+	setMappedSdcLimitsParam *types.SetMappedSdcLimitsParam,
+) error {
+	ctx, span := v.client.startSpan(ctx, "SetMappedSdcLimits")
+	defer span.end()
 
 	path := fmt.Sprintf(
 		"/api/instances/Volume::%s/action/setMappedSdcLimits",
 		v.Volume.ID)
 
-	err := v.client.getJSONWithRetry(
+	err := v.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, setMappedSdcLimitsParam, nil)
 	if err != nil {
 		return err
@@ -283,13 +403,21 @@ func (v *Volume) SetMappedSdcLimits(
 
 // RenameSdc renames the sdc with given name
 func (c *Client) RenameSdc(sdcID, name string) error {
+	return c.RenameSdcCtx(c.callContext(), sdcID, name)
+}
+
+// RenameSdcCtx renames the sdc with given name
+func (c *Client) RenameSdcCtx(ctx context.Context, sdcID, name string) error {
+	ctx, span := c.startSpan(ctx, "RenameSdc")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/Sdc::%s/action/setSdcName", sdcID)
 
 	renameSdcParam := &types.RenameSdcParam{
 		SdcName: name,
 	}
 
-	err := c.getJSONWithRetry(
+	err := c.getJSONWithRetry(ctx,
 		http.MethodPost, path, renameSdcParam, nil)
 	if err != nil {
 		return err
@@ -299,12 +427,18 @@ func (c *Client) RenameSdc(sdcID, name string) error {
 
 // DeleteSdc deletes a Sdc against Id
 func (s *System) DeleteSdc(id string) error {
-	defer TimeSpent("DeleteSdc", time.Now())
+	return s.DeleteSdcCtx(s.client.callContext(), id)
+}
+
+// DeleteSdcCtx deletes a Sdc against Id
+func (s *System) DeleteSdcCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "DeleteSdc")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdc::%v/action/removeSdc", id)
 
 	sdcParam := &types.EmptyPayload{}
-	err := s.client.getJSONWithRetry(http.MethodPost, path, sdcParam, nil)
+	err := s.client.getJSONWithRetry(ctx, http.MethodPost, path, sdcParam, nil)
 	if err != nil {
 		return err
 	}
@@ -314,14 +448,20 @@ func (s *System) DeleteSdc(id string) error {
 
 // GetSdcIDByIP get a Sdc id by IP Address
 func (s *System) GetSdcIDByIP(ip string) (string, error) {
-	defer TimeSpent("GetSdcId", time.Now())
+	return s.GetSdcIDByIPCtx(s.client.callContext(), ip)
+}
+
+// GetSdcIDByIPCtx get a Sdc id by IP Address
+func (s *System) GetSdcIDByIPCtx(ctx context.Context, ip string) (string, error) {
+	ctx, span := s.client.startSpan(ctx, "GetSdcId")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/types/Sdc/instances/action/queryIdByKey")
 
 	sdcParam := &types.GetSdcIDByIPParam{
 		IP: ip,
 	}
-	sdcID, err := s.client.getStringWithRetry(http.MethodPost, path, sdcParam)
+	sdcID, err := s.client.getStringWithRetry(ctx, http.MethodPost, path, sdcParam)
 	if err != nil {
 		return "", err
 	}
@@ -331,13 +471,19 @@ func (s *System) GetSdcIDByIP(ip string) (string, error) {
 
 // SetRestrictedMode sets the restricted mode for the system
 func (s *System) SetRestrictedMode(mode string) error {
-	defer TimeSpent("SetRestrictedMode", time.Now())
+	return s.SetRestrictedModeCtx(s.client.callContext(), mode)
+}
+
+// SetRestrictedModeCtx sets the restricted mode for the system
+func (s *System) SetRestrictedModeCtx(ctx context.Context, mode string) error {
+	ctx, span := s.client.startSpan(ctx, "SetRestrictedMode")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/System::%v/action/setRestrictedSdcMode", s.System.ID)
 	sdcParam := &types.SetRestrictedMode{
 		RestrictedSdcMode: mode,
 	}
-	err := s.client.getJSONWithRetry(http.MethodPost, path, sdcParam, nil)
+	err := s.client.getJSONWithRetry(ctx, http.MethodPost, path, sdcParam, nil)
 	if err != nil {
 		return err
 	}
@@ -347,7 +493,13 @@ func (s *System) SetRestrictedMode(mode string) error {
 
 // SetApprovedIps sets the approved IPs for a specific SDC in the system.
 func (s *System) SetApprovedIps(sdcID string, sdcApprovedIps []string) error {
-	defer TimeSpent("SetApprovedIps", time.Now())
+	return s.SetApprovedIpsCtx(s.client.callContext(), sdcID, sdcApprovedIps)
+}
+
+// SetApprovedIpsCtx sets the approved IPs for a specific SDC in the system.
+func (s *System) SetApprovedIpsCtx(ctx context.Context, sdcID string, sdcApprovedIps []string) error {
+	ctx, span := s.client.startSpan(ctx, "SetApprovedIps")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/System::%v/action/setApprovedSdcIps", s.System.ID)
 	sdcParam := &types.SetApprovedIps{
@@ -355,7 +507,7 @@ func (s *System) SetApprovedIps(sdcID string, sdcApprovedIps []string) error {
 		SdcApprovedIps: sdcApprovedIps,
 	}
 
-	err := s.client.getJSONWithRetry(http.MethodPost, path, sdcParam, nil)
+	err := s.client.getJSONWithRetry(ctx, http.MethodPost, path, sdcParam, nil)
 	if err != nil {
 		return err
 	}
@@ -365,7 +517,13 @@ func (s *System) SetApprovedIps(sdcID string, sdcApprovedIps []string) error {
 
 // ApproveSdc approves an SDC
 func (s *System) ApproveSdc(approveSdcParam *types.ApproveSdcParam) (*types.ApproveSdcResponse, error) {
-	defer TimeSpent("ApproveSdc", time.Now())
+	return s.ApproveSdcCtx(s.client.callContext(), approveSdcParam)
+}
+
+// ApproveSdcCtx approves an SDC
+func (s *System) ApproveSdcCtx(ctx context.Context, approveSdcParam *types.ApproveSdcParam) (*types.ApproveSdcResponse, error) {
+	ctx, span := s.client.startSpan(ctx, "ApproveSdc")
+	defer span.end()
 	var resp types.ApproveSdcResponse
 
 	path := fmt.Sprintf("/api/instances/System::%v/action/approveSdc", s.System.ID)
@@ -376,7 +534,7 @@ func (s *System) ApproveSdc(approveSdcParam *types.ApproveSdcParam) (*types.Appr
 		Name:    approveSdcParam.Name,
 	}
 
-	err := s.client.getJSONWithRetry(http.MethodPost, path, sdcParam, &resp)
+	err := s.client.getJSONWithRetry(ctx, http.MethodPost, path, sdcParam, &resp)
 	if err != nil {
 		return nil, err
 	}
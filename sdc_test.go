@@ -501,6 +501,13 @@ func TestFindSdc(t *testing.T) {
 
 					resp.Write(content)
 					resp.WriteHeader(http.StatusOK)
+				case fmt.Sprintf("/api/instances/Sdc::%v", searchSdcID):
+					content, err := json.Marshal(testSdc[0])
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					resp.Write(content)
 				default:
 					resp.WriteHeader(http.StatusBadRequest)
 					resp.Write([]byte(`{"message":"no route handled","httpStatusCode":400,"errorCode":0}`))
@@ -530,6 +537,9 @@ func TestFindSdc(t *testing.T) {
 
 					resp.Write(content)
 					resp.WriteHeader(http.StatusOK)
+				case fmt.Sprintf("/api/instances/Sdc::%v", searchSdcID):
+					resp.WriteHeader(http.StatusNotFound)
+					resp.Write([]byte(`{"message":"Not found","httpStatusCode":404,"errorCode":0}`))
 				default:
 					resp.WriteHeader(http.StatusBadRequest)
 					resp.Write([]byte(`{"message":"no route handled","httpStatusCode":400,"errorCode":0}`))
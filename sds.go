@@ -13,12 +13,12 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"strconv"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
@@ -56,7 +56,15 @@ func NewSdsEx(client *Client, sds *types.Sds) *Sds {
 func (pd *ProtectionDomain) CreateSds(
 	name string, ipList []string,
 ) (string, error) {
-	defer TimeSpent("CreateSds", time.Now())
+	return pd.CreateSdsCtx(pd.client.callContext(), name, ipList)
+}
+
+// CreateSdsCtx creates a new Sds with automatically assigned roles to IPs
+func (pd *ProtectionDomain) CreateSdsCtx(ctx context.Context,
+	name string, ipList []string,
+) (string, error) {
+	ctx, span := pd.client.startSpan(ctx, "CreateSds")
+	defer span.end()
 
 	sdsParam := &types.SdsParam{
 		Name:               name,
@@ -80,7 +88,7 @@ func (pd *ProtectionDomain) CreateSds(
 		return "", fmt.Errorf("Must explicitly provide IP role for more than 2 SDS IPs")
 	}
 
-	return pd.createSds(sdsParam)
+	return pd.createSds(ctx, sdsParam)
 }
 
 func getNonZeroIntType(i int) string {
@@ -92,7 +100,13 @@ func getNonZeroIntType(i int) string {
 
 // CreateSdsWithParams creates a new Sds with user defined SdsParam struct
 func (pd *ProtectionDomain) CreateSdsWithParams(sds *types.Sds) (string, error) {
-	defer TimeSpent("CreateSdsWithParams", time.Now())
+	return pd.CreateSdsWithParamsCtx(pd.client.callContext(), sds)
+}
+
+// CreateSdsWithParamsCtx creates a new Sds with user defined SdsParam struct
+func (pd *ProtectionDomain) CreateSdsWithParamsCtx(ctx context.Context, sds *types.Sds) (string, error) {
+	ctx, span := pd.client.startSpan(ctx, "CreateSdsWithParams")
+	defer span.end()
 
 	sdsParam := &types.SdsParam{
 		Name:               sds.Name,
@@ -133,14 +147,14 @@ func (pd *ProtectionDomain) CreateSdsWithParams(sds *types.Sds) (string, error)
 		}
 	}
 
-	return pd.createSds(sdsParam)
+	return pd.createSds(ctx, sdsParam)
 }
 
-func (pd *ProtectionDomain) createSds(sdsParam *types.SdsParam) (string, error) {
+func (pd *ProtectionDomain) createSds(ctx context.Context, sdsParam *types.SdsParam) (string, error) {
 	path := fmt.Sprintf("/api/types/Sds/instances")
 
 	sds := types.SdsResp{}
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, sdsParam, &sds)
 	if err != nil {
 		return "", err
@@ -151,12 +165,18 @@ func (pd *ProtectionDomain) createSds(sdsParam *types.SdsParam) (string, error)
 
 // GetSds returns all Sds on the protection domain
 func (pd *ProtectionDomain) GetSds() ([]types.Sds, error) {
-	defer TimeSpent("GetSds", time.Now())
+	return pd.GetSdsCtx(pd.client.callContext())
+}
+
+// GetSdsCtx returns all Sds on the protection domain
+func (pd *ProtectionDomain) GetSdsCtx(ctx context.Context) ([]types.Sds, error) {
+	ctx, span := pd.client.startSpan(ctx, "GetSds")
+	defer span.end()
 	path := fmt.Sprintf("/api/instances/ProtectionDomain::%v/relationships/Sds",
 		pd.ProtectionDomain.ID)
 
 	var sdss []types.Sds
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &sdss)
 	if err != nil {
 		return nil, err
@@ -167,11 +187,17 @@ func (pd *ProtectionDomain) GetSds() ([]types.Sds, error) {
 
 // GetAllSds returns all SDS on the system
 func (s *System) GetAllSds() ([]types.Sds, error) {
-	defer TimeSpent("GetSds", time.Now())
+	return s.GetAllSdsCtx(s.client.callContext())
+}
+
+// GetAllSdsCtx returns all SDS on the system
+func (s *System) GetAllSdsCtx(ctx context.Context) ([]types.Sds, error) {
+	ctx, span := s.client.startSpan(ctx, "GetSds")
+	defer span.end()
 	path := "/api/types/Sds/instances"
 
 	var sdss []types.Sds
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &sdss)
 	if err != nil {
 		return nil, err
@@ -184,45 +210,107 @@ func (s *System) GetAllSds() ([]types.Sds, error) {
 func (pd *ProtectionDomain) FindSds(
 	field, value string,
 ) (*types.Sds, error) {
-	defer TimeSpent("FindSds", time.Now())
+	return pd.FindSdsCtx(pd.client.callContext(), field, value)
+}
+
+// FindSdsCtx returns a Sds
+func (pd *ProtectionDomain) FindSdsCtx(ctx context.Context,
+	field, value string,
+) (*types.Sds, error) {
+	ctx, span := pd.client.startSpan(ctx, "FindSds")
+	defer span.end()
 
-	sdss, err := pd.GetSds()
+	sdss, err := pd.QuerySdsCtx(ctx, Query().Where(field, Eq, value))
 	if err != nil {
 		return nil, err
 	}
-
-	for _, sds := range sdss {
-		valueOf := reflect.ValueOf(sds)
-		switch {
-		case reflect.Indirect(valueOf).FieldByName(field).String() == value:
-			return &sds, nil
-		}
+	if len(sdss) == 0 {
+		return nil, errors.New("Couldn't find SDS")
 	}
 
-	return nil, errors.New("Couldn't find SDS")
+	return &sdss[0], nil
+}
+
+// QuerySds returns the Sdss of the protection domain that match q
+func (pd *ProtectionDomain) QuerySds(q *QueryBuilder) ([]types.Sds, error) {
+	return pd.QuerySdsCtx(pd.client.callContext(), q)
+}
+
+// QuerySdsCtx returns the Sdss of the protection domain that match q
+func (pd *ProtectionDomain) QuerySdsCtx(ctx context.Context, q *QueryBuilder) ([]types.Sds, error) {
+	ctx, span := pd.client.startSpan(ctx, "QuerySds")
+	defer span.end()
+
+	// lookups by ID or name are not limited to the protection domain
+	q = q.clone().Where("protectionDomainId", Eq, pd.ProtectionDomain.ID)
+	return queryMDM[types.Sds](ctx, pd.client, mdmType{
+		name:   "Sds",
+		list:   fmt.Sprintf("/api/instances/ProtectionDomain::%v/relationships/Sds", pd.ProtectionDomain.ID),
+		byName: true,
+	}, q)
 }
 
 // GetSdsByID returns a Sds by ID
 func (s *System) GetSdsByID(id string) (types.Sds, error) {
-	defer TimeSpent("GetSdsByID", time.Now())
+	return s.GetSdsByIDCtx(s.client.callContext(), id)
+}
+
+// GetSdsByIDCtx returns a Sds by ID
+func (s *System) GetSdsByIDCtx(ctx context.Context, id string) (types.Sds, error) {
+	ctx, span := s.client.startSpan(ctx, "GetSdsByID")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sds::%s", id)
 
 	var sds types.Sds
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &sds)
 
 	return sds, err
 }
 
+// GetSdsByIDs returns the Sdss with the given IDs, fetched in batches
+func (s *System) GetSdsByIDs(ids []string) ([]types.Sds, error) {
+	return s.GetSdsByIDsCtx(s.client.callContext(), ids)
+}
+
+// GetSdsByIDsCtx returns the Sdss with the given IDs, fetched in batches
+func (s *System) GetSdsByIDsCtx(ctx context.Context, ids []string) ([]types.Sds, error) {
+	ctx, span := s.client.startSpan(ctx, "GetSdsByIDs")
+	defer span.end()
+
+	return queryBySelectedIDs[types.Sds](ctx, s.client, "Sds", ids)
+}
+
+// GetSdsStatisticsByIDs returns the statistics of the Sdss with the given
+// IDs, by Sds ID, fetched in batches
+func (s *System) GetSdsStatisticsByIDs(ids []string) (map[string]*types.Statistics, error) {
+	return s.GetSdsStatisticsByIDsCtx(s.client.callContext(), ids)
+}
+
+// GetSdsStatisticsByIDsCtx returns the statistics of the Sdss with the given
+// IDs, by Sds ID, fetched in batches
+func (s *System) GetSdsStatisticsByIDsCtx(ctx context.Context, ids []string) (map[string]*types.Statistics, error) {
+	ctx, span := s.client.startSpan(ctx, "GetSdsStatisticsByIDs")
+	defer span.end()
+
+	return statisticsByIDs[types.Statistics](ctx, s.client, "Sds", sdsStatisticsProperties, ids)
+}
+
 // DeleteSds deletes a Sds against Id
 func (pd *ProtectionDomain) DeleteSds(id string) error {
-	defer TimeSpent("DeleteSds", time.Now())
+	return pd.DeleteSdsCtx(pd.client.callContext(), id)
+}
+
+// DeleteSdsCtx deletes a Sds against Id
+func (pd *ProtectionDomain) DeleteSdsCtx(ctx context.Context, id string) error {
+	ctx, span := pd.client.startSpan(ctx, "DeleteSds")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sds::%v/action/removeSds", id)
 
 	sdsParam := &types.EmptyPayload{}
-	err := pd.client.getJSONWithRetry(http.MethodPost, path, sdsParam, nil)
+	err := pd.client.getJSONWithRetry(ctx, http.MethodPost, path, sdsParam, nil)
 	if err != nil {
 		return err
 	}
@@ -232,11 +320,17 @@ func (pd *ProtectionDomain) DeleteSds(id string) error {
 
 // AddSdSIP adds a new IP with specified Role in SDS
 func (pd *ProtectionDomain) AddSdSIP(id, ip, role string) error {
-	defer TimeSpent("AddSDSIPRole", time.Now())
+	return pd.AddSdSIPCtx(pd.client.callContext(), id, ip, role)
+}
+
+// AddSdSIPCtx adds a new IP with specified Role in SDS
+func (pd *ProtectionDomain) AddSdSIPCtx(ctx context.Context, id, ip, role string) error {
+	ctx, span := pd.client.startSpan(ctx, "AddSDSIPRole")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sds::%v/action/addSdsIp", id)
 
-	err := pd.client.getJSONWithRetry(http.MethodPost, path, map[string]string{
+	err := pd.client.getJSONWithRetry(ctx, http.MethodPost, path, map[string]string{
 		"ip":   ip,
 		"role": role,
 	}, nil)
@@ -249,7 +343,13 @@ func (pd *ProtectionDomain) AddSdSIP(id, ip, role string) error {
 
 // SetSDSIPRole sets IP and Role of SDS
 func (pd *ProtectionDomain) SetSDSIPRole(id, ip, role string) error {
-	defer TimeSpent("SetSDSIPRole", time.Now())
+	return pd.SetSDSIPRoleCtx(pd.client.callContext(), id, ip, role)
+}
+
+// SetSDSIPRoleCtx sets IP and Role of SDS
+func (pd *ProtectionDomain) SetSDSIPRoleCtx(ctx context.Context, id, ip, role string) error {
+	ctx, span := pd.client.startSpan(ctx, "SetSDSIPRole")
+	defer span.end()
 
 	sdsParam := &types.SdsIPRole{
 		SdsIPToSet: ip,
@@ -258,7 +358,7 @@ func (pd *ProtectionDomain) SetSDSIPRole(id, ip, role string) error {
 
 	path := fmt.Sprintf("/api/instances/Sds::%v/action/setSdsIpRole", id)
 
-	err := pd.client.getJSONWithRetry(http.MethodPost, path, sdsParam, nil)
+	err := pd.client.getJSONWithRetry(ctx, http.MethodPost, path, sdsParam, nil)
 	if err != nil {
 		return err
 	}
@@ -268,7 +368,13 @@ func (pd *ProtectionDomain) SetSDSIPRole(id, ip, role string) error {
 
 // RemoveSDSIP removes IP from SDS
 func (pd *ProtectionDomain) RemoveSDSIP(id, ip string) error {
-	defer TimeSpent("RemoveSDSIP", time.Now())
+	return pd.RemoveSDSIPCtx(pd.client.callContext(), id, ip)
+}
+
+// RemoveSDSIPCtx removes IP from SDS
+func (pd *ProtectionDomain) RemoveSDSIPCtx(ctx context.Context, id, ip string) error {
+	ctx, span := pd.client.startSpan(ctx, "RemoveSDSIP")
+	defer span.end()
 
 	sdsParam := &types.SdsIP{
 		IP: ip,
@@ -276,7 +382,7 @@ func (pd *ProtectionDomain) RemoveSDSIP(id, ip string) error {
 
 	path := fmt.Sprintf("/api/instances/Sds::%v/action/removeSdsIp", id)
 
-	err := pd.client.getJSONWithRetry(http.MethodPost, path, sdsParam, nil)
+	err := pd.client.getJSONWithRetry(ctx, http.MethodPost, path, sdsParam, nil)
 	if err != nil {
 		return err
 	}
@@ -286,7 +392,13 @@ func (pd *ProtectionDomain) RemoveSDSIP(id, ip string) error {
 
 // SetSdsName sets sds name
 func (pd *ProtectionDomain) SetSdsName(id, name string) error {
-	defer TimeSpent("SetSdsName", time.Now())
+	return pd.SetSdsNameCtx(pd.client.callContext(), id, name)
+}
+
+// SetSdsNameCtx sets sds name
+func (pd *ProtectionDomain) SetSdsNameCtx(ctx context.Context, id, name string) error {
+	ctx, span := pd.client.startSpan(ctx, "SetSdsName")
+	defer span.end()
 
 	sdsParam := &types.SdsName{
 		Name: name,
@@ -294,7 +406,7 @@ func (pd *ProtectionDomain) SetSdsName(id, name string) error {
 
 	path := fmt.Sprintf("/api/instances/Sds::%v/action/setSdsName", id)
 
-	err := pd.client.getJSONWithRetry(http.MethodPost, path, sdsParam, nil)
+	err := pd.client.getJSONWithRetry(ctx, http.MethodPost, path, sdsParam, nil)
 	if err != nil {
 		return err
 	}
@@ -304,7 +416,13 @@ func (pd *ProtectionDomain) SetSdsName(id, name string) error {
 
 // SetSdsPort sets sds port
 func (pd *ProtectionDomain) SetSdsPort(id string, port int) error {
-	defer TimeSpent("SetSdsPort", time.Now())
+	return pd.SetSdsPortCtx(pd.client.callContext(), id, port)
+}
+
+// SetSdsPortCtx sets sds port
+func (pd *ProtectionDomain) SetSdsPortCtx(ctx context.Context, id string, port int) error {
+	ctx, span := pd.client.startSpan(ctx, "SetSdsPort")
+	defer span.end()
 
 	sdsParam := &map[string]string{
 		"sdsPort": strconv.Itoa(port),
@@ -312,7 +430,7 @@ func (pd *ProtectionDomain) SetSdsPort(id string, port int) error {
 
 	path := fmt.Sprintf("/api/instances/Sds::%v/action/setSdsPort", id)
 
-	err := pd.client.getJSONWithRetry(http.MethodPost, path, sdsParam, nil)
+	err := pd.client.getJSONWithRetry(ctx, http.MethodPost, path, sdsParam, nil)
 	if err != nil {
 		return err
 	}
@@ -322,7 +440,13 @@ func (pd *ProtectionDomain) SetSdsPort(id string, port int) error {
 
 // SetSdsDrlMode sets sds DRL Mode (Volatile or NonVolatile)
 func (pd *ProtectionDomain) SetSdsDrlMode(id, drlMode string) error {
-	defer TimeSpent("SetSdsDrlMode", time.Now())
+	return pd.SetSdsDrlModeCtx(pd.client.callContext(), id, drlMode)
+}
+
+// SetSdsDrlModeCtx sets sds DRL Mode (Volatile or NonVolatile)
+func (pd *ProtectionDomain) SetSdsDrlModeCtx(ctx context.Context, id, drlMode string) error {
+	ctx, span := pd.client.startSpan(ctx, "SetSdsDrlMode")
+	defer span.end()
 
 	sdsParam := &map[string]string{
 		"drlMode": drlMode,
@@ -330,7 +454,7 @@ func (pd *ProtectionDomain) SetSdsDrlMode(id, drlMode string) error {
 
 	path := fmt.Sprintf("/api/instances/Sds::%s/action/setDrlMode", id)
 
-	err := pd.client.getJSONWithRetry(http.MethodPost, path, sdsParam, nil)
+	err := pd.client.getJSONWithRetry(ctx, http.MethodPost, path, sdsParam, nil)
 	if err != nil {
 		return err
 	}
@@ -340,7 +464,13 @@ func (pd *ProtectionDomain) SetSdsDrlMode(id, drlMode string) error {
 
 // SetSdsRfCache enables or disables Rf Cache
 func (pd *ProtectionDomain) SetSdsRfCache(id string, enable bool) error {
-	defer TimeSpent("SetSdsRfCache", time.Now())
+	return pd.SetSdsRfCacheCtx(pd.client.callContext(), id, enable)
+}
+
+// SetSdsRfCacheCtx enables or disables Rf Cache
+func (pd *ProtectionDomain) SetSdsRfCacheCtx(ctx context.Context, id string, enable bool) error {
+	ctx, span := pd.client.startSpan(ctx, "SetSdsRfCache")
+	defer span.end()
 	rfcachePaths := map[bool]string{
 		true:  "/api/instances/Sds::%s/action/enableRfcache",
 		false: "/api/instances/Sds::%s/action/disableRfcache",
@@ -348,7 +478,7 @@ func (pd *ProtectionDomain) SetSdsRfCache(id string, enable bool) error {
 	path := fmt.Sprintf(rfcachePaths[enable], id)
 	sdsParam := &types.EmptyPayload{}
 
-	err := pd.client.getJSONWithRetry(http.MethodPost, path, sdsParam, nil)
+	err := pd.client.getJSONWithRetry(ctx, http.MethodPost, path, sdsParam, nil)
 	if err != nil {
 		return err
 	}
@@ -358,7 +488,13 @@ func (pd *ProtectionDomain) SetSdsRfCache(id string, enable bool) error {
 
 // SetSdsRmCache enables or disables Read Ram Cache
 func (pd *ProtectionDomain) SetSdsRmCache(id string, enable bool) error {
-	defer TimeSpent("SetSdsRmCache", time.Now())
+	return pd.SetSdsRmCacheCtx(pd.client.callContext(), id, enable)
+}
+
+// SetSdsRmCacheCtx enables or disables Read Ram Cache
+func (pd *ProtectionDomain) SetSdsRmCacheCtx(ctx context.Context, id string, enable bool) error {
+	ctx, span := pd.client.startSpan(ctx, "SetSdsRmCache")
+	defer span.end()
 
 	rmCacheParam := &map[string]string{
 		"rmcacheEnabled": types.GetBoolType(enable),
@@ -366,7 +502,7 @@ func (pd *ProtectionDomain) SetSdsRmCache(id string, enable bool) error {
 
 	path := fmt.Sprintf("/api/instances/Sds::%s/action/setSdsRmcacheEnabled", id)
 
-	err := pd.client.getJSONWithRetry(http.MethodPost, path, rmCacheParam, nil)
+	err := pd.client.getJSONWithRetry(ctx, http.MethodPost, path, rmCacheParam, nil)
 	if err != nil {
 		return err
 	}
@@ -376,7 +512,13 @@ func (pd *ProtectionDomain) SetSdsRmCache(id string, enable bool) error {
 
 // SetSdsRmCacheSize sets size of Read Ram Cache in MB
 func (pd *ProtectionDomain) SetSdsRmCacheSize(id string, size int) error {
-	defer TimeSpent("SetSdsRmCacheSize", time.Now())
+	return pd.SetSdsRmCacheSizeCtx(pd.client.callContext(), id, size)
+}
+
+// SetSdsRmCacheSizeCtx sets size of Read Ram Cache in MB
+func (pd *ProtectionDomain) SetSdsRmCacheSizeCtx(ctx context.Context, id string, size int) error {
+	ctx, span := pd.client.startSpan(ctx, "SetSdsRmCacheSize")
+	defer span.end()
 
 	rmCacheSizeParam := &map[string]string{
 		"rmcacheSizeInMB": strconv.Itoa(size),
@@ -384,7 +526,7 @@ func (pd *ProtectionDomain) SetSdsRmCacheSize(id string, size int) error {
 
 	path := fmt.Sprintf("/api/instances/Sds::%s/action/setSdsRmcacheSize", id)
 
-	err := pd.client.getJSONWithRetry(http.MethodPost, path, rmCacheSizeParam, nil)
+	err := pd.client.getJSONWithRetry(ctx, http.MethodPost, path, rmCacheSizeParam, nil)
 	if err != nil {
 		return err
 	}
@@ -394,7 +536,13 @@ func (pd *ProtectionDomain) SetSdsRmCacheSize(id string, size int) error {
 
 // SetSdsPerformanceProfile sets the SDS Performance Profile
 func (pd *ProtectionDomain) SetSdsPerformanceProfile(id, perfProf string) error {
-	defer TimeSpent("SetSdsRmCacheSize", time.Now())
+	return pd.SetSdsPerformanceProfileCtx(pd.client.callContext(), id, perfProf)
+}
+
+// SetSdsPerformanceProfileCtx sets the SDS Performance Profile
+func (pd *ProtectionDomain) SetSdsPerformanceProfileCtx(ctx context.Context, id, perfProf string) error {
+	ctx, span := pd.client.startSpan(ctx, "SetSdsRmCacheSize")
+	defer span.end()
 
 	perfProfileParam := &map[string]string{
 		"perfProfile": perfProf,
@@ -402,7 +550,7 @@ func (pd *ProtectionDomain) SetSdsPerformanceProfile(id, perfProf string) error
 
 	path := fmt.Sprintf("/api/instances/Sds::%s/action/setSdsPerformanceParameters", id)
 
-	err := pd.client.getJSONWithRetry(http.MethodPost, path, perfProfileParam, nil)
+	err := pd.client.getJSONWithRetry(ctx, http.MethodPost, path, perfProfileParam, nil)
 	if err != nil {
 		return err
 	}
@@ -414,9 +562,17 @@ func (pd *ProtectionDomain) SetSdsPerformanceProfile(id, perfProf string) error
 func (s *System) FindSds(
 	field, value string,
 ) (*types.Sds, error) {
-	defer TimeSpent("FindSds", time.Now())
+	return s.FindSdsCtx(s.client.callContext(), field, value)
+}
+
+// FindSdsCtx returns a Sds using system instance
+func (s *System) FindSdsCtx(ctx context.Context,
+	field, value string,
+) (*types.Sds, error) {
+	ctx, span := s.client.startSpan(ctx, "FindSds")
+	defer span.end()
 
-	sdss, err := s.GetAllSds()
+	sdss, err := s.GetAllSdsCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -406,6 +406,16 @@ func TestFindSds(t *testing.T) {
 
 					resp.Write(content)
 					resp.WriteHeader(http.StatusOK)
+				case fmt.Sprintf("/api/instances/Sds::%s", searchSdsID):
+					content, err := json.Marshal(types.Sds{
+						ID:                 searchSdsID,
+						ProtectionDomainID: pdID,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					resp.Write(content)
 				default:
 					resp.WriteHeader(http.StatusBadRequest)
 					resp.Write([]byte(`{"message":"no route handled","httpStatusCode":400,"errorCode":0}`))
@@ -435,6 +445,9 @@ func TestFindSds(t *testing.T) {
 
 					resp.Write(content)
 					resp.WriteHeader(http.StatusOK)
+				case fmt.Sprintf("/api/instances/Sds::%s", searchSdsID):
+					resp.WriteHeader(http.StatusNotFound)
+					resp.Write([]byte(`{"message":"Not found","httpStatusCode":404,"errorCode":0}`))
 				default:
 					resp.WriteHeader(http.StatusBadRequest)
 					resp.Write([]byte(`{"message":"no route handled","httpStatusCode":400,"errorCode":0}`))
@@ -13,9 +13,9 @@
 package goscaleio
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
@@ -36,12 +36,18 @@ func NewSdt(client *Client, sdt *types.Sdt) *Sdt {
 
 // GetAllSdts returns all sdt
 func (s *System) GetAllSdts() ([]types.Sdt, error) {
-	defer TimeSpent("GetAllSdts", time.Now())
+	return s.GetAllSdtsCtx(s.client.callContext())
+}
+
+// GetAllSdtsCtx returns all sdt
+func (s *System) GetAllSdtsCtx(ctx context.Context) ([]types.Sdt, error) {
+	ctx, span := s.client.startSpan(ctx, "GetAllSdts")
+	defer span.end()
 
 	path := "/api/types/Sdt/instances"
 
 	var allSdts []types.Sdt
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &allSdts)
 	if err != nil {
 		return nil, err
@@ -52,12 +58,18 @@ func (s *System) GetAllSdts() ([]types.Sdt, error) {
 
 // GetSdtByID returns an sdt searched by id
 func (s *System) GetSdtByID(id string) (*types.Sdt, error) {
-	defer TimeSpent("GetSdtByID", time.Now())
+	return s.GetSdtByIDCtx(s.client.callContext(), id)
+}
+
+// GetSdtByIDCtx returns an sdt searched by id
+func (s *System) GetSdtByIDCtx(ctx context.Context, id string) (*types.Sdt, error) {
+	ctx, span := s.client.startSpan(ctx, "GetSdtByID")
+	defer span.end()
 
 	path := fmt.Sprintf("api/instances/Sdt::%v", id)
 
 	var sdt types.Sdt
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &sdt)
 	if err != nil {
 		return nil, err
@@ -68,7 +80,13 @@ func (s *System) GetSdtByID(id string) (*types.Sdt, error) {
 
 // CreateSdt creates a new Sdt
 func (pd *ProtectionDomain) CreateSdt(param *types.SdtParam) (*types.SdtResp, error) {
-	defer TimeSpent("CreateSdt", time.Now())
+	return pd.CreateSdtCtx(pd.client.callContext(), param)
+}
+
+// CreateSdtCtx creates a new Sdt
+func (pd *ProtectionDomain) CreateSdtCtx(ctx context.Context, param *types.SdtParam) (*types.SdtResp, error) {
+	ctx, span := pd.client.startSpan(ctx, "CreateSdt")
+	defer span.end()
 
 	if len(param.IPList) == 0 {
 		return nil, fmt.Errorf("Must provide at least 1 SDT IP")
@@ -78,21 +96,27 @@ func (pd *ProtectionDomain) CreateSdt(param *types.SdtParam) (*types.SdtResp, er
 
 	resp := &types.SdtResp{}
 	path := "/api/types/Sdt/instances"
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, param, resp)
 	return resp, err
 }
 
 // RenameSdt changes the name of the sdt.
 func (s *System) RenameSdt(id, name string) error {
-	defer TimeSpent("RenameSdt", time.Now())
+	return s.RenameSdtCtx(s.client.callContext(), id, name)
+}
+
+// RenameSdtCtx changes the name of the sdt.
+func (s *System) RenameSdtCtx(ctx context.Context, id, name string) error {
+	ctx, span := s.client.startSpan(ctx, "RenameSdt")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdt::%v/action/renameSdt", id)
 
 	body := types.SdtRenameParam{
 		NewName: name,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, nil)
 	if err != nil {
 		return err
@@ -103,14 +127,20 @@ func (s *System) RenameSdt(id, name string) error {
 
 // SetSdtNvmePort set the NVMe port for the sdt.
 func (s *System) SetSdtNvmePort(id string, port int) error {
-	defer TimeSpent("SetSdtNvmePort", time.Now())
+	return s.SetSdtNvmePortCtx(s.client.callContext(), id, port)
+}
+
+// SetSdtNvmePortCtx set the NVMe port for the sdt.
+func (s *System) SetSdtNvmePortCtx(ctx context.Context, id string, port int) error {
+	ctx, span := s.client.startSpan(ctx, "SetSdtNvmePort")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdt::%v/action/modifyNvmePort", id)
 
 	body := types.SdtNvmePortParam{
 		NewNvmePort: types.IntString(port),
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, nil)
 	if err != nil {
 		return err
@@ -121,14 +151,20 @@ func (s *System) SetSdtNvmePort(id string, port int) error {
 
 // SetSdtStoragePort sets the storage port for the sdt.
 func (s *System) SetSdtStoragePort(id string, port int) error {
-	defer TimeSpent("SetSdtStoragePort", time.Now())
+	return s.SetSdtStoragePortCtx(s.client.callContext(), id, port)
+}
+
+// SetSdtStoragePortCtx sets the storage port for the sdt.
+func (s *System) SetSdtStoragePortCtx(ctx context.Context, id string, port int) error {
+	ctx, span := s.client.startSpan(ctx, "SetSdtStoragePort")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdt::%v/action/modifyStoragePort", id)
 
 	body := types.SdtStoragePortParam{
 		NewStoragePort: types.IntString(port),
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, nil)
 	if err != nil {
 		return err
@@ -139,14 +175,20 @@ func (s *System) SetSdtStoragePort(id string, port int) error {
 
 // SetSdtDiscoveryPort sets the discovery port for the sdt.
 func (s *System) SetSdtDiscoveryPort(id string, port int) error {
-	defer TimeSpent("SetSdtDiscoveryPort", time.Now())
+	return s.SetSdtDiscoveryPortCtx(s.client.callContext(), id, port)
+}
+
+// SetSdtDiscoveryPortCtx sets the discovery port for the sdt.
+func (s *System) SetSdtDiscoveryPortCtx(ctx context.Context, id string, port int) error {
+	ctx, span := s.client.startSpan(ctx, "SetSdtDiscoveryPort")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdt::%v/action/modifyDiscoveryPort", id)
 
 	body := types.SdtDiscoveryPortParam{
 		NewDiscoveryPort: types.IntString(port),
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, nil)
 	if err != nil {
 		return err
@@ -157,7 +199,13 @@ func (s *System) SetSdtDiscoveryPort(id string, port int) error {
 
 // AddSdtTargetIP adds target IP and role for the sdt.
 func (s *System) AddSdtTargetIP(id, ip, role string) error {
-	defer TimeSpent("AddSdtTargetIP", time.Now())
+	return s.AddSdtTargetIPCtx(s.client.callContext(), id, ip, role)
+}
+
+// AddSdtTargetIPCtx adds target IP and role for the sdt.
+func (s *System) AddSdtTargetIPCtx(ctx context.Context, id, ip, role string) error {
+	ctx, span := s.client.startSpan(ctx, "AddSdtTargetIP")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdt::%v/action/addIp", id)
 
@@ -165,7 +213,7 @@ func (s *System) AddSdtTargetIP(id, ip, role string) error {
 		IP:   ip,
 		Role: role,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, nil)
 	if err != nil {
 		return err
@@ -176,14 +224,20 @@ func (s *System) AddSdtTargetIP(id, ip, role string) error {
 
 // RemoveSdtTargetIP removes target IP and role from the sdt.
 func (s *System) RemoveSdtTargetIP(id, ip string) error {
-	defer TimeSpent("RemoveSdtTargetIP", time.Now())
+	return s.RemoveSdtTargetIPCtx(s.client.callContext(), id, ip)
+}
+
+// RemoveSdtTargetIPCtx removes target IP and role from the sdt.
+func (s *System) RemoveSdtTargetIPCtx(ctx context.Context, id, ip string) error {
+	ctx, span := s.client.startSpan(ctx, "RemoveSdtTargetIP")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdt::%v/action/removeIp", id)
 
 	body := types.SdtRemoveIPParam{
 		IP: ip,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, nil)
 	if err != nil {
 		return err
@@ -194,7 +248,13 @@ func (s *System) RemoveSdtTargetIP(id, ip string) error {
 
 // ModifySdtIPRole modify target IP role for the sdt.
 func (s *System) ModifySdtIPRole(id, ip, role string) error {
-	defer TimeSpent("ModifySdtIPRole", time.Now())
+	return s.ModifySdtIPRoleCtx(s.client.callContext(), id, ip, role)
+}
+
+// ModifySdtIPRoleCtx modify target IP role for the sdt.
+func (s *System) ModifySdtIPRoleCtx(ctx context.Context, id, ip, role string) error {
+	ctx, span := s.client.startSpan(ctx, "ModifySdtIPRole")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdt::%v/action/modifyIpRole", id)
 
@@ -202,7 +262,7 @@ func (s *System) ModifySdtIPRole(id, ip, role string) error {
 		IP:   ip,
 		Role: role,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, nil)
 	if err != nil {
 		return err
@@ -213,12 +273,18 @@ func (s *System) ModifySdtIPRole(id, ip, role string) error {
 
 // DeleteSdt deletes the sdt
 func (s *System) DeleteSdt(id string) error {
-	defer TimeSpent("DeleteSdt", time.Now())
+	return s.DeleteSdtCtx(s.client.callContext(), id)
+}
+
+// DeleteSdtCtx deletes the sdt
+func (s *System) DeleteSdtCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "DeleteSdt")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdt::%v/action/removeSdt", id)
 
 	param := &types.EmptyPayload{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, param, nil)
 	if err != nil {
 		return err
@@ -228,12 +294,18 @@ func (s *System) DeleteSdt(id string) error {
 
 // EnterSdtMaintenanceMode enter sdt maintenance mode
 func (s *System) EnterSdtMaintenanceMode(id string) error {
-	defer TimeSpent("EnterSdtMaintenanceMode", time.Now())
+	return s.EnterSdtMaintenanceModeCtx(s.client.callContext(), id)
+}
+
+// EnterSdtMaintenanceModeCtx enter sdt maintenance mode
+func (s *System) EnterSdtMaintenanceModeCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "EnterSdtMaintenanceMode")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdt::%v/action/enterMaintenanceMode", id)
 
 	param := &types.EmptyPayload{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, param, nil)
 	if err != nil {
 		return err
@@ -243,12 +315,18 @@ func (s *System) EnterSdtMaintenanceMode(id string) error {
 
 // ExitSdtMaintenanceMode exit sdt maintenance mode
 func (s *System) ExitSdtMaintenanceMode(id string) error {
-	defer TimeSpent("ExitSdtMaintenanceMode", time.Now())
+	return s.ExitSdtMaintenanceModeCtx(s.client.callContext(), id)
+}
+
+// ExitSdtMaintenanceModeCtx exit sdt maintenance mode
+func (s *System) ExitSdtMaintenanceModeCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "ExitSdtMaintenanceMode")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/Sdt::%v/action/exitMaintenanceMode", id)
 
 	param := &types.EmptyPayload{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, param, nil)
 	if err != nil {
 		return err
@@ -13,87 +13,51 @@
 package goscaleio
 
 import (
-	"bytes"
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strconv"
-	"time"
 
-	"github.com/dell/goscaleio/log"
 	types "github.com/dell/goscaleio/types/v1"
 	"github.com/google/uuid"
 )
 
 // DeployService used to deploy service
 func (gc *GatewayClient) DeployService(deploymentName, deploymentDesc, serviceTemplateID, firmwareRepositoryID, nodes string) (*types.ServiceResponse, error) {
-	defer TimeSpent("DeployService", time.Now())
-
-	path := fmt.Sprintf("/Api/V1/FirmwareRepository/%v", firmwareRepositoryID)
-
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
+	return gc.DeployServiceCtx(context.Background(), deploymentName, deploymentDesc, serviceTemplateID, firmwareRepositoryID, nodes)
+}
 
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
+// DeployServiceCtx used to deploy service
+func (gc *GatewayClient) DeployServiceCtx(ctx context.Context, deploymentName, deploymentDesc, serviceTemplateID, firmwareRepositoryID, nodes string) (*types.ServiceResponse, error) {
+	ctx, span := gc.startSpan(ctx, "DeployService")
+	defer span.end()
 
-	req.Header.Set("Content-Type", "application/json")
+	path := fmt.Sprintf("/Api/V1/FirmwareRepository/%v", firmwareRepositoryID)
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	responseString, _ := extractString(httpResp)
-
 	if httpResp.StatusCode == 200 && responseString == "" {
 		return nil, fmt.Errorf("Firmware Repository Not Found")
 	}
 
 	path = fmt.Sprintf("/Api/V1/ServiceTemplate/%v?forDeployment=true", serviceTemplateID)
 
-	req, httpError = http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
+	httpResp, responseString, err = gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	client = gc.http
-	httpResp, httpRespError = client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
-	}
-
-	responseString, _ = extractString(httpResp)
-
 	if httpResp.StatusCode != http.StatusOK || responseString == "" {
 		return nil, fmt.Errorf("Service Template Not Found")
 	}
@@ -124,32 +88,13 @@ func (gc *GatewayClient) DeployService(deploymentName, deploymentDesc, serviceTe
 	}
 
 	deploymentPayloadJSON, _ := json.Marshal(deploymentPayload)
-	req, httpError = http.NewRequest(http.MethodPost, gc.host+"/Api/V1/Deployment", bytes.NewBuffer(deploymentPayloadJSON))
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client = gc.http
-	httpResp, httpRespError = client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
-	}
-
-	responseString, ioErr := extractString(httpResp)
-	if ioErr != nil {
-		return nil, fmt.Errorf("Error Extracting Response: %s", ioErr)
+	httpResp, responseString, err = gc.do(ctx, gatewayRequest{
+		method: http.MethodPost,
+		path:   "/Api/V1/Deployment",
+		body:   deploymentPayloadJSON,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if httpResp.StatusCode != 200 {
@@ -160,7 +105,7 @@ func (gc *GatewayClient) DeployService(deploymentName, deploymentDesc, serviceTe
 		}
 
 		deploymentResponse.StatusCode = 400
-		return nil, fmt.Errorf("Error While Parsing Response Data For Deployment: %s", deploymentResponse.Messages[0].DisplayMessage)
+		return nil, fmt.Errorf("Error While Parsing Response Data For Deployment: %w", serviceError(httpResp, &deploymentResponse))
 	}
 
 	var deploymentResponse types.ServiceResponse
@@ -175,44 +120,31 @@ func (gc *GatewayClient) DeployService(deploymentName, deploymentDesc, serviceTe
 
 // UpdateService updates an existing service in the ScaleIO Gateway.
 func (gc *GatewayClient) UpdateService(deploymentID, deploymentName, deploymentDesc, nodes, nodename string) (*types.ServiceResponse, error) {
-	defer TimeSpent("UpdateService", time.Now())
-
-	path := fmt.Sprintf("/Api/V1/Deployment/%v", deploymentID)
-
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
+	return gc.UpdateServiceCtx(context.Background(), deploymentID, deploymentName, deploymentDesc, nodes, nodename)
+}
 
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
+// UpdateServiceCtx updates an existing service in the ScaleIO Gateway.
+func (gc *GatewayClient) UpdateServiceCtx(ctx context.Context, deploymentID, deploymentName, deploymentDesc, nodes, nodename string) (*types.ServiceResponse, error) {
+	ctx, span := gc.startSpan(ctx, "UpdateService")
+	defer span.end()
 
-	req.Header.Set("Content-Type", "application/json")
+	path := fmt.Sprintf("/Api/V1/Deployment/%v", deploymentID)
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	responseString, _ := extractString(httpResp)
-
 	if httpResp.StatusCode != 200 || responseString == "" {
 		var deploymentResponse types.ServiceFailedResponse
 		parseError := json.Unmarshal([]byte(responseString), &deploymentResponse)
 		if parseError != nil {
 			return nil, fmt.Errorf("Error While Parsing Response Data For Deployment: %s", parseError)
 		}
-		return nil, fmt.Errorf("Error While Parsing Response Data For Deployment: %s", deploymentResponse.Messages[0].DisplayMessage)
+		return nil, fmt.Errorf("Error While Parsing Response Data For Deployment: %w", serviceError(httpResp, &deploymentResponse))
 	}
 
 	var deploymentResponse types.ServiceResponse
@@ -377,32 +309,13 @@ func (gc *GatewayClient) UpdateService(deploymentID, deploymentName, deploymentD
 		return nil, fmt.Errorf("Removing node(s) is not supported")
 	}
 
-	req, httpError = http.NewRequest("PUT", gc.host+"/Api/V1/Deployment/"+deploymentID, bytes.NewBuffer(deploymentPayloadJSON))
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client = gc.http
-	httpResp, httpRespError = client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
-	}
-
-	responseString, ioErr := extractString(httpResp)
-	if ioErr != nil {
-		return nil, fmt.Errorf("Error Extracting Response: %s", ioErr)
+	httpResp, responseString, err = gc.do(ctx, gatewayRequest{
+		method: http.MethodPut,
+		path:   "/Api/V1/Deployment/" + deploymentID,
+		body:   deploymentPayloadJSON,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if httpResp.StatusCode != http.StatusOK {
@@ -412,7 +325,7 @@ func (gc *GatewayClient) UpdateService(deploymentID, deploymentName, deploymentD
 			return nil, fmt.Errorf("Error While Parsing Response Data For Deployment: %s", parseError)
 		}
 		deploymentResponse.StatusCode = 400
-		return nil, fmt.Errorf("Error While Parsing Response Data For Deployment: %s", deploymentResponse.Messages[0].DisplayMessage)
+		return nil, fmt.Errorf("Error While Parsing Response Data For Deployment: %w", serviceError(httpResp, &deploymentResponse))
 	}
 
 	deploymentResponse = types.ServiceResponse{}
@@ -426,90 +339,36 @@ func (gc *GatewayClient) UpdateService(deploymentID, deploymentName, deploymentD
 
 // GetServiceDetailsByID retrieves service details by deployment ID.
 func (gc *GatewayClient) GetServiceDetailsByID(deploymentID string, newToken bool) (*types.ServiceResponse, error) {
-	defer TimeSpent("GetServiceDetailsByID", time.Now())
-
-	if newToken {
-		bodyData := map[string]interface{}{
-			"username": gc.username,
-			"password": gc.password,
-		}
-
-		body, _ := json.Marshal(bodyData)
-
-		req, err := http.NewRequest(http.MethodPost, gc.host+"/rest/auth/login", bytes.NewBuffer(body))
-		if err != nil {
-			return nil, err
-		}
-
-		req.Header.Add("Content-Type", "application/json")
-
-		resp, err := gc.http.Do(req)
-		if err != nil {
-			return nil, err
-		}
+	return gc.GetServiceDetailsByIDCtx(context.Background(), deploymentID, newToken)
+}
 
-		defer func() {
-			if err := resp.Body.Close(); err != nil {
-				log.DoLog(log.Log.Error, err.Error())
-			}
-		}()
-
-		// parse the response
-		switch {
-		case resp == nil:
-			return nil, errNilReponse
-		case !(resp.StatusCode >= 200 && resp.StatusCode <= 299):
-			return nil, ParseJSONError(resp)
-		}
+// GetServiceDetailsByIDCtx retrieves service details by deployment ID.
+func (gc *GatewayClient) GetServiceDetailsByIDCtx(ctx context.Context, deploymentID string, newToken bool) (*types.ServiceResponse, error) {
+	ctx, span := gc.startSpan(ctx, "GetServiceDetailsByID")
+	defer span.end()
 
-		bs, err := io.ReadAll(resp.Body)
+	if newToken {
+		token, err := gc.NewTokenGenerationCtx(ctx)
 		if err != nil {
 			return nil, err
 		}
-
-		responseBody := string(bs)
-		result := make(map[string]interface{})
-		jsonErr := json.Unmarshal([]byte(responseBody), &result)
-		if err != nil {
-			return nil, fmt.Errorf("Error For Uploading Package: %s", jsonErr)
-		}
-
-		token := result["access_token"].(string)
-		gc.token = token
+		gc.setToken(token)
 	}
 
 	path := fmt.Sprintf("/Api/V1/Deployment/%v", deploymentID)
 
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 	if httpResp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("Couldn't find service with the given filter")
 	}
 
 	var deploymentResponse types.ServiceResponse
-	responseString, _ := extractString(httpResp)
 	parseError := json.Unmarshal([]byte(responseString), &deploymentResponse)
 	if parseError != nil {
 		return nil, fmt.Errorf("Error While Parsing Response Data For Deployment: %s", parseError)
@@ -519,41 +378,29 @@ func (gc *GatewayClient) GetServiceDetailsByID(deploymentID string, newToken boo
 
 // GetServiceDetailsByFilter retrieves service details based on a filter and value.
 func (gc *GatewayClient) GetServiceDetailsByFilter(filter, value string) ([]types.ServiceResponse, error) {
-	defer TimeSpent("GetServiceDetailsByFilter", time.Now())
+	return gc.GetServiceDetailsByFilterCtx(context.Background(), filter, value)
+}
+
+// GetServiceDetailsByFilterCtx retrieves service details based on a filter and value.
+func (gc *GatewayClient) GetServiceDetailsByFilterCtx(ctx context.Context, filter, value string) ([]types.ServiceResponse, error) {
+	ctx, span := gc.startSpan(ctx, "GetServiceDetailsByFilter")
+	defer span.end()
 
 	encodedValue := url.QueryEscape(value)
 	path := fmt.Sprintf("/Api/V1/Deployment?filter=eq,%v,%v", filter, encodedValue)
 
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 	if httpResp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("Couldn't find service with the given filter")
 	}
 
 	var deploymentResponse []types.ServiceResponse
-	responseString, _ := extractString(httpResp)
 	parseError := json.Unmarshal([]byte(responseString), &deploymentResponse)
 	if parseError != nil {
 		return nil, fmt.Errorf("Error While Parsing Response Data For Deployment: %s", parseError)
@@ -567,37 +414,26 @@ func (gc *GatewayClient) GetServiceDetailsByFilter(filter, value string) ([]type
 
 // GetAllServiceDetails retrieves all service details from the GatewayClient.
 func (gc *GatewayClient) GetAllServiceDetails() ([]types.ServiceResponse, error) {
-	defer TimeSpent("DeploGetServiceDetailsByIDyService", time.Now())
-
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+"/Api/V1/Deployment/", nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
+	return gc.GetAllServiceDetailsCtx(context.Background())
+}
 
-	req.Header.Set("Content-Type", "application/json")
+// GetAllServiceDetailsCtx retrieves all service details from the GatewayClient.
+func (gc *GatewayClient) GetAllServiceDetailsCtx(ctx context.Context) ([]types.ServiceResponse, error) {
+	ctx, span := gc.startSpan(ctx, "DeploGetServiceDetailsByIDyService")
+	defer span.end()
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   "/Api/V1/Deployment/",
+	})
+	if err != nil {
+		return nil, err
 	}
 	if httpResp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("Couldn't find service with the given filter")
 	}
 
 	var deploymentResponse []types.ServiceResponse
-	responseString, _ := extractString(httpResp)
 	parseError := json.Unmarshal([]byte(responseString), &deploymentResponse)
 	if parseError != nil {
 		return nil, fmt.Errorf("Error While Parsing Response Data For Deployment: %s", parseError)
@@ -607,34 +443,24 @@ func (gc *GatewayClient) GetAllServiceDetails() ([]types.ServiceResponse, error)
 
 // DeleteService deletes a service by its ID, along with servers in inventory and managed state.
 func (gc *GatewayClient) DeleteService(serviceID, serversInInventory, serversManagedState string) (*types.ServiceResponse, error) {
+	return gc.DeleteServiceCtx(context.Background(), serviceID, serversInInventory, serversManagedState)
+}
+
+// DeleteServiceCtx deletes a service by its ID, along with servers in inventory and managed state.
+func (gc *GatewayClient) DeleteServiceCtx(ctx context.Context, serviceID, serversInInventory, serversManagedState string) (*types.ServiceResponse, error) {
 	var deploymentResponse types.ServiceResponse
 
 	deploymentResponse.StatusCode = 400
 
-	defer TimeSpent("DeleteService", time.Now())
-
-	req, httpError := http.NewRequest("DELETE", gc.host+"/Api/V1/Deployment/"+serviceID+"?serversInInventory="+serversInInventory+"&serversManagedState="+serversManagedState, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
+	ctx, span := gc.startSpan(ctx, "DeleteService")
+	defer span.end()
 
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, _, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodDelete,
+		path:   "/Api/V1/Deployment/" + serviceID + "?serversInInventory=" + serversInInventory + "&serversManagedState=" + serversManagedState,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if httpResp.StatusCode == 204 {
@@ -646,40 +472,28 @@ func (gc *GatewayClient) DeleteService(serviceID, serversInInventory, serversMan
 
 // GetServiceComplianceDetails retrieves service compliance details for a given deployment.
 func (gc *GatewayClient) GetServiceComplianceDetails(deploymentID string) ([]types.ComplianceReport, error) {
-	defer TimeSpent("GetServiceComplianceDetails", time.Now())
-
-	path := fmt.Sprintf("/Api/V1/Deployment/%v/firmware/compliancereport", deploymentID)
-
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error while handling cookie: %s", err)
-		}
+	return gc.GetServiceComplianceDetailsCtx(context.Background(), deploymentID)
+}
 
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
+// GetServiceComplianceDetailsCtx retrieves service compliance details for a given deployment.
+func (gc *GatewayClient) GetServiceComplianceDetailsCtx(ctx context.Context, deploymentID string) ([]types.ComplianceReport, error) {
+	ctx, span := gc.startSpan(ctx, "GetServiceComplianceDetails")
+	defer span.end()
 
-	req.Header.Set("Content-Type", "application/json")
+	path := fmt.Sprintf("/Api/V1/Deployment/%v/firmware/compliancereport", deploymentID)
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 	if httpResp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("Couldn't find compliance report for given deployment")
 	}
 
 	var complianceReports []types.ComplianceReport
-	responseString, _ := extractString(httpResp)
 	parseError := json.Unmarshal([]byte(responseString), &complianceReports)
 	if parseError != nil {
 		return nil, fmt.Errorf("Error while parsing response data for compliance report: %s", parseError)
@@ -693,9 +507,15 @@ func (gc *GatewayClient) GetServiceComplianceDetails(deploymentID string) ([]typ
 
 // GetServiceComplianceDetailsByFilter retrieves service compliance details based on a filter and value.
 func (gc *GatewayClient) GetServiceComplianceDetailsByFilter(deploymentID, filter, value string) ([]types.ComplianceReport, error) {
-	defer TimeSpent("GetServiceComplianceDetailsByFilter", time.Now())
+	return gc.GetServiceComplianceDetailsByFilterCtx(context.Background(), deploymentID, filter, value)
+}
+
+// GetServiceComplianceDetailsByFilterCtx retrieves service compliance details based on a filter and value.
+func (gc *GatewayClient) GetServiceComplianceDetailsByFilterCtx(ctx context.Context, deploymentID, filter, value string) ([]types.ComplianceReport, error) {
+	ctx, span := gc.startSpan(ctx, "GetServiceComplianceDetailsByFilter")
+	defer span.end()
 
-	complianceReports, err := gc.GetServiceComplianceDetails(deploymentID)
+	complianceReports, err := gc.GetServiceComplianceDetailsCtx(ctx, deploymentID)
 	if err != nil || len(complianceReports) == 0 {
 		return nil, fmt.Errorf("Couldn't find compliance report for the given deployment")
 	}
@@ -0,0 +1,264 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Logout ends the session of the client on the array.
+func (c *Client) Logout() error {
+	return c.LogoutCtx(c.callContext())
+}
+
+// LogoutCtx ends the session of the client on the array, and removes its
+// token from the token cache. On the PowerFlex Manager platform, the refresh
+// token of the session is revoked. A session that has already expired is not
+// an error. The client logs in again if it is used afterwards.
+func (c *Client) LogoutCtx(ctx context.Context) error {
+	ctx, span := c.startSpan(ctx, "Logout")
+	defer span.end()
+
+	token := c.api.GetToken()
+	if token == "" {
+		return nil
+	}
+	var err error
+	if refresh := c.tokens.refreshToken(); refresh != "" {
+		body := map[string]string{"refresh_token": refresh}
+		err = c.api.DoWithHeaders(ctx, http.MethodPost, "/rest/auth/logout", nil, body, nil, c.version())
+	} else {
+		err = c.api.DoWithHeaders(ctx, http.MethodGet, "/api/logout", nil, nil, nil, c.version())
+	}
+	if err != nil && !errors.Is(err, ErrUnauthorized) {
+		return err
+	}
+
+	c.api.SetToken("")
+	if c.tokens != nil {
+		c.tokens.tokenIssued(time.Time{})
+		c.tokens.setRefreshToken("")
+	}
+	c.forgetToken(ctx, token)
+	return nil
+}
+
+// Close ends the session of the client. It is safe to defer and to call
+// more than once.
+func (c *Client) Close() error {
+	return c.Logout()
+}
+
+// gatewaySession holds the tokens issued by /rest/auth on the PowerFlex
+// Manager platform.
+type gatewaySession struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// errNoRESTAuth is returned when the array does not serve /rest/auth.
+var errNoRESTAuth = errors.New("/rest/auth is not available")
+
+// restAuth reports whether the client logs in with /rest/auth, as on the
+// PowerFlex Manager platform, rather than with api/login. The version must
+// be known beforehand, and arrays found not to serve /rest/auth keep using
+// api/login.
+func (c *Client) restAuth() bool {
+	caps, err := NewCapabilities(c.version())
+	return err == nil && caps.Supports(FeatureManagementPlatform) && c.tokens.restAuthAvailable()
+}
+
+// newSession logs in to /rest/auth/login with credentials and makes the
+// tokens of the new session those of the client.
+func (c *Client) newSession(ctx context.Context, credentials Credentials) error {
+	session, err := c.postSession(ctx, "/rest/auth/login", map[string]string{
+		"username": credentials.Username,
+		"password": credentials.Password,
+	})
+	if err != nil {
+		if !errors.Is(err, errNoRESTAuth) {
+			c.logger.Error(err.Error())
+		}
+		return err
+	}
+	c.api.SetToken(session.AccessToken)
+	c.tokens.setRefreshToken(session.RefreshToken)
+	return nil
+}
+
+// refreshSession exchanges refresh for a new access token at
+// /rest/auth/refresh. The refresh token is kept unless a new one is issued.
+func (c *Client) refreshSession(ctx context.Context, refresh string) error {
+	session, err := c.postSession(ctx, "/rest/auth/refresh", map[string]string{"refresh_token": refresh})
+	if err != nil {
+		return err
+	}
+	if session.RefreshToken == "" {
+		session.RefreshToken = refresh
+	}
+	c.api.SetToken(session.AccessToken)
+	c.tokens.setRefreshToken(session.RefreshToken)
+	return nil
+}
+
+// postSession posts body to one of the /rest/auth endpoints that issue
+// tokens and returns them.
+func (c *Client) postSession(ctx context.Context, path string, body map[string]string) (gatewaySession, error) {
+	resp, err := c.api.DoAndGetResponseBody(ctx, http.MethodPost, path, nil, body, c.version())
+	if err != nil {
+		return gatewaySession{}, err
+	}
+	defer c.closeBody(resp)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed:
+		return gatewaySession{}, errNoRESTAuth
+	case !(resp.StatusCode >= 200 && resp.StatusCode <= 299):
+		return gatewaySession{}, c.api.ParseJSONError(resp)
+	}
+	var session gatewaySession
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return gatewaySession{}, err
+	}
+	if session.AccessToken == "" {
+		return gatewaySession{}, errors.New("no access token in the response")
+	}
+	return session, nil
+}
+
+// Logout ends the session of the gateway client.
+func (gc *GatewayClient) Logout() error {
+	return gc.LogoutCtx(context.Background())
+}
+
+// LogoutCtx ends the session of the gateway client on the PowerFlex Manager
+// platform, revoking its refresh token. Gateways authenticated with basic
+// auth have no session to end. A session that has already expired is not an
+// error.
+func (gc *GatewayClient) LogoutCtx(ctx context.Context) error {
+	ctx, span := gc.startSpan(ctx, "Logout")
+	defer span.end()
+
+	// wait for a refresh in progress, whose session would be left open
+	gc.loginMu.Lock()
+	defer gc.loginMu.Unlock()
+
+	token, refresh := gc.getSession()
+	if token == "" {
+		return nil
+	}
+
+	body, _ := json.Marshal(map[string]string{"refresh_token": refresh})
+	res, err := gc.send(ctx, gatewayRequest{
+		method:   http.MethodPost,
+		path:     "/rest/auth/logout",
+		body:     body,
+		auth:     authToken,
+		noCookie: true,
+	}, token)
+	if err != nil {
+		return err
+	}
+	defer gc.closeBody(res)
+
+	if res.StatusCode != http.StatusUnauthorized && !(res.StatusCode >= 200 && res.StatusCode <= 299) {
+		responseString, err := extractString(res)
+		if err != nil {
+			return fmt.Errorf("Error Extracting Response: %w", err)
+		}
+		return gatewayError(res, responseString)
+	}
+
+	gc.setSession(gatewaySession{})
+	return nil
+}
+
+// Close ends the session of the gateway client and closes its idle
+// connections. It is safe to defer and to call more than once.
+func (gc *GatewayClient) Close() error {
+	err := gc.Logout()
+	gc.http.CloseIdleConnections()
+	return err
+}
+
+// login opens a new session with the credentials of gc.
+func (gc *GatewayClient) login(ctx context.Context) error {
+	session, err := gc.newSession(ctx)
+	if err != nil {
+		return err
+	}
+	gc.setSession(session)
+	return nil
+}
+
+// renew replaces an expired access token, with the refresh token when there
+// is one and by logging in again otherwise.
+func (gc *GatewayClient) renew(ctx context.Context) error {
+	if _, refresh := gc.getSession(); refresh != "" {
+		session, err := gc.refreshSession(ctx, refresh)
+		if err == nil {
+			gc.setSession(session)
+			return nil
+		}
+		gc.logger.Debug(fmt.Sprintf("unable to refresh the session, logging in again: %s", err))
+	}
+	return gc.login(ctx)
+}
+
+// refreshSession exchanges refresh for a new access token at
+// /rest/auth/refresh. The refresh token is kept unless a new one is issued.
+func (gc *GatewayClient) refreshSession(ctx context.Context, refresh string) (gatewaySession, error) {
+	body, _ := json.Marshal(map[string]string{"refresh_token": refresh})
+	res, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodPost,
+		path:   "/rest/auth/refresh",
+		body:   body,
+		auth:   authNone,
+	})
+	if err != nil {
+		return gatewaySession{}, err
+	}
+	if !(res.StatusCode >= 200 && res.StatusCode <= 299) {
+		return gatewaySession{}, gatewayError(res, responseString)
+	}
+
+	var session gatewaySession
+	if err := json.Unmarshal([]byte(responseString), &session); err != nil {
+		return gatewaySession{}, err
+	}
+	if session.AccessToken == "" {
+		return gatewaySession{}, errors.New("no access token in the response")
+	}
+	if session.RefreshToken == "" {
+		session.RefreshToken = refresh
+	}
+	return session, nil
+}
+
+func (gc *GatewayClient) getSession() (token, refresh string) {
+	gc.tokenMu.RLock()
+	defer gc.tokenMu.RUnlock()
+	return gc.token, gc.refresh
+}
+
+func (gc *GatewayClient) setSession(session gatewaySession) {
+	gc.tokenMu.Lock()
+	defer gc.tokenMu.Unlock()
+	gc.token = session.AccessToken
+	gc.refresh = session.RefreshToken
+}
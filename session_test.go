@@ -0,0 +1,226 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dell/goscaleio/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientLogout(t *testing.T) {
+	var logouts []string
+	status := http.StatusOK
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			w.Write([]byte(`"token"`))
+		case "/api/logout":
+			_, token, _ := r.BasicAuth()
+			logouts = append(logouts, token)
+			w.WriteHeader(status)
+			if status != http.StatusOK {
+				fmt.Fprintf(w, `{"message":"%s","httpStatusCode":%d,"errorCode":0}`, http.StatusText(status), status)
+			}
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.Close())
+	assert.Equal(t, []string{"token"}, logouts)
+	assert.Empty(t, client.GetToken())
+	// nothing is left to end
+	assert.NoError(t, client.Close())
+	assert.Len(t, logouts, 1)
+
+	// the session expired already
+	status = http.StatusUnauthorized
+	_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+	assert.NoError(t, err)
+	assert.NoError(t, client.Logout())
+	assert.Empty(t, client.GetToken())
+
+	status = http.StatusInternalServerError
+	_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+	assert.NoError(t, err)
+	assert.Error(t, client.Logout())
+	assert.Equal(t, "token", client.GetToken())
+}
+
+// sessionGateway issues access and refresh tokens like the /rest/auth
+// endpoints of the PowerFlex Manager platform.
+type sessionGateway struct {
+	mu        sync.Mutex
+	logins    int
+	refreshes int
+	access    string
+	refresh   map[string]bool
+	logouts   []string
+}
+
+func (g *sessionGateway) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		var body struct {
+			RefreshToken string `json:"refresh_token"`
+		}
+		switch r.URL.Path {
+		case "/rest/auth/login":
+			g.logins++
+			g.access = fmt.Sprintf("access-%d", g.logins)
+			refresh := fmt.Sprintf("refresh-%d", g.logins)
+			g.refresh[refresh] = true
+			fmt.Fprintf(w, `{"access_token":"%s","refresh_token":"%s"}`, g.access, refresh)
+		case "/rest/auth/refresh":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if !g.refresh[body.RefreshToken] {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			g.refreshes++
+			g.access = fmt.Sprintf("refreshed-%d", g.refreshes)
+			fmt.Fprintf(w, `{"access_token":"%s"}`, g.access)
+		case "/rest/auth/logout":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "Bearer "+g.access, r.Header.Get("Authorization"))
+			delete(g.refresh, body.RefreshToken)
+			g.logouts = append(g.logouts, body.RefreshToken)
+			w.WriteHeader(http.StatusNoContent)
+		case "/api/version":
+			w.Write([]byte("4.5"))
+		case "/Api/V1/ManagedDevice", "/api/types/System/instances":
+			if g.access == "" || strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") != g.access {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"Unauthorized","httpStatusCode":401,"errorCode":0}`))
+				return
+			}
+			w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	})
+}
+
+// expire revokes the access token, and the refresh tokens too if all is set.
+func (g *sessionGateway) expire(all bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.access = ""
+	if all {
+		g.refresh = map[string]bool{}
+	}
+}
+
+func TestGatewaySession(t *testing.T) {
+	g := &sessionGateway{refresh: map[string]bool{}}
+	ts := httptest.NewServer(g.handler(t))
+	defer ts.Close()
+
+	gc, err := NewGatewayWithOptions(context.Background(), ts.URL, "admin", "password", api.ClientOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, g.logins)
+
+	// an expired access token is refreshed without logging in again
+	g.expire(false)
+	_, err = gc.GetAllNodes()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, g.logins)
+	assert.Equal(t, 1, g.refreshes)
+	assert.Equal(t, "refreshed-1", gc.getToken())
+
+	// a revoked refresh token falls back to the credentials
+	g.expire(true)
+	_, err = gc.GetAllNodes()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, g.logins)
+	assert.Equal(t, "access-2", gc.getToken())
+
+	assert.NoError(t, gc.Close())
+	assert.Equal(t, []string{"refresh-2"}, g.logouts)
+	assert.Empty(t, g.refresh)
+	token, refresh := gc.getSession()
+	assert.Empty(t, token)
+	assert.Empty(t, refresh)
+	assert.NoError(t, gc.Close())
+	assert.Len(t, g.logouts, 1)
+}
+
+func TestClientSession(t *testing.T) {
+	g := &sessionGateway{refresh: map[string]bool{}}
+	ts := httptest.NewServer(g.handler(t))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "4.5", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, g.logins)
+	assert.Equal(t, "access-1", client.GetToken())
+
+	// an expired access token is refreshed without logging in again
+	g.expire(false)
+	_, err = client.GetSystems()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, g.logins)
+	assert.Equal(t, 1, g.refreshes)
+	assert.Equal(t, "refreshed-1", client.GetToken())
+
+	// a revoked refresh token falls back to the credentials
+	g.expire(true)
+	_, err = client.GetSystems()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, g.logins)
+	assert.Equal(t, "access-2", client.GetToken())
+
+	assert.NoError(t, client.Close())
+	assert.Equal(t, []string{"refresh-2"}, g.logouts)
+	assert.Empty(t, g.refresh)
+	assert.Empty(t, client.GetToken())
+	assert.NoError(t, client.Close())
+	assert.Len(t, g.logouts, 1)
+}
+
+func TestGatewayLogoutBasicAuth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/auth/login":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/version":
+			w.Write([]byte("3.6"))
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	gc, err := NewGateway(ts.URL, "admin", "password", false, false)
+	assert.NoError(t, err)
+	assert.NoError(t, gc.Logout())
+}
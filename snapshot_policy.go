@@ -13,20 +13,26 @@
 package goscaleio
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // CreateSnapshotPolicy creates a snapshot policy on the PowerFlex array
 func (s *System) CreateSnapshotPolicy(snapPolicy *types.SnapshotPolicyCreateParam) (string, error) {
-	defer TimeSpent("crate snapshot policy", time.Now())
+	return s.CreateSnapshotPolicyCtx(s.client.callContext(), snapPolicy)
+}
+
+// CreateSnapshotPolicyCtx creates a snapshot policy on the PowerFlex array
+func (s *System) CreateSnapshotPolicyCtx(ctx context.Context, snapPolicy *types.SnapshotPolicyCreateParam) (string, error) {
+	ctx, span := s.client.startSpan(ctx, "crate snapshot policy")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/types/SnapshotPolicy/instances")
 	snapResp := types.SnapShotPolicyCreateResp{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, snapPolicy, &snapResp)
 	if err != nil {
 		return "", err
@@ -36,9 +42,17 @@ func (s *System) CreateSnapshotPolicy(snapPolicy *types.SnapshotPolicyCreatePara
 
 // RemoveSnapshotPolicy removes a snapshot policy from the PowerFlex array
 func (s *System) RemoveSnapshotPolicy(id string) error {
+	return s.RemoveSnapshotPolicyCtx(s.client.callContext(), id)
+}
+
+// RemoveSnapshotPolicyCtx removes a snapshot policy from the PowerFlex array
+func (s *System) RemoveSnapshotPolicyCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "RemoveSnapshotPolicy")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/SnapshotPolicy::%v/action/removeSnapshotPolicy", id)
 	removeParam := &types.EmptyPayload{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, removeParam, nil)
 	if err != nil {
 		return err
@@ -48,11 +62,19 @@ func (s *System) RemoveSnapshotPolicy(id string) error {
 
 // RenameSnapshotPolicy renames a snapshot policy
 func (s *System) RenameSnapshotPolicy(id, name string) error {
+	return s.RenameSnapshotPolicyCtx(s.client.callContext(), id, name)
+}
+
+// RenameSnapshotPolicyCtx renames a snapshot policy
+func (s *System) RenameSnapshotPolicyCtx(ctx context.Context, id, name string) error {
+	ctx, span := s.client.startSpan(ctx, "RenameSnapshotPolicy")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/SnapshotPolicy::%v/action/renameSnapshotPolicy", id)
 	renameSnap := &types.SnapshotPolicyRenameParam{
 		NewName: name,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, renameSnap, nil)
 	if err != nil {
 		return err
@@ -62,8 +84,16 @@ func (s *System) RenameSnapshotPolicy(id, name string) error {
 
 // ModifySnapshotPolicy modifies a snapshot policy
 func (s *System) ModifySnapshotPolicy(modifysnapPolicy *types.SnapshotPolicyModifyParam, id string) error {
+	return s.ModifySnapshotPolicyCtx(s.client.callContext(), modifysnapPolicy, id)
+}
+
+// ModifySnapshotPolicyCtx modifies a snapshot policy
+func (s *System) ModifySnapshotPolicyCtx(ctx context.Context, modifysnapPolicy *types.SnapshotPolicyModifyParam, id string) error {
+	ctx, span := s.client.startSpan(ctx, "ModifySnapshotPolicy")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/SnapshotPolicy::%v/action/modifySnapshotPolicy", id)
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, modifysnapPolicy, nil)
 	if err != nil {
 		return err
@@ -73,8 +103,16 @@ func (s *System) ModifySnapshotPolicy(modifysnapPolicy *types.SnapshotPolicyModi
 
 // AssignVolumeToSnapshotPolicy assigns volume to a snapshot policy
 func (s *System) AssignVolumeToSnapshotPolicy(assignVoltoSnap *types.AssignVolumeToSnapshotPolicyParam, id string) error {
+	return s.AssignVolumeToSnapshotPolicyCtx(s.client.callContext(), assignVoltoSnap, id)
+}
+
+// AssignVolumeToSnapshotPolicyCtx assigns volume to a snapshot policy
+func (s *System) AssignVolumeToSnapshotPolicyCtx(ctx context.Context, assignVoltoSnap *types.AssignVolumeToSnapshotPolicyParam, id string) error {
+	ctx, span := s.client.startSpan(ctx, "AssignVolumeToSnapshotPolicy")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/SnapshotPolicy::%v/action/addSourceVolumeToSnapshotPolicy", id)
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, assignVoltoSnap, nil)
 	if err != nil {
 		return err
@@ -84,8 +122,16 @@ func (s *System) AssignVolumeToSnapshotPolicy(assignVoltoSnap *types.AssignVolum
 
 // UnassignVolumeFromSnapshotPolicy unassigns volume from a snapshot policy
 func (s *System) UnassignVolumeFromSnapshotPolicy(UnassignVolFromSnap *types.AssignVolumeToSnapshotPolicyParam, id string) error {
+	return s.UnassignVolumeFromSnapshotPolicyCtx(s.client.callContext(), UnassignVolFromSnap, id)
+}
+
+// UnassignVolumeFromSnapshotPolicyCtx unassigns volume from a snapshot policy
+func (s *System) UnassignVolumeFromSnapshotPolicyCtx(ctx context.Context, UnassignVolFromSnap *types.AssignVolumeToSnapshotPolicyParam, id string) error {
+	ctx, span := s.client.startSpan(ctx, "UnassignVolumeFromSnapshotPolicy")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/SnapshotPolicy::%v/action/removeSourceVolumeFromSnapshotPolicy", id)
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, UnassignVolFromSnap, nil)
 	if err != nil {
 		return err
@@ -95,9 +141,17 @@ func (s *System) UnassignVolumeFromSnapshotPolicy(UnassignVolFromSnap *types.Ass
 
 // PauseSnapshotPolicy pause a snapshot policy
 func (s *System) PauseSnapshotPolicy(id string) error {
+	return s.PauseSnapshotPolicyCtx(s.client.callContext(), id)
+}
+
+// PauseSnapshotPolicyCtx pause a snapshot policy
+func (s *System) PauseSnapshotPolicyCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "PauseSnapshotPolicy")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/SnapshotPolicy::%v/action/pauseSnapshotPolicy", id)
 	pauseParam := &types.EmptyPayload{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, pauseParam, nil)
 	if err != nil {
 		return err
@@ -107,9 +161,17 @@ func (s *System) PauseSnapshotPolicy(id string) error {
 
 // ResumeSnapshotPolicy resume a snapshot policy which was paused
 func (s *System) ResumeSnapshotPolicy(id string) error {
+	return s.ResumeSnapshotPolicyCtx(s.client.callContext(), id)
+}
+
+// ResumeSnapshotPolicyCtx resume a snapshot policy which was paused
+func (s *System) ResumeSnapshotPolicyCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "ResumeSnapshotPolicy")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/SnapshotPolicy::%v/action/resumeSnapshotPolicy", id)
 	resumeParam := &types.EmptyPayload{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, resumeParam, nil)
 	if err != nil {
 		return err
@@ -119,9 +181,17 @@ func (s *System) ResumeSnapshotPolicy(id string) error {
 
 // GetSourceVolume returns a list of volumes assigned to snapshot policy
 func (s *System) GetSourceVolume(id string) ([]*types.Volume, error) {
+	return s.GetSourceVolumeCtx(s.client.callContext(), id)
+}
+
+// GetSourceVolumeCtx returns a list of volumes assigned to snapshot policy
+func (s *System) GetSourceVolumeCtx(ctx context.Context, id string) ([]*types.Volume, error) {
+	ctx, span := s.client.startSpan(ctx, "GetSourceVolume")
+	defer span.end()
+
 	var volumes []*types.Volume
 	path := fmt.Sprintf("/api/instances/SnapshotPolicy::%v/relationships/SourceVolume", id)
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &volumes)
 	if err != nil {
 		return nil, err
@@ -13,6 +13,7 @@
 package goscaleio
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -22,9 +23,17 @@ import (
 
 // GetSSOUser retrieves the details of an SSO user by their ID.
 func (c *Client) GetSSOUser(userID string) (*types.SSOUserDetails, error) {
+	return c.GetSSOUserCtx(c.callContext(), userID)
+}
+
+// GetSSOUserCtx retrieves the details of an SSO user by their ID.
+func (c *Client) GetSSOUserCtx(ctx context.Context, userID string) (*types.SSOUserDetails, error) {
+	ctx, span := c.startSpan(ctx, "GetSSOUser")
+	defer span.end()
+
 	path := fmt.Sprintf("/rest/v1/users/%s", userID)
 	user := &types.SSOUserDetails{}
-	err := c.getJSONWithRetry(http.MethodGet, path, nil, &user)
+	err := c.getJSONWithRetry(ctx, http.MethodGet, path, nil, &user)
 	if err != nil {
 		return nil, err
 	}
@@ -34,10 +43,18 @@ func (c *Client) GetSSOUser(userID string) (*types.SSOUserDetails, error) {
 
 // GetSSOUserByFilters retrieves the details of an SSO user by filter.
 func (c *Client) GetSSOUserByFilters(key string, value string) (*types.SSOUserList, error) {
+	return c.GetSSOUserByFiltersCtx(c.callContext(), key, value)
+}
+
+// GetSSOUserByFiltersCtx retrieves the details of an SSO user by filter.
+func (c *Client) GetSSOUserByFiltersCtx(ctx context.Context, key string, value string) (*types.SSOUserList, error) {
+	ctx, span := c.startSpan(ctx, "GetSSOUserByFilters")
+	defer span.end()
+
 	encodedValue := url.QueryEscape(value)
 	path := `/rest/v1/users?filter=` + key + `%20eq%20%22` + encodedValue + `%22`
 	users := &types.SSOUserList{}
-	err := c.getJSONWithRetry(http.MethodGet, path, nil, &users)
+	err := c.getJSONWithRetry(ctx, http.MethodGet, path, nil, &users)
 	if err != nil {
 		return nil, err
 	}
@@ -46,8 +63,16 @@ func (c *Client) GetSSOUserByFilters(key string, value string) (*types.SSOUserLi
 
 // CreateSSOUser creates a new SSO user with the given parameters.
 func (c *Client) CreateSSOUser(userParam *types.SSOUserCreateParam) (*types.SSOUserDetails, error) {
+	return c.CreateSSOUserCtx(c.callContext(), userParam)
+}
+
+// CreateSSOUserCtx creates a new SSO user with the given parameters.
+func (c *Client) CreateSSOUserCtx(ctx context.Context, userParam *types.SSOUserCreateParam) (*types.SSOUserDetails, error) {
+	ctx, span := c.startSpan(ctx, "CreateSSOUser")
+	defer span.end()
+
 	userResp := &types.SSOUserDetails{}
-	err := c.getJSONWithRetry(http.MethodPost, "/rest/v1/users", userParam, &userResp)
+	err := c.getJSONWithRetry(ctx, http.MethodPost, "/rest/v1/users", userParam, &userResp)
 	if err != nil {
 		return nil, err
 	}
@@ -56,18 +81,34 @@ func (c *Client) CreateSSOUser(userParam *types.SSOUserCreateParam) (*types.SSOU
 
 // ModifySSOUser modifies the details of an SSO user by their ID.
 func (c *Client) ModifySSOUser(userID string, userParam *types.SSOUserModifyParam) (*types.SSOUserDetails, error) {
+	return c.ModifySSOUserCtx(c.callContext(), userID, userParam)
+}
+
+// ModifySSOUserCtx modifies the details of an SSO user by their ID.
+func (c *Client) ModifySSOUserCtx(ctx context.Context, userID string, userParam *types.SSOUserModifyParam) (*types.SSOUserDetails, error) {
+	ctx, span := c.startSpan(ctx, "ModifySSOUser")
+	defer span.end()
+
 	path := fmt.Sprintf("/rest/v1/users/%s", userID)
-	err := c.getJSONWithRetry(http.MethodPatch, path, userParam, nil)
+	err := c.getJSONWithRetry(ctx, http.MethodPatch, path, userParam, nil)
 	if err != nil {
 		return nil, err
 	}
-	return c.GetSSOUser(userID)
+	return c.GetSSOUserCtx(ctx, userID)
 }
 
 // ResetSSOUserPassword resets the password of an SSO user by their ID.
 func (c *Client) ResetSSOUserPassword(userID string, userParam *types.SSOUserModifyParam) error {
+	return c.ResetSSOUserPasswordCtx(c.callContext(), userID, userParam)
+}
+
+// ResetSSOUserPasswordCtx resets the password of an SSO user by their ID.
+func (c *Client) ResetSSOUserPasswordCtx(ctx context.Context, userID string, userParam *types.SSOUserModifyParam) error {
+	ctx, span := c.startSpan(ctx, "ResetSSOUserPassword")
+	defer span.end()
+
 	path := fmt.Sprintf("/rest/v1/users/%s/reset-password", userID)
-	err := c.getJSONWithRetry(http.MethodPost, path, userParam, nil)
+	err := c.getJSONWithRetry(ctx, http.MethodPost, path, userParam, nil)
 	if err != nil {
 		return err
 	}
@@ -76,8 +117,16 @@ func (c *Client) ResetSSOUserPassword(userID string, userParam *types.SSOUserMod
 
 // DeleteSSOUser deletes an SSO user by their ID.
 func (c *Client) DeleteSSOUser(userID string) error {
+	return c.DeleteSSOUserCtx(c.callContext(), userID)
+}
+
+// DeleteSSOUserCtx deletes an SSO user by their ID.
+func (c *Client) DeleteSSOUserCtx(ctx context.Context, userID string) error {
+	ctx, span := c.startSpan(ctx, "DeleteSSOUser")
+	defer span.end()
+
 	path := fmt.Sprintf("/rest/v1/users/%s", userID)
-	err := c.getJSONWithRetry(http.MethodDelete, path, nil, nil)
+	err := c.getJSONWithRetry(ctx, http.MethodDelete, path, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,164 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	types "github.com/dell/goscaleio/types/v1"
+)
+
+// The statistics fetched for each object type by getters such as
+// System.GetVolumeStatisticsByIDs.
+var (
+	volumeStatisticsProperties = []string{
+		"userDataReadBwc", "userDataWriteBwc", "userDataTrimBwc",
+		"userDataSdcReadLatency", "userDataSdcWriteLatency", "userDataSdcTrimLatency",
+		"mappedSdcIds", "numOfMappedSdcs",
+	}
+	sdcStatisticsProperties = []string{
+		"userDataReadBwc", "userDataWriteBwc", "userDataTrimBwc",
+		"userDataSdcReadLatency", "userDataSdcWriteLatency", "userDataSdcTrimLatency",
+		"volumeIds", "numOfMappedVolumes",
+	}
+	sdsStatisticsProperties = []string{
+		"capacityInUseInKb", "maxCapacityInKb", "thickCapacityInUseInKb",
+		"thinCapacityInUseInKb", "snapCapacityInUseInKb", "unreachableUnusedCapacityInKb",
+		"rmcacheSizeInKb", "numOfDevices",
+		"primaryReadBwc", "primaryWriteBwc", "secondaryReadBwc", "secondaryWriteBwc",
+		"totalReadBwc", "totalWriteBwc", "rebalanceReadBwc", "rebalanceWriteBwc",
+		"fwdRebuildReadBwc", "fwdRebuildWriteBwc", "bckRebuildReadBwc", "bckRebuildWriteBwc",
+	}
+	storagePoolStatisticsProperties = []string{
+		"capacityInUseInKb", "maxCapacityInKb", "thickCapacityInUseInKb",
+		"thinCapacityInUseInKb", "snapCapacityInUseInKb", "spareCapacityInKb",
+		"capacityAvailableForVolumeAllocationInKb", "netUserDataCapacityInKb",
+		"netUnusedCapacityInKb", "numOfVolumes", "numOfSnapshots", "numOfDevices",
+		"primaryReadBwc", "primaryWriteBwc", "secondaryReadBwc", "secondaryWriteBwc",
+		"totalReadBwc", "totalWriteBwc", "rebalanceReadBwc", "rebalanceWriteBwc",
+		"fwdRebuildReadBwc", "fwdRebuildWriteBwc", "bckRebuildReadBwc", "bckRebuildWriteBwc",
+	}
+)
+
+// SelectedStatistics holds the statistics returned by
+// System.QuerySelectedStatistics.
+type SelectedStatistics struct {
+	byType map[string]json.RawMessage
+}
+
+// QuerySelectedStatistics returns the given statistics of many objects in
+// one request. properties maps an object type, such as "Volume", "Sdc",
+// "Sds", "ProtectionDomain", "StoragePool" or "System", to the names of the
+// statistics to return, such as "userDataReadBwc". ids maps an object type
+// to the IDs of the objects; all the objects of the types not in ids are
+// returned.
+func (s *System) QuerySelectedStatistics(ids, properties map[string][]string) (*SelectedStatistics, error) {
+	return s.QuerySelectedStatisticsCtx(s.client.callContext(), ids, properties)
+}
+
+// QuerySelectedStatisticsCtx returns the given statistics of many objects in
+// one request. properties maps an object type, such as "Volume", "Sdc",
+// "Sds", "ProtectionDomain", "StoragePool" or "System", to the names of the
+// statistics to return, such as "userDataReadBwc". ids maps an object type
+// to the IDs of the objects; all the objects of the types not in ids are
+// returned.
+func (s *System) QuerySelectedStatisticsCtx(ctx context.Context, ids, properties map[string][]string) (*SelectedStatistics, error) {
+	ctx, span := s.client.startSpan(ctx, "QuerySelectedStatistics")
+	defer span.end()
+
+	objectTypes := make([]string, 0, len(properties))
+	for objectType := range properties {
+		objectTypes = append(objectTypes, objectType)
+	}
+	sort.Strings(objectTypes)
+
+	var list []map[string]interface{}
+	for _, objectType := range objectTypes {
+		selected := map[string]interface{}{
+			"type":       objectType,
+			"properties": properties[objectType],
+		}
+		switch {
+		case objectType == "System":
+			// there is a single system, with no ID to select
+		case len(ids[objectType]) > 0:
+			selected["ids"] = ids[objectType]
+		default:
+			selected["allIds"] = []string{}
+		}
+		list = append(list, selected)
+	}
+
+	path := "/api/instances/querySelectedStatistics"
+	body := map[string]interface{}{"selectedStatisticsList": list}
+
+	var byType map[string]json.RawMessage
+	err := s.client.getJSONWithRetry(ctx, http.MethodPost, path, body, &byType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SelectedStatistics{byType: byType}, nil
+}
+
+// Decode decodes the statistics of the objects of objectType into v, a
+// pointer to a map from object ID to statistics, or to the statistics
+// themselves for "System". v is left unchanged if there are none.
+func (s *SelectedStatistics) Decode(objectType string, v interface{}) error {
+	raw, ok := s.byType[objectType]
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// Volumes returns the statistics of the volumes, by volume ID.
+func (s *SelectedStatistics) Volumes() (map[string]types.VolumeStatistics, error) {
+	stats := map[string]types.VolumeStatistics{}
+	return stats, s.Decode("Volume", &stats)
+}
+
+// Sdcs returns the statistics of the SDCs, by SDC ID.
+func (s *SelectedStatistics) Sdcs() (map[string]types.SdcStatistics, error) {
+	stats := map[string]types.SdcStatistics{}
+	return stats, s.Decode("Sdc", &stats)
+}
+
+// Sdss returns the statistics of the Sdss, by Sds ID.
+func (s *SelectedStatistics) Sdss() (map[string]types.Statistics, error) {
+	stats := map[string]types.Statistics{}
+	return stats, s.Decode("Sds", &stats)
+}
+
+// ProtectionDomains returns the statistics of the protection domains, by
+// protection domain ID.
+func (s *SelectedStatistics) ProtectionDomains() (map[string]types.Statistics, error) {
+	stats := map[string]types.Statistics{}
+	return stats, s.Decode("ProtectionDomain", &stats)
+}
+
+// StoragePools returns the statistics of the storage pools, by storage pool
+// ID.
+func (s *SelectedStatistics) StoragePools() (map[string]types.Statistics, error) {
+	stats := map[string]types.Statistics{}
+	return stats, s.Decode("StoragePool", &stats)
+}
+
+// System returns the statistics of the system.
+func (s *SelectedStatistics) System() (*types.Statistics, error) {
+	var stats types.Statistics
+	return &stats, s.Decode("System", &stats)
+}
@@ -0,0 +1,104 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuerySelectedStatistics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/instances/querySelectedStatistics", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"selectedStatisticsList":[
+			{"type":"ProtectionDomain","allIds":[],"properties":["numOfSds"]},
+			{"type":"Sdc","allIds":[],"properties":["numOfMappedVolumes"]},
+			{"type":"Sds","ids":["sds1"],"properties":["numOfDevices"]},
+			{"type":"System","properties":["numOfStoragePools"]},
+			{"type":"Volume","ids":["v1","v2"],"properties":["userDataReadBwc","numOfMappedSdcs"]}
+		]}`, string(body))
+
+		w.Write([]byte(`{
+			"Volume":{
+				"v1":{"userDataReadBwc":{"totalWeightInKb":10,"numOccured":2,"numSeconds":5},"numOfMappedSdcs":1},
+				"v2":{"userDataReadBwc":{"totalWeightInKb":0,"numOccured":0,"numSeconds":5},"numOfMappedSdcs":3}
+			},
+			"Sdc":{"s1":{"numOfMappedVolumes":2}},
+			"Sds":{"sds1":{"numOfDevices":5}},
+			"ProtectionDomain":{"pd1":{"numOfSds":3}},
+			"System":{"numOfStoragePools":4}
+		}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	s := NewSystem(client)
+
+	stats, err := s.QuerySelectedStatistics(
+		map[string][]string{"Volume": {"v1", "v2"}, "Sds": {"sds1"}},
+		map[string][]string{
+			"Volume":           {"userDataReadBwc", "numOfMappedSdcs"},
+			"Sdc":              {"numOfMappedVolumes"},
+			"Sds":              {"numOfDevices"},
+			"ProtectionDomain": {"numOfSds"},
+			"System":           {"numOfStoragePools"},
+		})
+	assert.NoError(t, err)
+
+	volumes, err := stats.Volumes()
+	assert.NoError(t, err)
+	assert.Len(t, volumes, 2)
+	assert.Equal(t, 10, volumes["v1"].UserDataReadBwc.TotalWeightInKb)
+	assert.Equal(t, 3, volumes["v2"].NumOfMappedSdcs)
+
+	sdcs, err := stats.Sdcs()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sdcs["s1"].NumOfMappedVolumes)
+
+	sdss, err := stats.Sdss()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, sdss["sds1"].NumOfDevices)
+
+	domains, err := stats.ProtectionDomains()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, domains["pd1"].NumOfSds)
+
+	pools, err := stats.StoragePools()
+	assert.NoError(t, err)
+	assert.Empty(t, pools)
+
+	system, err := stats.System()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, system.NumOfStoragePools)
+
+	var invalid map[string]string
+	assert.Error(t, stats.Decode("Volume", &invalid))
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"bad request","httpStatusCode":400,"errorCode":0}`))
+	}))
+	defer failing.Close()
+
+	client, err = NewClientWithArgs(failing.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = NewSystem(client).QuerySelectedStatistics(nil, map[string][]string{"System": {"numOfStoragePools"}})
+	assert.EqualError(t, err, "bad request")
+}
@@ -13,10 +13,10 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
@@ -45,10 +45,18 @@ func NewStoragePoolEx(client *Client, pool *types.StoragePool) *StoragePool {
 
 // CreateStoragePool creates a storage pool
 func (pd *ProtectionDomain) CreateStoragePool(sp *types.StoragePoolParam) (string, error) {
+	return pd.CreateStoragePoolCtx(pd.client.callContext(), sp)
+}
+
+// CreateStoragePoolCtx creates a storage pool
+func (pd *ProtectionDomain) CreateStoragePoolCtx(ctx context.Context, sp *types.StoragePoolParam) (string, error) {
+	ctx, span := pd.client.startSpan(ctx, "CreateStoragePool")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/types/StoragePool/instances")
 	sp.ProtectionDomainID = pd.ProtectionDomain.ID
 	spResponse := types.StoragePoolResp{}
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, sp, &spResponse)
 	if err != nil {
 		return "", err
@@ -58,6 +66,14 @@ func (pd *ProtectionDomain) CreateStoragePool(sp *types.StoragePoolParam) (strin
 
 // ModifyStoragePoolName Modifies Storagepool Name
 func (pd *ProtectionDomain) ModifyStoragePoolName(ID, name string) (string, error) {
+	return pd.ModifyStoragePoolNameCtx(pd.client.callContext(), ID, name)
+}
+
+// ModifyStoragePoolNameCtx Modifies Storagepool Name
+func (pd *ProtectionDomain) ModifyStoragePoolNameCtx(ctx context.Context, ID, name string) (string, error) {
+	ctx, span := pd.client.startSpan(ctx, "ModifyStoragePoolName")
+	defer span.end()
+
 	storagePoolParam := &types.ModifyStoragePoolName{
 		Name: name,
 	}
@@ -65,7 +81,7 @@ func (pd *ProtectionDomain) ModifyStoragePoolName(ID, name string) (string, erro
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setStoragePoolName", ID)
 
 	spResp := types.StoragePoolResp{}
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, storagePoolParam, &spResp)
 	if err != nil {
 		return "", err
@@ -76,6 +92,14 @@ func (pd *ProtectionDomain) ModifyStoragePoolName(ID, name string) (string, erro
 
 // ModifyStoragePoolMedia Modifies Storagepool Media Type
 func (pd *ProtectionDomain) ModifyStoragePoolMedia(ID, mediaType string) (string, error) {
+	return pd.ModifyStoragePoolMediaCtx(pd.client.callContext(), ID, mediaType)
+}
+
+// ModifyStoragePoolMediaCtx Modifies Storagepool Media Type
+func (pd *ProtectionDomain) ModifyStoragePoolMediaCtx(ctx context.Context, ID, mediaType string) (string, error) {
+	ctx, span := pd.client.startSpan(ctx, "ModifyStoragePoolMedia")
+	defer span.end()
+
 	storagePool := &types.StoragePoolMediaType{
 		MediaType: mediaType,
 	}
@@ -83,7 +107,7 @@ func (pd *ProtectionDomain) ModifyStoragePoolMedia(ID, mediaType string) (string
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setMediaType", ID)
 
 	spResp := types.StoragePoolResp{}
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, storagePool, &spResp)
 	if err != nil {
 		return "", err
@@ -94,6 +118,14 @@ func (pd *ProtectionDomain) ModifyStoragePoolMedia(ID, mediaType string) (string
 
 // ModifyRMCache Sets Read RAM Cache
 func (sp *StoragePool) ModifyRMCache(useRmcache string) error {
+	return sp.ModifyRMCacheCtx(sp.client.callContext(), useRmcache)
+}
+
+// ModifyRMCacheCtx Sets Read RAM Cache
+func (sp *StoragePool) ModifyRMCacheCtx(ctx context.Context, useRmcache string) error {
+	ctx, span := sp.client.startSpan(ctx, "ModifyRMCache")
+	defer span.end()
+
 	link, err := GetLink(sp.StoragePool.Links, "self")
 	if err != nil {
 		return err
@@ -102,19 +134,27 @@ func (sp *StoragePool) ModifyRMCache(useRmcache string) error {
 	payload := &types.StoragePoolUseRmCache{
 		UseRmcache: useRmcache,
 	}
-	err = sp.client.getJSONWithRetry(
+	err = sp.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, payload, nil)
 	return err
 }
 
 // EnableRFCache Enables RFCache
 func (pd *ProtectionDomain) EnableRFCache(ID string) (string, error) {
+	return pd.EnableRFCacheCtx(pd.client.callContext(), ID)
+}
+
+// EnableRFCacheCtx Enables RFCache
+func (pd *ProtectionDomain) EnableRFCacheCtx(ctx context.Context, ID string) (string, error) {
+	ctx, span := pd.client.startSpan(ctx, "EnableRFCache")
+	defer span.end()
+
 	storagePoolParam := &types.StoragePoolUseRfCache{}
 
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/enableRfcache", ID)
 
 	spResp := types.StoragePoolResp{}
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, storagePoolParam, &spResp)
 	if err != nil {
 		return "", err
@@ -125,11 +165,19 @@ func (pd *ProtectionDomain) EnableRFCache(ID string) (string, error) {
 
 // EnableOrDisableZeroPadding Enables / disables zero padding
 func (pd *ProtectionDomain) EnableOrDisableZeroPadding(ID string, zeroPadValue string) error {
+	return pd.EnableOrDisableZeroPaddingCtx(pd.client.callContext(), ID, zeroPadValue)
+}
+
+// EnableOrDisableZeroPaddingCtx Enables / disables zero padding
+func (pd *ProtectionDomain) EnableOrDisableZeroPaddingCtx(ctx context.Context, ID string, zeroPadValue string) error {
+	ctx, span := pd.client.startSpan(ctx, "EnableOrDisableZeroPadding")
+	defer span.end()
+
 	zeroPaddedParam := &types.StoragePoolZeroPadEnabled{
 		ZeroPadEnabled: zeroPadValue,
 	}
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setZeroPaddingPolicy", ID)
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, zeroPaddedParam, nil)
 	if err != nil {
 		return err
@@ -139,11 +187,19 @@ func (pd *ProtectionDomain) EnableOrDisableZeroPadding(ID string, zeroPadValue s
 
 // SetReplicationJournalCapacity Sets replication journal capacity
 func (pd *ProtectionDomain) SetReplicationJournalCapacity(ID string, replicationJournalCapacity string) error {
+	return pd.SetReplicationJournalCapacityCtx(pd.client.callContext(), ID, replicationJournalCapacity)
+}
+
+// SetReplicationJournalCapacityCtx Sets replication journal capacity
+func (pd *ProtectionDomain) SetReplicationJournalCapacityCtx(ctx context.Context, ID string, replicationJournalCapacity string) error {
+	ctx, span := pd.client.startSpan(ctx, "SetReplicationJournalCapacity")
+	defer span.end()
+
 	replicationJournalCapacityParam := &types.ReplicationJournalCapacityParam{
 		ReplicationJournalCapacityMaxRatio: replicationJournalCapacity,
 	}
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setReplicationJournalCapacity", ID)
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, replicationJournalCapacityParam, nil)
 	if err != nil {
 		return err
@@ -153,8 +209,16 @@ func (pd *ProtectionDomain) SetReplicationJournalCapacity(ID string, replication
 
 // SetCapacityAlertThreshold Sets high or critical capacity alert threshold
 func (pd *ProtectionDomain) SetCapacityAlertThreshold(ID string, capacityAlertThreshold *types.CapacityAlertThresholdParam) error {
+	return pd.SetCapacityAlertThresholdCtx(pd.client.callContext(), ID, capacityAlertThreshold)
+}
+
+// SetCapacityAlertThresholdCtx Sets high or critical capacity alert threshold
+func (pd *ProtectionDomain) SetCapacityAlertThresholdCtx(ctx context.Context, ID string, capacityAlertThreshold *types.CapacityAlertThresholdParam) error {
+	ctx, span := pd.client.startSpan(ctx, "SetCapacityAlertThreshold")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setCapacityAlertThresholds", ID)
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, capacityAlertThreshold, nil)
 	if err != nil {
 		return err
@@ -164,8 +228,16 @@ func (pd *ProtectionDomain) SetCapacityAlertThreshold(ID string, capacityAlertTh
 
 // SetProtectedMaintenanceModeIoPriorityPolicy sets protected maintenance mode IO priority policy
 func (pd *ProtectionDomain) SetProtectedMaintenanceModeIoPriorityPolicy(ID string, protectedMaintenanceModeParam *types.ProtectedMaintenanceModeParam) error {
+	return pd.SetProtectedMaintenanceModeIoPriorityPolicyCtx(pd.client.callContext(), ID, protectedMaintenanceModeParam)
+}
+
+// SetProtectedMaintenanceModeIoPriorityPolicyCtx sets protected maintenance mode IO priority policy
+func (pd *ProtectionDomain) SetProtectedMaintenanceModeIoPriorityPolicyCtx(ctx context.Context, ID string, protectedMaintenanceModeParam *types.ProtectedMaintenanceModeParam) error {
+	ctx, span := pd.client.startSpan(ctx, "SetProtectedMaintenanceModeIoPriorityPolicy")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setProtectedMaintenanceModeIoPriorityPolicy", ID)
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, protectedMaintenanceModeParam, nil)
 	if err != nil {
 		return err
@@ -175,11 +247,19 @@ func (pd *ProtectionDomain) SetProtectedMaintenanceModeIoPriorityPolicy(ID strin
 
 // SetRebalanceEnabled sets rebalance enabled.
 func (pd *ProtectionDomain) SetRebalanceEnabled(ID string, rebalanceEnabledValue string) error {
+	return pd.SetRebalanceEnabledCtx(pd.client.callContext(), ID, rebalanceEnabledValue)
+}
+
+// SetRebalanceEnabledCtx sets rebalance enabled.
+func (pd *ProtectionDomain) SetRebalanceEnabledCtx(ctx context.Context, ID string, rebalanceEnabledValue string) error {
+	ctx, span := pd.client.startSpan(ctx, "SetRebalanceEnabled")
+	defer span.end()
+
 	rebalanceEnabledParam := &types.RebalanceEnabledParam{
 		RebalanceEnabled: rebalanceEnabledValue,
 	}
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setRebalanceEnabled", ID)
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, rebalanceEnabledParam, nil)
 	if err != nil {
 		return err
@@ -189,8 +269,16 @@ func (pd *ProtectionDomain) SetRebalanceEnabled(ID string, rebalanceEnabledValue
 
 // SetRebalanceIoPriorityPolicy Sets rebalance I/O priority policy
 func (pd *ProtectionDomain) SetRebalanceIoPriorityPolicy(ID string, protectedMaintenanceModeParam *types.ProtectedMaintenanceModeParam) error {
+	return pd.SetRebalanceIoPriorityPolicyCtx(pd.client.callContext(), ID, protectedMaintenanceModeParam)
+}
+
+// SetRebalanceIoPriorityPolicyCtx Sets rebalance I/O priority policy
+func (pd *ProtectionDomain) SetRebalanceIoPriorityPolicyCtx(ctx context.Context, ID string, protectedMaintenanceModeParam *types.ProtectedMaintenanceModeParam) error {
+	ctx, span := pd.client.startSpan(ctx, "SetRebalanceIoPriorityPolicy")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setRebalanceIoPriorityPolicy", ID)
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, protectedMaintenanceModeParam, nil)
 	if err != nil {
 		return err
@@ -200,8 +288,16 @@ func (pd *ProtectionDomain) SetRebalanceIoPriorityPolicy(ID string, protectedMai
 
 // SetVTreeMigrationIOPriorityPolicy Sets V-Tree migration I/O priority policy
 func (pd *ProtectionDomain) SetVTreeMigrationIOPriorityPolicy(ID string, protectedMaintenanceModeParam *types.ProtectedMaintenanceModeParam) error {
+	return pd.SetVTreeMigrationIOPriorityPolicyCtx(pd.client.callContext(), ID, protectedMaintenanceModeParam)
+}
+
+// SetVTreeMigrationIOPriorityPolicyCtx Sets V-Tree migration I/O priority policy
+func (pd *ProtectionDomain) SetVTreeMigrationIOPriorityPolicyCtx(ctx context.Context, ID string, protectedMaintenanceModeParam *types.ProtectedMaintenanceModeParam) error {
+	ctx, span := pd.client.startSpan(ctx, "SetVTreeMigrationIOPriorityPolicy")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setVTreeMigrationIoPriorityPolicy", ID)
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, protectedMaintenanceModeParam, nil)
 	if err != nil {
 		return err
@@ -211,11 +307,19 @@ func (pd *ProtectionDomain) SetVTreeMigrationIOPriorityPolicy(ID string, protect
 
 // SetSparePercentage Sets spare percentage
 func (pd *ProtectionDomain) SetSparePercentage(ID string, sparePercentageValue string) error {
+	return pd.SetSparePercentageCtx(pd.client.callContext(), ID, sparePercentageValue)
+}
+
+// SetSparePercentageCtx Sets spare percentage
+func (pd *ProtectionDomain) SetSparePercentageCtx(ctx context.Context, ID string, sparePercentageValue string) error {
+	ctx, span := pd.client.startSpan(ctx, "SetSparePercentage")
+	defer span.end()
+
 	percentageParam := &types.SparePercentageParam{
 		SparePercentage: sparePercentageValue,
 	}
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setSparePercentage", ID)
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, percentageParam, nil)
 	if err != nil {
 		return err
@@ -225,11 +329,19 @@ func (pd *ProtectionDomain) SetSparePercentage(ID string, sparePercentageValue s
 
 // SetRMcacheWriteHandlingMode Sets RMcache write handling mode
 func (pd *ProtectionDomain) SetRMcacheWriteHandlingMode(ID string, writeHandlingModeValue string) error {
+	return pd.SetRMcacheWriteHandlingModeCtx(pd.client.callContext(), ID, writeHandlingModeValue)
+}
+
+// SetRMcacheWriteHandlingModeCtx Sets RMcache write handling mode
+func (pd *ProtectionDomain) SetRMcacheWriteHandlingModeCtx(ctx context.Context, ID string, writeHandlingModeValue string) error {
+	ctx, span := pd.client.startSpan(ctx, "SetRMcacheWriteHandlingMode")
+	defer span.end()
+
 	writeHandlingParam := &types.RmcacheWriteHandlingModeParam{
 		RmcacheWriteHandlingMode: writeHandlingModeValue,
 	}
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setRmcacheWriteHandlingMode", ID)
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, writeHandlingParam, nil)
 	if err != nil {
 		return err
@@ -239,11 +351,19 @@ func (pd *ProtectionDomain) SetRMcacheWriteHandlingMode(ID string, writeHandling
 
 // SetRebuildEnabled Sets Rebuild Enabled
 func (pd *ProtectionDomain) SetRebuildEnabled(ID string, rebuildEnabledValue string) error {
+	return pd.SetRebuildEnabledCtx(pd.client.callContext(), ID, rebuildEnabledValue)
+}
+
+// SetRebuildEnabledCtx Sets Rebuild Enabled
+func (pd *ProtectionDomain) SetRebuildEnabledCtx(ctx context.Context, ID string, rebuildEnabledValue string) error {
+	ctx, span := pd.client.startSpan(ctx, "SetRebuildEnabled")
+	defer span.end()
+
 	rebuildEnabled := &types.RebuildEnabledParam{
 		RebuildEnabled: rebuildEnabledValue,
 	}
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setRebuildEnabled", ID)
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, rebuildEnabled, nil)
 	if err != nil {
 		return err
@@ -253,11 +373,19 @@ func (pd *ProtectionDomain) SetRebuildEnabled(ID string, rebuildEnabledValue str
 
 // SetRebuildRebalanceParallelismParam Sets rebuild/rebalance parallelism
 func (pd *ProtectionDomain) SetRebuildRebalanceParallelismParam(ID string, limitValue string) error {
+	return pd.SetRebuildRebalanceParallelismParamCtx(pd.client.callContext(), ID, limitValue)
+}
+
+// SetRebuildRebalanceParallelismParamCtx Sets rebuild/rebalance parallelism
+func (pd *ProtectionDomain) SetRebuildRebalanceParallelismParamCtx(ctx context.Context, ID string, limitValue string) error {
+	ctx, span := pd.client.startSpan(ctx, "SetRebuildRebalanceParallelismParam")
+	defer span.end()
+
 	rebuildRebalanceParam := &types.RebuildRebalanceParallelismParam{
 		Limit: limitValue,
 	}
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/setRebuildRebalanceParallelism", ID)
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, rebuildRebalanceParam, nil)
 	if err != nil {
 		return err
@@ -267,18 +395,26 @@ func (pd *ProtectionDomain) SetRebuildRebalanceParallelismParam(ID string, limit
 
 // Fragmentation enables or disables fragmentation
 func (pd *ProtectionDomain) Fragmentation(ID string, value bool) error {
+	return pd.FragmentationCtx(pd.client.callContext(), ID, value)
+}
+
+// FragmentationCtx enables or disables fragmentation
+func (pd *ProtectionDomain) FragmentationCtx(ctx context.Context, ID string, value bool) error {
+	ctx, span := pd.client.startSpan(ctx, "Fragmentation")
+	defer span.end()
+
 	payload := &types.FragmentationParam{}
 	if value {
 
 		path := fmt.Sprintf("/api/instances/StoragePool::%v/action/enableFragmentation", ID)
-		err := pd.client.getJSONWithRetry(
+		err := pd.client.getJSONWithRetry(ctx,
 			http.MethodPost, path, payload, nil)
 		if err != nil {
 			return err
 		}
 	} else {
 		path := fmt.Sprintf("/api/instances/StoragePool::%v/action/disableFragmentation", ID)
-		err := pd.client.getJSONWithRetry(
+		err := pd.client.getJSONWithRetry(ctx,
 			http.MethodPost, path, payload, nil)
 		if err != nil {
 			return err
@@ -290,12 +426,20 @@ func (pd *ProtectionDomain) Fragmentation(ID string, value bool) error {
 
 // DisableRFCache Disables RFCache
 func (pd *ProtectionDomain) DisableRFCache(ID string) (string, error) {
+	return pd.DisableRFCacheCtx(pd.client.callContext(), ID)
+}
+
+// DisableRFCacheCtx Disables RFCache
+func (pd *ProtectionDomain) DisableRFCacheCtx(ctx context.Context, ID string) (string, error) {
+	ctx, span := pd.client.startSpan(ctx, "DisableRFCache")
+	defer span.end()
+
 	payload := &types.StoragePoolUseRfCache{}
 
 	path := fmt.Sprintf("/api/instances/StoragePool::%v/action/disableRfcache", ID)
 
 	spResp := types.StoragePoolResp{}
-	err := pd.client.getJSONWithRetry(
+	err := pd.client.getJSONWithRetry(ctx,
 
 		http.MethodPost, path, payload, &spResp)
 	if err != nil {
@@ -307,8 +451,16 @@ func (pd *ProtectionDomain) DisableRFCache(ID string) (string, error) {
 
 // DeleteStoragePool will delete a storage pool
 func (pd *ProtectionDomain) DeleteStoragePool(name string) error {
+	return pd.DeleteStoragePoolCtx(pd.client.callContext(), name)
+}
+
+// DeleteStoragePoolCtx will delete a storage pool
+func (pd *ProtectionDomain) DeleteStoragePoolCtx(ctx context.Context, name string) error {
+	ctx, span := pd.client.startSpan(ctx, "DeleteStoragePool")
+	defer span.end()
+
 	// get the storage pool name
-	pool, err := pd.FindStoragePool("", name, "")
+	pool, err := pd.FindStoragePoolCtx(ctx, "", name, "")
 	if err != nil {
 		return err
 	}
@@ -322,7 +474,7 @@ func (pd *ProtectionDomain) DeleteStoragePool(name string) error {
 
 	path := fmt.Sprintf("%v/action/removeStoragePool", link.HREF)
 
-	err = pd.client.getJSONWithRetry(
+	err = pd.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, storagePoolParam, nil)
 	if err != nil {
 		return err
@@ -335,6 +487,16 @@ func (pd *ProtectionDomain) DeleteStoragePool(name string) error {
 func (pd *ProtectionDomain) GetStoragePool(
 	storagepoolhref string,
 ) ([]*types.StoragePool, error) {
+	return pd.GetStoragePoolCtx(pd.client.callContext(), storagepoolhref)
+}
+
+// GetStoragePoolCtx returns a storage pool
+func (pd *ProtectionDomain) GetStoragePoolCtx(ctx context.Context,
+	storagepoolhref string,
+) ([]*types.StoragePool, error) {
+	ctx, span := pd.client.startSpan(ctx, "GetStoragePool")
+	defer span.end()
+
 	var (
 		err error
 		sp  = &types.StoragePool{}
@@ -348,10 +510,10 @@ func (pd *ProtectionDomain) GetStoragePool(
 		if err != nil {
 			return nil, err
 		}
-		err = pd.client.getJSONWithRetry(
+		err = pd.client.getJSONWithRetry(ctx,
 			http.MethodGet, link.HREF, nil, &sps)
 	} else {
-		err = pd.client.getJSONWithRetry(
+		err = pd.client.getJSONWithRetry(ctx,
 			http.MethodGet, storagepoolhref, nil, sp)
 	}
 	if err != nil {
@@ -368,7 +530,17 @@ func (pd *ProtectionDomain) GetStoragePool(
 func (pd *ProtectionDomain) FindStoragePool(
 	id, name, href string,
 ) (*types.StoragePool, error) {
-	sps, err := pd.GetStoragePool(href)
+	return pd.FindStoragePoolCtx(pd.client.callContext(), id, name, href)
+}
+
+// FindStoragePoolCtx returns a storagepool based on id or name
+func (pd *ProtectionDomain) FindStoragePoolCtx(ctx context.Context,
+	id, name, href string,
+) (*types.StoragePool, error) {
+	ctx, span := pd.client.startSpan(ctx, "FindStoragePool")
+	defer span.end()
+
+	sps, err := pd.GetStoragePoolCtx(ctx, href)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting protection domains %s", err)
 	}
@@ -384,6 +556,14 @@ func (pd *ProtectionDomain) FindStoragePool(
 
 // GetStatistics returns statistics
 func (sp *StoragePool) GetStatistics() (*types.Statistics, error) {
+	return sp.GetStatisticsCtx(sp.client.callContext())
+}
+
+// GetStatisticsCtx returns statistics
+func (sp *StoragePool) GetStatisticsCtx(ctx context.Context) (*types.Statistics, error) {
+	ctx, span := sp.client.startSpan(ctx, "GetStatistics")
+	defer span.end()
+
 	link, err := GetLink(sp.StoragePool.Links,
 		"/api/StoragePool/relationship/Statistics")
 	if err != nil {
@@ -391,7 +571,7 @@ func (sp *StoragePool) GetStatistics() (*types.Statistics, error) {
 	}
 
 	stats := types.Statistics{}
-	err = sp.client.getJSONWithRetry(
+	err = sp.client.getJSONWithRetry(ctx,
 		http.MethodGet, link.HREF, nil, &stats)
 	if err != nil {
 		return nil, err
@@ -402,6 +582,14 @@ func (sp *StoragePool) GetStatistics() (*types.Statistics, error) {
 
 // GetSDSStoragePool return SDS instances associated with storage pool
 func (sp *StoragePool) GetSDSStoragePool() ([]types.Sds, error) {
+	return sp.GetSDSStoragePoolCtx(sp.client.callContext())
+}
+
+// GetSDSStoragePoolCtx return SDS instances associated with storage pool
+func (sp *StoragePool) GetSDSStoragePoolCtx(ctx context.Context) ([]types.Sds, error) {
+	ctx, span := sp.client.startSpan(ctx, "GetSDSStoragePool")
+	defer span.end()
+
 	link, err := GetLink(sp.StoragePool.Links,
 		"/api/StoragePool/relationship/SpSds")
 	if err != nil {
@@ -409,7 +597,7 @@ func (sp *StoragePool) GetSDSStoragePool() ([]types.Sds, error) {
 	}
 
 	sds := []types.Sds{}
-	err = sp.client.getJSONWithRetry(
+	err = sp.client.getJSONWithRetry(ctx,
 		http.MethodGet, link.HREF, nil, &sds)
 	if err != nil {
 		return nil, err
@@ -420,12 +608,18 @@ func (sp *StoragePool) GetSDSStoragePool() ([]types.Sds, error) {
 
 // GetStoragePoolByID returns a Storagepool by ID
 func (s *System) GetStoragePoolByID(id string) (*types.StoragePool, error) {
-	defer TimeSpent("GetStoragePoolByID", time.Now())
+	return s.GetStoragePoolByIDCtx(s.client.callContext(), id)
+}
+
+// GetStoragePoolByIDCtx returns a Storagepool by ID
+func (s *System) GetStoragePoolByIDCtx(ctx context.Context, id string) (*types.StoragePool, error) {
+	ctx, span := s.client.startSpan(ctx, "GetStoragePoolByID")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/StoragePool::%s", id)
 
 	var storagepool *types.StoragePool
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &storagepool)
 	if err != nil {
 		return nil, err
@@ -434,13 +628,47 @@ func (s *System) GetStoragePoolByID(id string) (*types.StoragePool, error) {
 	return storagepool, err
 }
 
+// GetStoragePoolsByIDs returns the storage pools with the given IDs, fetched in batches
+func (s *System) GetStoragePoolsByIDs(ids []string) ([]types.StoragePool, error) {
+	return s.GetStoragePoolsByIDsCtx(s.client.callContext(), ids)
+}
+
+// GetStoragePoolsByIDsCtx returns the storage pools with the given IDs, fetched in batches
+func (s *System) GetStoragePoolsByIDsCtx(ctx context.Context, ids []string) ([]types.StoragePool, error) {
+	ctx, span := s.client.startSpan(ctx, "GetStoragePoolsByIDs")
+	defer span.end()
+
+	return queryBySelectedIDs[types.StoragePool](ctx, s.client, "StoragePool", ids)
+}
+
+// GetStoragePoolStatisticsByIDs returns the statistics of the storage pools
+// with the given IDs, by storage pool ID, fetched in batches
+func (s *System) GetStoragePoolStatisticsByIDs(ids []string) (map[string]*types.Statistics, error) {
+	return s.GetStoragePoolStatisticsByIDsCtx(s.client.callContext(), ids)
+}
+
+// GetStoragePoolStatisticsByIDsCtx returns the statistics of the storage
+// pools with the given IDs, by storage pool ID, fetched in batches
+func (s *System) GetStoragePoolStatisticsByIDsCtx(ctx context.Context, ids []string) (map[string]*types.Statistics, error) {
+	ctx, span := s.client.startSpan(ctx, "GetStoragePoolStatisticsByIDs")
+	defer span.end()
+
+	return statisticsByIDs[types.Statistics](ctx, s.client, "StoragePool", storagePoolStatisticsProperties, ids)
+}
+
 // GetAllStoragePools returns all Storage pools on the system
 func (s *System) GetAllStoragePools() ([]types.StoragePool, error) {
-	defer TimeSpent("GetStoragepool", time.Now())
+	return s.GetAllStoragePoolsCtx(s.client.callContext())
+}
+
+// GetAllStoragePoolsCtx returns all Storage pools on the system
+func (s *System) GetAllStoragePoolsCtx(ctx context.Context) ([]types.StoragePool, error) {
+	ctx, span := s.client.startSpan(ctx, "GetStoragepool")
+	defer span.end()
 	path := "/api/types/StoragePool/instances"
 
 	var storagepools []types.StoragePool
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &storagepools)
 	if err != nil {
 		return nil, err
@@ -13,9 +13,9 @@
 package goscaleio
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
@@ -36,9 +36,15 @@ func NewSystem(client *Client) *System {
 
 // GetSystems returns systems
 func (c *Client) GetSystems() ([]*types.System, error) {
-	defer TimeSpent("GetSystems", time.Now())
+	return c.GetSystemsCtx(c.callContext())
+}
+
+// GetSystemsCtx returns systems
+func (c *Client) GetSystemsCtx(ctx context.Context) ([]*types.System, error) {
+	ctx, span := c.startSpan(ctx, "GetSystems")
+	defer span.end()
 
-	systems, err := c.GetInstance("")
+	systems, err := c.GetInstanceCtx(ctx, "")
 	if err != nil {
 		return nil, fmt.Errorf("err: problem getting instances: %s", err)
 	}
@@ -49,9 +55,17 @@ func (c *Client) GetSystems() ([]*types.System, error) {
 func (c *Client) FindSystem(
 	instanceID, name, href string,
 ) (*System, error) {
-	defer TimeSpent("FindSystem", time.Now())
+	return c.FindSystemCtx(c.callContext(), instanceID, name, href)
+}
 
-	systems, err := c.GetInstance(href)
+// FindSystemCtx returns a system based on ID or name
+func (c *Client) FindSystemCtx(ctx context.Context,
+	instanceID, name, href string,
+) (*System, error) {
+	ctx, span := c.startSpan(ctx, "FindSystem")
+	defer span.end()
+
+	systems, err := c.GetInstanceCtx(ctx, href)
 	if err != nil {
 		return nil, fmt.Errorf("err: problem getting instances: %s", err)
 	}
@@ -68,7 +82,13 @@ func (c *Client) FindSystem(
 
 // GetStatistics returns system statistics
 func (s *System) GetStatistics() (*types.Statistics, error) {
-	defer TimeSpent("GetStatistics", time.Now())
+	return s.GetStatisticsCtx(s.client.callContext())
+}
+
+// GetStatisticsCtx returns system statistics
+func (s *System) GetStatisticsCtx(ctx context.Context) (*types.Statistics, error) {
+	ctx, span := s.client.startSpan(ctx, "GetStatistics")
+	defer span.end()
 
 	link, err := GetLink(s.System.Links,
 		"/api/System/relationship/Statistics")
@@ -77,7 +97,7 @@ func (s *System) GetStatistics() (*types.Statistics, error) {
 	}
 
 	stats := types.Statistics{}
-	err = s.client.getJSONWithRetry(
+	err = s.client.getJSONWithRetry(ctx,
 		http.MethodGet, link.HREF, nil, &stats)
 	if err != nil {
 		return nil, err
@@ -90,7 +110,15 @@ func (s *System) GetStatistics() (*types.Statistics, error) {
 func (s *System) CreateSnapshotConsistencyGroup(
 	snapshotVolumesParam *types.SnapshotVolumesParam,
 ) (*types.SnapshotVolumesResp, error) {
-	defer TimeSpent("CreateSnapshotConsistencyGroup", time.Now())
+	return s.CreateSnapshotConsistencyGroupCtx(s.client.callContext(), snapshotVolumesParam)
+}
+
+// CreateSnapshotConsistencyGroupCtx creates a snapshot consistency group
+func (s *System) CreateSnapshotConsistencyGroupCtx(ctx context.Context,
+	snapshotVolumesParam *types.SnapshotVolumesParam,
+) (*types.SnapshotVolumesResp, error) {
+	ctx, span := s.client.startSpan(ctx, "CreateSnapshotConsistencyGroup")
+	defer span.end()
 
 	link, err := GetLink(s.System.Links, "self")
 	if err != nil {
@@ -100,7 +128,7 @@ func (s *System) CreateSnapshotConsistencyGroup(
 	path := fmt.Sprintf("%v/action/snapshotVolumes", link.HREF)
 
 	snapResp := types.SnapshotVolumesResp{}
-	err = s.client.getJSONWithRetry(
+	err = s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, snapshotVolumesParam, &snapResp)
 	if err != nil {
 		return nil, err
@@ -111,13 +139,19 @@ func (s *System) CreateSnapshotConsistencyGroup(
 
 // GetMDMClusterDetails returns MDM cluster details
 func (s *System) GetMDMClusterDetails() (*types.MdmCluster, error) {
-	defer TimeSpent("GetMDMClusterDetails", time.Now())
+	return s.GetMDMClusterDetailsCtx(s.client.callContext())
+}
+
+// GetMDMClusterDetailsCtx returns MDM cluster details
+func (s *System) GetMDMClusterDetailsCtx(ctx context.Context) (*types.MdmCluster, error) {
+	ctx, span := s.client.startSpan(ctx, "GetMDMClusterDetails")
+	defer span.end()
 
 	path := "api/instances/System/queryMdmCluster"
 	mdmParam := &types.EmptyPayload{}
 
 	mdmResp := types.MdmCluster{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, mdmParam, &mdmResp)
 	if err != nil {
 		return nil, err
@@ -128,11 +162,17 @@ func (s *System) GetMDMClusterDetails() (*types.MdmCluster, error) {
 
 // AddStandByMdm adds the standby MDMs to the MDM cluster
 func (s *System) AddStandByMdm(mdmParam *types.StandByMdm) (string, error) {
-	defer TimeSpent("AddStandByMdm", time.Now())
+	return s.AddStandByMdmCtx(s.client.callContext(), mdmParam)
+}
+
+// AddStandByMdmCtx adds the standby MDMs to the MDM cluster
+func (s *System) AddStandByMdmCtx(ctx context.Context, mdmParam *types.StandByMdm) (string, error) {
+	ctx, span := s.client.startSpan(ctx, "AddStandByMdm")
+	defer span.end()
 
 	path := "api/instances/System/action/addStandbyMdm"
 	mdm := &types.Mdm{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, mdmParam, &mdm)
 	if err != nil {
 		return "", err
@@ -142,13 +182,19 @@ func (s *System) AddStandByMdm(mdmParam *types.StandByMdm) (string, error) {
 
 // RemoveStandByMdm removes standby MDM
 func (s *System) RemoveStandByMdm(id string) error {
-	defer TimeSpent("RemoveStandByMdm", time.Now())
+	return s.RemoveStandByMdmCtx(s.client.callContext(), id)
+}
+
+// RemoveStandByMdmCtx removes standby MDM
+func (s *System) RemoveStandByMdmCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "RemoveStandByMdm")
+	defer span.end()
 
 	path := "/api/instances/System/action/removeStandbyMdm"
 	mdmParam := &types.RemoveStandByMdmParam{
 		ID: id,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, mdmParam, nil)
 	if err != nil {
 		return err
@@ -158,13 +204,19 @@ func (s *System) RemoveStandByMdm(id string) error {
 
 // ModifyPerformanceProfileMdmCluster modifies performance profile of MDM cluster
 func (s *System) ModifyPerformanceProfileMdmCluster(perfProfile string) error {
-	defer TimeSpent("ModifyPerformanceProfileMdmCluster", time.Now())
+	return s.ModifyPerformanceProfileMdmClusterCtx(s.client.callContext(), perfProfile)
+}
+
+// ModifyPerformanceProfileMdmClusterCtx modifies performance profile of MDM cluster
+func (s *System) ModifyPerformanceProfileMdmClusterCtx(ctx context.Context, perfProfile string) error {
+	ctx, span := s.client.startSpan(ctx, "ModifyPerformanceProfileMdmCluster")
+	defer span.end()
 
 	path := "/api/instances/System/action/setMdmPerformanceParameters"
 	mdmParam := &types.ChangeMdmPerfProfile{
 		PerfProfile: perfProfile,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, mdmParam, nil)
 	if err != nil {
 		return err
@@ -174,11 +226,17 @@ func (s *System) ModifyPerformanceProfileMdmCluster(perfProfile string) error {
 
 // SwitchClusterMode changes the MDM cluster mode
 func (s *System) SwitchClusterMode(switchClusterMode *types.SwitchClusterMode) error {
-	defer TimeSpent("SwitchClusterMode", time.Now())
+	return s.SwitchClusterModeCtx(s.client.callContext(), switchClusterMode)
+}
+
+// SwitchClusterModeCtx changes the MDM cluster mode
+func (s *System) SwitchClusterModeCtx(ctx context.Context, switchClusterMode *types.SwitchClusterMode) error {
+	ctx, span := s.client.startSpan(ctx, "SwitchClusterMode")
+	defer span.end()
 
 	path := "/api/instances/System/action/switchClusterMode"
 
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, switchClusterMode, nil)
 	if err != nil {
 		return err
@@ -188,13 +246,19 @@ func (s *System) SwitchClusterMode(switchClusterMode *types.SwitchClusterMode) e
 
 // ChangeMdmOwnerShip modifies the primary MDM
 func (s *System) ChangeMdmOwnerShip(id string) error {
-	defer TimeSpent("ChangeMdmOwnerShip", time.Now())
+	return s.ChangeMdmOwnerShipCtx(s.client.callContext(), id)
+}
+
+// ChangeMdmOwnerShipCtx modifies the primary MDM
+func (s *System) ChangeMdmOwnerShipCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "ChangeMdmOwnerShip")
+	defer span.end()
 
 	path := "/api/instances/System/action/changeMdmOwnership"
 	mdmParam := &types.ChangeMdmOwnerShip{
 		ID: id,
 	}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, mdmParam, nil)
 	if err != nil {
 		return err
@@ -204,10 +268,16 @@ func (s *System) ChangeMdmOwnerShip(id string) error {
 
 // RenameMdm modifies name of the MDM
 func (s *System) RenameMdm(renameMdm *types.RenameMdm) error {
-	defer TimeSpent("ChangeMdmOwnerShip", time.Now())
+	return s.RenameMdmCtx(s.client.callContext(), renameMdm)
+}
+
+// RenameMdmCtx modifies name of the MDM
+func (s *System) RenameMdmCtx(ctx context.Context, renameMdm *types.RenameMdm) error {
+	ctx, span := s.client.startSpan(ctx, "ChangeMdmOwnerShip")
+	defer span.end()
 
 	path := "/api/instances/System/action/renameMdm"
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, renameMdm, nil)
 	if err != nil {
 		return err
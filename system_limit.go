@@ -13,19 +13,25 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // GetSystemLimits gets list of sytem limits
 func (c *Client) GetSystemLimits() (systemLimits *types.QuerySystemLimitsResponse, err error) {
-	defer TimeSpent("GetSystemLimits", time.Now())
+	return c.GetSystemLimitsCtx(c.callContext())
+}
+
+// GetSystemLimitsCtx gets list of sytem limits
+func (c *Client) GetSystemLimitsCtx(ctx context.Context) (systemLimits *types.QuerySystemLimitsResponse, err error) {
+	ctx, span := c.startSpan(ctx, "GetSystemLimits")
+	defer span.end()
 	var body types.QuerySystemLimitsParam
 	path := "/api/instances/System/action/querySystemLimits"
-	err = c.getJSONWithRetry(
+	err = c.getJSONWithRetry(ctx,
 		http.MethodPost, path, body, &systemLimits)
 	if err != nil {
 		return nil, err
@@ -36,8 +42,14 @@ func (c *Client) GetSystemLimits() (systemLimits *types.QuerySystemLimitsRespons
 
 // GetMaxVol returns max volume size in GB
 func (c *Client) GetMaxVol() (MaxVolumeSize string, err error) {
-	defer TimeSpent("GetMaxVol", time.Now())
-	sysLimit, err := c.GetSystemLimits()
+	return c.GetMaxVolCtx(c.callContext())
+}
+
+// GetMaxVolCtx returns max volume size in GB
+func (c *Client) GetMaxVolCtx(ctx context.Context) (MaxVolumeSize string, err error) {
+	ctx, span := c.startSpan(ctx, "GetMaxVol")
+	defer span.end()
+	sysLimit, err := c.GetSystemLimitsCtx(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,61 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"time"
+
+	"github.com/dell/goscaleio/api"
+)
+
+// span traces a call of a public method and, as TimeSpent, reports its
+// duration to ExternalTimeRecorder.
+type span struct {
+	call  *api.Call
+	name  string
+	start time.Time
+}
+
+// startSpan starts the span of a call of the public method name. The
+// returned context must be used for the requests of the call, and the span
+// ended when it returns.
+func (c *Client) startSpan(ctx context.Context, name string) (context.Context, span) {
+	var t *api.Telemetry
+	if c != nil {
+		t = c.telemetry
+	}
+	return startSpan(ctx, t, name)
+}
+
+// startSpan starts the span of a call of the public method name. The
+// returned context must be used for the requests of the call, and the span
+// ended when it returns.
+func (gc *GatewayClient) startSpan(ctx context.Context, name string) (context.Context, span) {
+	var t *api.Telemetry
+	if gc != nil {
+		t = gc.telemetry
+	}
+	return startSpan(ctx, t, name)
+}
+
+func startSpan(ctx context.Context, t *api.Telemetry, name string) (context.Context, span) {
+	start := time.Now()
+	ctx, call := t.StartCall(ctx, name)
+	return ctx, span{call: call, name: name, start: start}
+}
+
+func (s span) end() {
+	s.call.End()
+	TimeSpent(s.name, s.start)
+}
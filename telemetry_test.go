@@ -0,0 +1,173 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dell/goscaleio/api"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttributes(s sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range s.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTelemetry(t *testing.T) {
+	var attempts atomic.Int32
+	var traceparent atomic.Value
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent.Store(r.Header.Get("traceparent"))
+		switch r.URL.Path {
+		case "/api/types/Volume/instances/action/queryIdByKey":
+			w.Write([]byte(`"v1"`))
+		case "/api/instances/Volume::v1":
+			if attempts.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"id":"v1","name":"vol"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not found","httpStatusCode":404,"errorCode":0}`))
+		}
+	}))
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	client, err := NewClientWithOptions(ts.URL, "3.6", api.ClientOptions{
+		RetryPolicy: &api.RetryPolicy{
+			MaxAttempts:          2,
+			InitialBackoff:       time.Millisecond,
+			RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+		},
+		TracerProvider: tp,
+		MeterProvider:  mp,
+		Propagator:     propagation.TraceContext{},
+	})
+	assert.NoError(t, err)
+
+	var recorded []string
+	ExternalTimeRecorder = func(name string, _ time.Duration) { recorded = append(recorded, name) }
+	defer func() { ExternalTimeRecorder = nil }()
+
+	ctx, root := tp.Tracer("test").Start(context.Background(), "CreateVolume")
+	vols, err := client.GetVolumeCtx(ctx, "", "", "", "vol", false)
+	root.End()
+	assert.NoError(t, err)
+	assert.Len(t, vols, 1)
+	assert.Equal(t, []string{"FindVolumeID", "GetVolume"}, recorded)
+	assert.Contains(t, traceparent.Load(), root.SpanContext().TraceID().String())
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 6) {
+		return
+	}
+	post, find, get503, get200, getVolume := spans[0], spans[1], spans[2], spans[3], spans[4]
+	assert.Equal(t, "FindVolumeID", find.Name())
+	assert.Equal(t, "GetVolume", getVolume.Name())
+	assert.Equal(t, root.SpanContext().SpanID(), getVolume.Parent().SpanID())
+	assert.Equal(t, getVolume.SpanContext().SpanID(), find.Parent().SpanID())
+	assert.Equal(t, find.SpanContext().SpanID(), post.Parent().SpanID())
+	assert.Equal(t, getVolume.SpanContext().SpanID(), get200.Parent().SpanID())
+
+	attrs := spanAttributes(get503)
+	assert.Equal(t, "GET", get503.Name())
+	assert.Equal(t, int64(503), attrs["http.response.status_code"].AsInt64())
+	assert.Equal(t, "Volume", attrs[api.AttrObjectType].AsString())
+	assert.Equal(t, "v1", attrs[api.AttrObjectID].AsString())
+	assert.Equal(t, codes.Error, get503.Status().Code)
+
+	attrs = spanAttributes(get200)
+	assert.Equal(t, int64(200), attrs["http.response.status_code"].AsInt64())
+	assert.Equal(t, int64(1), attrs["http.request.resend_count"].AsInt64())
+
+	attrs = spanAttributes(getVolume)
+	assert.Equal(t, int64(1), attrs[api.AttrRetryCount].AsInt64())
+	assert.Equal(t, "v1", attrs[api.AttrObjectID].AsString())
+	assert.Equal(t, codes.Unset, getVolume.Status().Code)
+
+	_, err = client.GetVolumeCtx(context.Background(), "", "bad", "", "", false)
+	assert.Error(t, err)
+	spans = recorder.Ended()
+	failed := spans[len(spans)-1]
+	assert.Equal(t, "GetVolume", failed.Name())
+	assert.Equal(t, codes.Error, failed.Status().Code)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	names := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			names[m.Name] = true
+		}
+	}
+	assert.True(t, names["goscaleio.call.duration"])
+	assert.True(t, names["http.client.request.duration"])
+}
+
+func TestTelemetryWithoutClient(t *testing.T) {
+	// objects built without a client are traced with the global providers
+	var c *Client
+	ctx, span := c.startSpan(context.Background(), "GetVolume")
+	assert.NotNil(t, api.CallFromContext(ctx))
+	span.end()
+}
+
+func TestTelemetrySpans(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/Compatibility":
+			w.Write([]byte(`{"id":"1"}`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer ts.Close()
+
+	var recorded []string
+	ExternalTimeRecorder = func(name string, _ time.Duration) { recorded = append(recorded, name) }
+	defer func() { ExternalTimeRecorder = nil }()
+
+	client, err := NewClientWithArgs(ts.URL, "4.5", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = NewSystem(client).GetCompatibilityManagementCtx(context.Background())
+	assert.NoError(t, err)
+
+	gc := &GatewayClient{http: ts.Client(), host: ts.URL, version: "4.0", token: "token"}
+	_, err = gc.GetPackageDetailsCtx(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"GetCompatibilityManagement", "GetPackageDetails"}, recorded)
+}
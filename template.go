@@ -13,53 +13,41 @@
 package goscaleio
 
 import (
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // GetTemplateByID gets the node details based on ID
 func (gc *GatewayClient) GetTemplateByID(id string) (*types.TemplateDetails, error) {
-	defer TimeSpent("GetTemplateByID", time.Now())
+	return gc.GetTemplateByIDCtx(context.Background(), id)
+}
+
+// GetTemplateByIDCtx gets the node details based on ID
+func (gc *GatewayClient) GetTemplateByIDCtx(ctx context.Context, id string) (*types.TemplateDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetTemplateByID")
+	defer span.end()
 
 	path := fmt.Sprintf("/Api/V1/template/%v", id)
 
 	var template types.TemplateDetails
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if httpResp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("Template not found")
 	}
 
-	responseString, _ := extractString(httpResp)
-	err := json.Unmarshal([]byte(responseString), &template)
+	err = json.Unmarshal([]byte(responseString), &template)
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing response data for template: %s", err)
 	}
@@ -68,37 +56,26 @@ func (gc *GatewayClient) GetTemplateByID(id string) (*types.TemplateDetails, err
 
 // GetAllTemplates gets all the Template details
 func (gc *GatewayClient) GetAllTemplates() ([]types.TemplateDetails, error) {
-	defer TimeSpent("GetAllTemplates", time.Now())
+	return gc.GetAllTemplatesCtx(context.Background())
+}
+
+// GetAllTemplatesCtx gets all the Template details
+func (gc *GatewayClient) GetAllTemplatesCtx(ctx context.Context) ([]types.TemplateDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetAllTemplates")
+	defer span.end()
 
 	path := "/Api/V1/template"
 
 	var templates types.TemplateDetailsFilter
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if httpResp.StatusCode == 200 {
-		responseString, _ := extractString(httpResp)
 		parseError := json.Unmarshal([]byte(responseString), &templates)
 
 		if parseError != nil {
@@ -111,43 +88,31 @@ func (gc *GatewayClient) GetAllTemplates() ([]types.TemplateDetails, error) {
 
 // GetTemplateByFilters gets the Template details based on the provided filter
 func (gc *GatewayClient) GetTemplateByFilters(key string, value string) ([]types.TemplateDetails, error) {
-	defer TimeSpent("GetTemplateByFilters", time.Now())
+	return gc.GetTemplateByFiltersCtx(context.Background(), key, value)
+}
+
+// GetTemplateByFiltersCtx gets the Template details based on the provided filter
+func (gc *GatewayClient) GetTemplateByFiltersCtx(ctx context.Context, key string, value string) ([]types.TemplateDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetTemplateByFilters")
+	defer span.end()
 
 	encodedValue := url.QueryEscape(value)
 
 	path := `/Api/V1/template?filter=` + key + `%20eq%20%22` + encodedValue + `%22`
 
 	var templates types.TemplateDetailsFilter
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+path, nil)
-	if httpError != nil {
-		return nil, httpError
-	}
-
-	if gc.version == "4.0" {
-		req.Header.Set("Authorization", "Bearer "+gc.token)
-
-		err := setCookie(req.Header, gc.host)
-		if err != nil {
-			return nil, fmt.Errorf("Error While Handling Cookie: %s", err)
-		}
-
-	} else {
-		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(gc.username+":"+gc.password)))
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return nil, httpRespError
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   path,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if httpResp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("Template not found")
 	}
 
-	responseString, _ := extractString(httpResp)
 	parseError := json.Unmarshal([]byte(responseString), &templates)
 	if parseError != nil {
 		return nil, fmt.Errorf("Error While Parsing Response Data For Template: %s", parseError)
@@ -162,10 +127,16 @@ func (gc *GatewayClient) GetTemplateByFilters(key string, value string) ([]types
 
 // CloneTemplate Creates a new Template based on a preexisting Template using the original template id
 func (gc *GatewayClient) CloneTemplate(s *System, originTemplateID string, templateName string) error {
-	defer TimeSpent("CloneTemplate", time.Now())
+	return gc.CloneTemplateCtx(context.Background(), s, originTemplateID, templateName)
+}
+
+// CloneTemplateCtx Creates a new Template based on a preexisting Template using the original template id
+func (gc *GatewayClient) CloneTemplateCtx(ctx context.Context, s *System, originTemplateID string, templateName string) error {
+	ctx, span := gc.startSpan(ctx, "CloneTemplate")
+	defer span.end()
 	path := `/Api/V1/ServiceTemplate/cloneTemplate`
 
-	template, err := gc.GetTemplateByFilters("originalTemplateId", originTemplateID)
+	template, err := gc.GetTemplateByFiltersCtx(ctx, "originalTemplateId", originTemplateID)
 	if err != nil {
 		return fmt.Errorf("Error While Cloning Template: %s", err.Error())
 	}
@@ -175,7 +146,7 @@ func (gc *GatewayClient) CloneTemplate(s *System, originTemplateID string, templ
 	template[0].TemplateName = templateName
 	template[0].InConfiguration = false
 
-	errCT := s.client.getJSONWithRetry(http.MethodPost, path, template[0], nil)
+	errCT := s.client.getJSONWithRetry(ctx, http.MethodPost, path, template[0], nil)
 	if errCT != nil {
 		return fmt.Errorf("Error While Cloning Template: Template already exists please use a different name")
 	}
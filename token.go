@@ -0,0 +1,225 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// loginTimeout bounds a shared login, which does not end with the context of
+// the caller that started it.
+const loginTimeout = time.Minute
+
+// tokenState serializes authentication for a Client, so that goroutines
+// which see an expired token at the same time share a single login.
+type tokenState struct {
+	mu       sync.Mutex // guards the fields below and Client.configConnect
+	inflight *loginCall
+	issuedAt time.Time
+	lifetime time.Duration
+	window   time.Duration
+	refresh  string // refresh token of a /rest/auth session
+	noREST   bool   // the array does not serve /rest/auth
+}
+
+// loginCall is a login in progress that other callers can wait for.
+type loginCall struct {
+	config *ConfigConnect // settings of the Authenticate call logged in for
+	done   chan struct{}
+	err    error
+}
+
+func (call *loginCall) wait(ctx context.Context) error {
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// setLifetime records how long tokens issued by the array stay valid and how
+// long before expiry they are refreshed. A zero lifetime disables proactive
+// refresh; a zero window defaults to a tenth of the lifetime.
+func (s *tokenState) setLifetime(lifetime, window time.Duration) {
+	if window <= 0 || window >= lifetime {
+		window = lifetime / 10
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lifetime = lifetime
+	s.window = window
+}
+
+// tokenIssued records that the client's token was replaced at t.
+func (s *tokenState) tokenIssued(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issuedAt = t
+}
+
+// refreshToken returns the refresh token of the session, if it has one.
+func (s *tokenState) refreshToken() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.refresh
+}
+
+// setRefreshToken replaces the refresh token of the session.
+func (s *tokenState) setRefreshToken(refresh string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refresh = refresh
+}
+
+// restAuthAvailable reports whether /rest/auth may be used to log in.
+func (s *tokenState) restAuthAvailable() bool {
+	if s == nil {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.noREST
+}
+
+// restAuthUnavailable records that the array does not serve /rest/auth.
+func (s *tokenState) restAuthUnavailable() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.noREST = true
+}
+
+// expiring reports whether the client's token is close enough to its
+// configured lifetime that it should be refreshed.
+func (s *tokenState) expiring(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.expiringAt(s.issuedAt, now)
+}
+
+// fresh reports whether a token issued at issuedAt can still be used
+// without being refreshed.
+func (s *tokenState) fresh(issuedAt, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.expiringAt(issuedAt, now)
+}
+
+// expiringAt reports whether a token issued at issuedAt should be refreshed.
+// s.mu must be held.
+func (s *tokenState) expiringAt(issuedAt, now time.Time) bool {
+	if s.lifetime <= 0 || issuedAt.IsZero() {
+		return false
+	}
+	return !now.Before(issuedAt.Add(s.lifetime - s.window))
+}
+
+// login authenticates with the current credentials. Concurrent calls are
+// merged: only the first one sends a request to api/login and every caller
+// waits for its result until its own context is done. A login with the
+// settings of an earlier Authenticate call is not joined but waited for.
+// When stale is not empty, login returns at once if the token has already
+// been replaced since the caller used stale.
+func (c *Client) login(ctx context.Context, stale string) error {
+	s := c.tokens
+	if s == nil {
+		_, err := c.newToken(ctx, stale, c.config())
+		return err
+	}
+
+	for {
+		s.mu.Lock()
+		current, config := c.configConnect, *c.configConnect
+		if call := s.inflight; call != nil {
+			s.mu.Unlock()
+			if call.config == current {
+				return call.wait(ctx)
+			}
+			// a login for earlier settings ends before one for these starts
+			select {
+			case <-call.done:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if stale != "" && c.api.GetToken() != stale {
+			s.mu.Unlock()
+			return nil
+		}
+		call := &loginCall{config: current, done: make(chan struct{})}
+		s.inflight = call
+		s.mu.Unlock()
+
+		go c.runLogin(ctx, stale, config, call)
+		return call.wait(ctx)
+	}
+}
+
+// runLogin logs in with the credentials of config for call, under a context
+// that outlives the one of the caller that started it, and reports the result
+// to the callers waiting for call.
+func (c *Client) runLogin(ctx context.Context, stale string, config ConfigConnect, call *loginCall) {
+	s := c.tokens
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loginTimeout)
+	defer cancel()
+	issuedAt, err := c.newToken(ctx, stale, config)
+
+	s.mu.Lock()
+	s.inflight = nil
+	if err == nil {
+		s.issuedAt = issuedAt
+	}
+	s.mu.Unlock()
+	call.err = err
+	close(call.done)
+}
+
+// reauthenticate replaces a token rejected by the array. stale is the token
+// the failed request was sent with.
+func (c *Client) reauthenticate(ctx context.Context, stale string) error {
+	c.logger.Info("Need to re-auth")
+	if err := c.login(ctx, stale); err != nil {
+		return fmt.Errorf("Error Authenticating: %s", err)
+	}
+	return nil
+}
+
+// refreshIfExpiring logs in again when the token is about to reach the
+// lifetime set in ConfigConnect.TokenLifetime. Failures are logged and the
+// current token is kept, since a 401 still triggers re-authentication.
+func (c *Client) refreshIfExpiring(ctx context.Context) {
+	if c.tokens == nil || !c.tokens.expiring(time.Now()) {
+		return
+	}
+	token := c.api.GetToken()
+	if token == "" {
+		return
+	}
+	c.logger.Debug("token is about to expire, refreshing")
+	if err := c.login(ctx, token); err != nil {
+		c.logger.Error(fmt.Sprintf("unable to refresh token: %s", err.Error()))
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CachedToken is a token stored in a TokenCache.
+type CachedToken struct {
+	Token    string    `json:"token"`
+	IssuedAt time.Time `json:"issuedAt"`
+}
+
+// TokenCache shares tokens between the clients of the same endpoint and
+// user, in one process or several, so that they log in once rather than
+// each on its own. Keys are opaque strings of letters and digits.
+type TokenCache interface {
+	// LoadToken returns the token stored for key, or nil if there is none.
+	LoadToken(ctx context.Context, key string) (*CachedToken, error)
+	// StoreToken saves token for key, replacing any previous one. A nil
+	// token removes it.
+	StoreToken(ctx context.Context, key string, token *CachedToken) error
+}
+
+// SetTokenCache sets the cache the client shares its token through. Set it
+// before Authenticate. A cached token is used as long as it is younger than
+// ConfigConnect.TokenLifetime, or until the array rejects it when no
+// lifetime is set.
+func (c *Client) SetTokenCache(cache TokenCache) {
+	c.tokenCache = cache
+}
+
+// MemoryTokenCache is a TokenCache for the clients of a single process.
+type MemoryTokenCache struct {
+	mu     sync.Mutex // guards tokens
+	tokens map[string]CachedToken
+}
+
+// NewMemoryTokenCache returns an empty MemoryTokenCache.
+func NewMemoryTokenCache() *MemoryTokenCache {
+	return &MemoryTokenCache{tokens: map[string]CachedToken{}}
+}
+
+// LoadToken returns the token stored for key.
+func (m *MemoryTokenCache) LoadToken(_ context.Context, key string) (*CachedToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// StoreToken saves token for key.
+func (m *MemoryTokenCache) StoreToken(_ context.Context, key string, token *CachedToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if token == nil {
+		delete(m.tokens, key)
+	} else {
+		m.tokens[key] = *token
+	}
+	return nil
+}
+
+// FileTokenCache is a TokenCache kept in a JSON file, for the processes of
+// a host. Access is serialized with a lock on a file next to it, and the
+// file is only readable by its owner.
+type FileTokenCache struct {
+	path string
+}
+
+// NewFileTokenCache returns a FileTokenCache kept at path. The file is
+// created on the first store.
+func NewFileTokenCache(path string) *FileTokenCache {
+	return &FileTokenCache{path: path}
+}
+
+// LoadToken returns the token stored for key.
+func (f *FileTokenCache) LoadToken(_ context.Context, key string) (*CachedToken, error) {
+	var token *CachedToken
+	err := f.locked(false, func() error {
+		tokens, err := f.read()
+		if t, ok := tokens[key]; ok {
+			token = &t
+		}
+		return err
+	})
+	return token, err
+}
+
+// StoreToken saves token for key.
+func (f *FileTokenCache) StoreToken(_ context.Context, key string, token *CachedToken) error {
+	return f.locked(true, func() error {
+		tokens, err := f.read()
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			delete(tokens, key)
+		} else {
+			tokens[key] = *token
+		}
+		return f.write(tokens)
+	})
+}
+
+// locked calls fn holding the lock of the cache, exclusively if exclusive
+// is set.
+func (f *FileTokenCache) locked(exclusive bool, fn func() error) error {
+	lock, err := os.OpenFile(f.path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return err
+	}
+	defer lock.Close()
+	if err := lockFile(lock, exclusive); err != nil {
+		return fmt.Errorf("unable to lock %s: %w", lock.Name(), err)
+	}
+	defer unlockFile(lock)
+	return fn()
+}
+
+func (f *FileTokenCache) read() (map[string]CachedToken, error) {
+	tokens := map[string]CachedToken{}
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("invalid token cache %s: %w", f.path, err)
+	}
+	return tokens, nil
+}
+
+// write replaces the file at once, so that a crash leaves the previous
+// tokens rather than a partial file.
+func (f *FileTokenCache) write(tokens map[string]CachedToken) error {
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// SecretStore holds data shaped like the data of a Kubernetes secret: keys
+// mapped to bytes. It lets a SecretTokenCache keep tokens in a secret
+// without this package depending on a Kubernetes client.
+type SecretStore interface {
+	// LoadSecret returns the data of the secret, empty if it does not exist.
+	LoadSecret(ctx context.Context) (map[string][]byte, error)
+	// StoreSecret replaces the data of the secret.
+	StoreSecret(ctx context.Context, data map[string][]byte) error
+}
+
+// SecretTokenCache is a TokenCache kept in a SecretStore, for the replicas
+// of a workload. Each token is a JSON-encoded CachedToken under its key.
+// Concurrent updates from several processes are left to the store, such as
+// the conflicts the Kubernetes API server reports on stale updates.
+type SecretTokenCache struct {
+	store SecretStore
+	mu    sync.Mutex // serializes the updates of this process
+}
+
+// NewSecretTokenCache returns a SecretTokenCache kept in store.
+func NewSecretTokenCache(store SecretStore) *SecretTokenCache {
+	return &SecretTokenCache{store: store}
+}
+
+// LoadToken returns the token stored for key.
+func (s *SecretTokenCache) LoadToken(ctx context.Context, key string) (*CachedToken, error) {
+	data, err := s.store.LoadSecret(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := data[key]
+	if !ok {
+		return nil, nil
+	}
+	var token CachedToken
+	if err := json.Unmarshal(value, &token); err != nil {
+		return nil, fmt.Errorf("invalid cached token %s: %w", key, err)
+	}
+	return &token, nil
+}
+
+// StoreToken saves token for key.
+func (s *SecretTokenCache) StoreToken(ctx context.Context, key string, token *CachedToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := s.store.LoadSecret(ctx)
+	if err != nil {
+		return err
+	}
+	updated := make(map[string][]byte, len(data)+1)
+	for k, v := range data {
+		updated[k] = v
+	}
+	if token == nil {
+		delete(updated, key)
+	} else {
+		value, err := json.Marshal(token)
+		if err != nil {
+			return err
+		}
+		updated[key] = value
+	}
+	return s.store.StoreSecret(ctx, updated)
+}
+
+// tokenCacheKey returns the key the tokens of username on endpoint are
+// cached under. Hashing keeps it valid as a file or secret key.
+func tokenCacheKey(endpoint, username string) string {
+	sum := sha256.Sum256([]byte(endpoint + "\n" + username))
+	return hex.EncodeToString(sum[:])
+}
+
+// cachedTokenKey returns the key in the token cache of the token of the
+// client logged in with config.
+func (c *Client) cachedTokenKey(ctx context.Context, config ConfigConnect) (string, error) {
+	credentials, err := config.loginCredentials(ctx)
+	if err != nil {
+		return "", err
+	}
+	var endpoint string
+	if endpoints := c.Endpoints(); len(endpoints) > 0 {
+		endpoint = endpoints[0]
+	}
+	return tokenCacheKey(endpoint, credentials.Username), nil
+}
+
+// newToken replaces the token of the client and returns when it was issued.
+// A fresh token another client stored in the token cache is used when there
+// is one; otherwise the client logs in with the credentials of config and
+// stores its new token. stale, the token being replaced, is never reused.
+func (c *Client) newToken(ctx context.Context, stale string, config ConfigConnect) (time.Time, error) {
+	if c.tokenCache == nil {
+		if err := c.doLogin(ctx, config); err != nil {
+			return time.Time{}, err
+		}
+		return time.Now(), nil
+	}
+
+	key, err := c.cachedTokenKey(ctx, config)
+	if err != nil {
+		return time.Time{}, err
+	}
+	cached, err := c.tokenCache.LoadToken(ctx, key)
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("unable to load the cached token: %s", err.Error()))
+	} else if cached != nil && cached.Token != "" && cached.Token != stale &&
+		(c.tokens == nil || c.tokens.fresh(cached.IssuedAt, time.Now())) {
+		if c.useCachedToken(ctx, cached.Token) {
+			return cached.IssuedAt, nil
+		}
+	}
+
+	if err := c.doLogin(ctx, config); err != nil {
+		return time.Time{}, err
+	}
+	issuedAt := time.Now()
+	token := &CachedToken{Token: c.api.GetToken(), IssuedAt: issuedAt}
+	if err := c.tokenCache.StoreToken(ctx, key, token); err != nil {
+		c.logger.Error(fmt.Sprintf("unable to cache the token: %s", err.Error()))
+	}
+	return issuedAt, nil
+}
+
+// useCachedToken makes token the token of the client. When the version is
+// not known yet, it is fetched with token, as doLogin would have done, since
+// it decides how the token is sent. It returns false, leaving the client
+// without a token, when token cannot be used.
+func (c *Client) useCachedToken(ctx context.Context, token string) bool {
+	c.api.SetToken(token)
+	// the refresh token of the previous session does not renew this one
+	c.tokens.setRefreshToken("")
+	if c.version() == "" {
+		if err := c.updateVersion(ctx); err != nil {
+			c.logger.Debug(fmt.Sprintf("unable to use the cached token: %s", err.Error()))
+			c.api.SetToken("")
+			return false
+		}
+	}
+	c.logger.Debug("using the cached token")
+	return true
+}
+
+// forgetToken removes token from the token cache after the session has
+// ended, unless another client replaced it already.
+func (c *Client) forgetToken(ctx context.Context, token string) {
+	if c.tokenCache == nil {
+		return
+	}
+	key, err := c.cachedTokenKey(ctx, c.config())
+	if err != nil {
+		return
+	}
+	cached, err := c.tokenCache.LoadToken(ctx, key)
+	if err != nil || cached == nil || cached.Token != token {
+		return
+	}
+	if err := c.tokenCache.StoreToken(ctx, key, nil); err != nil {
+		c.logger.Error(fmt.Sprintf("unable to remove the cached token: %s", err.Error()))
+	}
+}
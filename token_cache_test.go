@@ -0,0 +1,210 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memorySecret is a SecretStore standing for a Kubernetes secret.
+type memorySecret struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (s *memorySecret) LoadSecret(context.Context) (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data, nil
+}
+
+func (s *memorySecret) StoreSecret(_ context.Context, data map[string][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = data
+	return nil
+}
+
+func TestTokenCaches(t *testing.T) {
+	caches := map[string]TokenCache{
+		"memory": NewMemoryTokenCache(),
+		"file":   NewFileTokenCache(filepath.Join(t.TempDir(), "tokens.json")),
+		"secret": NewSecretTokenCache(&memorySecret{}),
+	}
+	issuedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for name, cache := range caches {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			token, err := cache.LoadToken(ctx, "a")
+			assert.NoError(t, err)
+			assert.Nil(t, token)
+
+			assert.NoError(t, cache.StoreToken(ctx, "a", &CachedToken{Token: "token-a", IssuedAt: issuedAt}))
+			assert.NoError(t, cache.StoreToken(ctx, "b", &CachedToken{Token: "token-b"}))
+			token, err = cache.LoadToken(ctx, "a")
+			assert.NoError(t, err)
+			assert.Equal(t, &CachedToken{Token: "token-a", IssuedAt: issuedAt}, token)
+
+			assert.NoError(t, cache.StoreToken(ctx, "a", nil))
+			token, err = cache.LoadToken(ctx, "a")
+			assert.NoError(t, err)
+			assert.Nil(t, token)
+			token, err = cache.LoadToken(ctx, "b")
+			assert.NoError(t, err)
+			assert.Equal(t, "token-b", token.Token)
+		})
+	}
+}
+
+func TestFileTokenCacheConcurrentStores(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+
+	// separate caches open the file separately, as processes would
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := fmt.Sprintf("key-%d", i)
+			assert.NoError(t, NewFileTokenCache(path).StoreToken(context.Background(), key, &CachedToken{Token: key}))
+		}()
+	}
+	wg.Wait()
+
+	cache := NewFileTokenCache(path)
+	for i := 0; i < 20; i++ {
+		token, err := cache.LoadToken(context.Background(), fmt.Sprintf("key-%d", i))
+		assert.NoError(t, err)
+		assert.NotNil(t, token)
+	}
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = cache.LoadToken(context.Background(), "key-0")
+	assert.ErrorContains(t, err, "invalid token cache")
+}
+
+func TestClientTokenCache(t *testing.T) {
+	var logins int32
+	ts := newTokenTestServer(t, &logins)
+	defer ts.Close()
+
+	cache := NewMemoryTokenCache()
+	newClient := func() *Client {
+		client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+		assert.NoError(t, err)
+		client.SetTokenCache(cache)
+		_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+		assert.NoError(t, err)
+		return client
+	}
+
+	// the second client reuses the token of the first
+	first, second := newClient(), newClient()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+	assert.Equal(t, "token-1", second.GetToken())
+
+	// a login elsewhere revokes token-1; the first client to find it
+	// rejected logs in and the other one picks its token up from the cache
+	res, err := http.Get(ts.URL + "/api/login")
+	assert.NoError(t, err)
+	res.Body.Close()
+	_, err = first.GetSystems()
+	assert.NoError(t, err)
+	_, err = second.GetSystems()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&logins))
+	assert.Equal(t, "token-3", second.GetToken())
+
+	// tokens older than the configured lifetime are not reused
+	key, err := first.cachedTokenKey(context.Background(), first.config())
+	assert.NoError(t, err)
+	assert.NoError(t, cache.StoreToken(context.Background(), key, &CachedToken{Token: "token-3", IssuedAt: time.Now().Add(-2 * time.Hour)}))
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	client.SetTokenCache(cache)
+	_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password", TokenLifetime: time.Hour})
+	assert.NoError(t, err)
+	assert.Equal(t, "token-4", client.GetToken())
+
+	// another user has its own token
+	other, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	other.SetTokenCache(cache)
+	_, err = other.Authenticate(&ConfigConnect{Username: "monitor", Password: "password"})
+	assert.NoError(t, err)
+	assert.Equal(t, "token-5", other.GetToken())
+
+	// ended sessions are removed, unless the token was replaced already
+	first.forgetToken(context.Background(), "token-3")
+	cached, err := cache.LoadToken(context.Background(), key)
+	assert.NoError(t, err)
+	assert.Equal(t, "token-4", cached.Token)
+	first.forgetToken(context.Background(), "token-4")
+	cached, err = cache.LoadToken(context.Background(), key)
+	assert.NoError(t, err)
+	assert.Nil(t, cached)
+}
+
+func TestClientTokenCacheUnknownVersion(t *testing.T) {
+	var logins int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			atomic.AddInt32(&logins, 1)
+			w.Write([]byte(`"token"`))
+		case "/api/version":
+			w.Write([]byte(`"4.5"`))
+		case "/api/types/System/instances":
+			// a 4.x array only takes the token as a bearer token
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"Unauthorized","httpStatusCode":401,"errorCode":0}`))
+				return
+			}
+			assert.Equal(t, "application/json;version=4.5", r.Header.Get("Accept"))
+			w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	cache := NewMemoryTokenCache()
+	for i := 0; i < 2; i++ {
+		client, err := NewClientWithArgs(ts.URL, "", math.MaxInt64, true, false)
+		assert.NoError(t, err)
+		client.SetTokenCache(cache)
+		_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+		assert.NoError(t, err)
+		assert.Equal(t, "4.5", client.configConnect.Version)
+
+		_, err = client.GetSystems()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+}
@@ -0,0 +1,209 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTokenTestServer returns a server that issues a new token on every login
+// and rejects requests made with any other token.
+func newTokenTestServer(t *testing.T, logins *int32) *httptest.Server {
+	var mu sync.Mutex
+	current := ""
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			// slow enough for concurrent callers to pile up behind the login
+			time.Sleep(20 * time.Millisecond)
+			n := atomic.AddInt32(logins, 1)
+			mu.Lock()
+			current = fmt.Sprintf("token-%d", n)
+			mu.Unlock()
+			fmt.Fprintf(w, `"%s"`, current)
+		case "/api/types/System/instances":
+			_, token, _ := r.BasicAuth()
+			mu.Lock()
+			ok := token == current
+			mu.Unlock()
+			if !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"Unauthorized","httpStatusCode":401,"errorCode":0}`))
+				return
+			}
+			w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+}
+
+func TestConcurrentReauthentication(t *testing.T) {
+	var logins int32
+	ts := newTokenTestServer(t, &logins)
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+
+	// invalidate the session on the array
+	client.SetToken("expired")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 50)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.GetSystemsCtx(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&logins))
+	assert.Equal(t, "token-2", client.GetToken())
+}
+
+func TestLoginOutlivesCaller(t *testing.T) {
+	var logins int32
+	ts := newTokenTestServer(t, &logins)
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{Username: "admin", Password: "password"})
+	assert.NoError(t, err)
+	stale := client.GetToken()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- client.login(ctx, stale) }()
+	assert.Eventually(t, func() bool {
+		client.tokens.mu.Lock()
+		defer client.tokens.mu.Unlock()
+		return client.tokens.inflight != nil
+	}, time.Second, time.Millisecond)
+
+	// the caller that started the login gives up, the login carries on for
+	// the others
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	assert.NoError(t, client.login(context.Background(), stale))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&logins))
+	assert.Equal(t, "token-2", client.GetToken())
+}
+
+func TestAuthenticateDuringLogin(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/login" {
+			t.Errorf("unexpected path: %q", r.URL.Path)
+			return
+		}
+		// the old password is rejected, once the new one has been set
+		if _, password, _ := r.BasicAuth(); password == "old" {
+			<-release
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"Unauthorized","httpStatusCode":401,"errorCode":0}`))
+			return
+		}
+		w.Write([]byte(`"token-new"`))
+	}))
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+
+	oldErr := make(chan error, 1)
+	go func() {
+		_, err := client.Authenticate(&ConfigConnect{Username: "admin", Password: "old"})
+		oldErr <- err
+	}()
+	assert.Eventually(t, func() bool {
+		client.tokens.mu.Lock()
+		defer client.tokens.mu.Unlock()
+		return client.tokens.inflight != nil
+	}, time.Second, time.Millisecond)
+
+	// the new credentials are not merged into the login with the old ones
+	newErr := make(chan error, 1)
+	go func() {
+		_, err := client.Authenticate(&ConfigConnect{Username: "admin", Password: "new"})
+		newErr <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	assert.Error(t, <-oldErr)
+	assert.NoError(t, <-newErr)
+	assert.Equal(t, "token-new", client.GetToken())
+}
+
+func TestProactiveTokenRefresh(t *testing.T) {
+	var logins int32
+	ts := newTokenTestServer(t, &logins)
+	defer ts.Close()
+
+	client, err := NewClientWithArgs(ts.URL, "3.6", math.MaxInt64, true, false)
+	assert.NoError(t, err)
+	_, err = client.Authenticate(&ConfigConnect{
+		Username:           "admin",
+		Password:           "password",
+		TokenLifetime:      time.Hour,
+		TokenRefreshWindow: time.Minute,
+	})
+	assert.NoError(t, err)
+
+	_, err = client.GetSystems()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+
+	// pretend the token was issued just before the refresh window
+	client.tokens.tokenIssued(time.Now().Add(-time.Hour + 30*time.Second))
+
+	_, err = client.GetSystems()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&logins))
+	assert.Equal(t, "token-2", client.GetToken())
+}
+
+func TestTokenStateExpiring(t *testing.T) {
+	now := time.Now()
+	s := &tokenState{}
+	assert.False(t, s.expiring(now))
+
+	s.setLifetime(10*time.Minute, 0)
+	assert.False(t, s.expiring(now))
+
+	s.tokenIssued(now.Add(-8 * time.Minute))
+	assert.False(t, s.expiring(now))
+
+	s.tokenIssued(now.Add(-9 * time.Minute))
+	assert.True(t, s.expiring(now))
+}
@@ -13,20 +13,26 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // GetTreeQuota gets list of tree Quota
 func (s *System) GetTreeQuota() (treeQuotaList []types.TreeQuota, err error) {
-	defer TimeSpent("GetTreeQuota", time.Now())
+	return s.GetTreeQuotaCtx(s.client.callContext())
+}
+
+// GetTreeQuotaCtx gets list of tree Quota
+func (s *System) GetTreeQuotaCtx(ctx context.Context) (treeQuotaList []types.TreeQuota, err error) {
+	ctx, span := s.client.startSpan(ctx, "GetTreeQuota")
+	defer span.end()
 	path := "/rest/v1/file-tree-quotas?select=*"
 
-	err = s.client.getJSONWithRetry(
+	err = s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &treeQuotaList)
 	if err != nil {
 		return nil, err
@@ -37,10 +43,16 @@ func (s *System) GetTreeQuota() (treeQuotaList []types.TreeQuota, err error) {
 
 // GetTreeQuotaByID gets a specific tree quota by ID
 func (s *System) GetTreeQuotaByID(id string) (treeQuota *types.TreeQuota, err error) {
-	defer TimeSpent("GetTreeQuota", time.Now())
+	return s.GetTreeQuotaByIDCtx(s.client.callContext(), id)
+}
+
+// GetTreeQuotaByIDCtx gets a specific tree quota by ID
+func (s *System) GetTreeQuotaByIDCtx(ctx context.Context, id string) (treeQuota *types.TreeQuota, err error) {
+	ctx, span := s.client.startSpan(ctx, "GetTreeQuota")
+	defer span.end()
 	path := fmt.Sprintf("/rest/v1/file-tree-quotas/%s?select=*", id)
 
-	err = s.client.getJSONWithRetry(
+	err = s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &treeQuota)
 	if err != nil {
 		return nil, err
@@ -51,10 +63,18 @@ func (s *System) GetTreeQuotaByID(id string) (treeQuota *types.TreeQuota, err er
 
 // CreateTreeQuota create an tree quota for a File System.
 func (s *System) CreateTreeQuota(createParams *types.TreeQuotaCreate) (resp *types.TreeQuotaCreateResponse, err error) {
+	return s.CreateTreeQuotaCtx(s.client.callContext(), createParams)
+}
+
+// CreateTreeQuotaCtx create an tree quota for a File System.
+func (s *System) CreateTreeQuotaCtx(ctx context.Context, createParams *types.TreeQuotaCreate) (resp *types.TreeQuotaCreateResponse, err error) {
+	ctx, span := s.client.startSpan(ctx, "CreateTreeQuota")
+	defer span.end()
+
 	path := "/rest/v1/file-tree-quotas"
 
 	var body *types.TreeQuotaCreate = createParams
-	err = s.client.getJSONWithRetry(http.MethodPost, path, body, &resp)
+	err = s.client.getJSONWithRetry(ctx, http.MethodPost, path, body, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -64,10 +84,18 @@ func (s *System) CreateTreeQuota(createParams *types.TreeQuotaCreate) (resp *typ
 
 // ModifyTreeQuota modifies a tree quota
 func (s *System) ModifyTreeQuota(ModifyParams *types.TreeQuotaModify, id string) (err error) {
+	return s.ModifyTreeQuotaCtx(s.client.callContext(), ModifyParams, id)
+}
+
+// ModifyTreeQuotaCtx modifies a tree quota
+func (s *System) ModifyTreeQuotaCtx(ctx context.Context, ModifyParams *types.TreeQuotaModify, id string) (err error) {
+	ctx, span := s.client.startSpan(ctx, "ModifyTreeQuota")
+	defer span.end()
+
 	path := fmt.Sprintf("/rest/v1/file-tree-quotas/%s", id)
 
 	var body *types.TreeQuotaModify = ModifyParams
-	err = s.client.getJSONWithRetry(http.MethodPatch, path, body, nil)
+	err = s.client.getJSONWithRetry(ctx, http.MethodPatch, path, body, nil)
 	if err != nil {
 		return err
 	}
@@ -77,10 +105,16 @@ func (s *System) ModifyTreeQuota(ModifyParams *types.TreeQuotaModify, id string)
 
 // DeleteTreeQuota delete a tree quota by ID
 func (s *System) DeleteTreeQuota(id string) error {
-	defer TimeSpent("DeleteTreeQuota", time.Now())
+	return s.DeleteTreeQuotaCtx(s.client.callContext(), id)
+}
+
+// DeleteTreeQuotaCtx delete a tree quota by ID
+func (s *System) DeleteTreeQuotaCtx(ctx context.Context, id string) error {
+	ctx, span := s.client.startSpan(ctx, "DeleteTreeQuota")
+	defer span.end()
 	path := fmt.Sprintf("/rest/v1/file-tree-quotas/%s", id)
 
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodDelete, path, nil, nil)
 	if err != nil {
 		return err
@@ -91,8 +125,14 @@ func (s *System) DeleteTreeQuota(id string) error {
 
 // GetTreeQuotaByFSID gets a specific tree quota by filesystem ID
 func (s *System) GetTreeQuotaByFSID(id string) (*types.TreeQuota, error) {
-	defer TimeSpent("GetTreeQuotaByFSID", time.Now())
-	treeQuotaList, err := s.GetTreeQuota()
+	return s.GetTreeQuotaByFSIDCtx(s.client.callContext(), id)
+}
+
+// GetTreeQuotaByFSIDCtx gets a specific tree quota by filesystem ID
+func (s *System) GetTreeQuotaByFSIDCtx(ctx context.Context, id string) (*types.TreeQuota, error) {
+	ctx, span := s.client.startSpan(ctx, "GetTreeQuotaByFSID")
+	defer span.end()
+	treeQuotaList, err := s.GetTreeQuotaCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -376,7 +376,7 @@ func TestGetTreeQuota(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			client, err := NewClientWithArgs(tc.server.URL, "3.6", math.MaxInt64, true, false)
+			client, err := NewClientWithArgs(tc.server.URL, "4.0", math.MaxInt64, true, false)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -465,7 +465,7 @@ func TestGetTreeQuotaByFSID(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			client, err := NewClientWithArgs(tc.server.URL, "3.6", math.MaxInt64, true, false)
+			client, err := NewClientWithArgs(tc.server.URL, "4.0", math.MaxInt64, true, false)
 			if err != nil {
 				t.Fatal(err)
 			}
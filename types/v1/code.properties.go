@@ -25,7 +25,14 @@ package goscaleio
 
 // TranslateErrorCodeToErrorMessage translates an error code to error message
 func TranslateErrorCodeToErrorMessage(code string) string {
-	errorCodeTranslationMap := map[string]string{
+	return errorCodeTranslationMap[code]
+}
+
+// errorCodeTranslationMap maps the error codes of the array to their messages.
+var errorCodeTranslationMap = errorCodeTranslations()
+
+func errorCodeTranslations() map[string]string {
+	return map[string]string{
 		"ILLEGAL":                          "Illegal",
 		"ERROR":                            "Error",
 		"PENDING":                          "The operation will continue asynchronously",
@@ -1137,6 +1144,4 @@ func TranslateErrorCodeToErrorMessage(code string) string {
 		"PHOENIX_WORKLOAD_NAME_TOO_LONG":                   "The provided workload name is too long. Max size is 18 characters.",
 		"GUI_OFFLINE_MODE_COMMANDS_NOT_SUPPORTED":          "Commands are not supported in Offline Mode",
 	}
-
-	return errorCodeTranslationMap[code]
 }
@@ -0,0 +1,213 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Errors that the errors returned by the array and the gateway match with
+// errors.Is, e.g. errors.Is(err, ErrNotFound).
+var (
+	// ErrNotFound is returned when the object does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists is returned when an object with the same name or
+	// settings exists.
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrAlreadyMapped is returned when the volume is already mapped.
+	ErrAlreadyMapped = errors.New("already mapped")
+	// ErrNotMapped is returned when the volume is not mapped.
+	ErrNotMapped = errors.New("not mapped")
+	// ErrUnauthorized is returned when the credentials are rejected.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is returned when the user may not perform the operation.
+	ErrForbidden = errors.New("forbidden")
+	// ErrVersionUnsupported is returned when the operation is not supported
+	// by the version of the array.
+	ErrVersionUnsupported = errors.New("version unsupported")
+)
+
+// Is reports whether e matches target, one of the errors above. The error is
+// classified by its numeric ErrorCode, then by its detail codes, then by its
+// message, using the messages of TranslateErrorCodeToErrorMessage, and then
+// by its HTTP status code.
+func (e Error) Is(target error) bool {
+	return target != nil && e.sentinel() == target
+}
+
+func (e Error) sentinel() error {
+	if err := errorForCode(errorCodeNames[e.ErrorCode]); err != nil {
+		return err
+	}
+	for _, d := range e.ErrorDetails {
+		if err := errorForCode(d.Error); err != nil {
+			return err
+		}
+		if err := errorForMessage(d.ErrorMessage); err != nil {
+			return err
+		}
+	}
+	if err := errorForMessage(e.Message); err != nil {
+		return err
+	}
+	return errorForStatus(e.HTTPStatusCode)
+}
+
+// ToError returns the failure described by r, typically the reply to a
+// rejected installer request, as an *Error.
+func (r *GatewayResponse) ToError() *Error {
+	return &Error{
+		Message:        r.Message,
+		HTTPStatusCode: r.StatusCode,
+		ErrorCode:      r.ErrorCode,
+	}
+}
+
+// ToError returns the failure described by r as an *Error. Its message is the
+// first display message, as shown by the gateway.
+func (r *ServiceFailedResponse) ToError() *Error {
+	message := r.DetailMessage
+	if len(r.Messages) > 0 {
+		message = r.Messages[0].DisplayMessage
+	}
+	if message == "" {
+		message = r.Error
+	}
+
+	status := r.StatusCode
+	if status == 0 {
+		status = r.Status
+	}
+	return &Error{
+		Message:        message,
+		HTTPStatusCode: status,
+	}
+}
+
+// errorCodeNames names the numeric error codes of the array that errorForCode
+// classifies, e.g. 79 is "VOL_NOT_FOUND". TestErrorCodes pins each code to
+// the error it matches.
+var errorCodeNames = map[int]string{
+	3:   "NOT_FOUND",
+	7:   "ALREADY_EXISTS",
+	47:  "MIGRATE_NOT_FOUND",
+	78:  "TGT_NOT_FOUND",
+	79:  "VOL_NOT_FOUND",
+	80:  "INI_NOT_FOUND",
+	81:  "VOL_ALREADY_MAPPED_TO_THIS_INI",
+	82:  "VOL_ALREADY_MAPPED_TO_ALL_INIS",
+	83:  "VOL_ALREADY_MAPPED_TO_SCSI",
+	84:  "VOL_NOT_MAPPED_TO_INI",
+	85:  "VOL_NOT_MAPPED_TO_SCSI",
+	91:  "HOST_NOT_FOUND",
+	98:  "TGT_NAME_IN_USE",
+	99:  "VOL_NAME_IN_USE",
+	116: "VIRTUAL_IP_ALREADY_EXIST",
+	126: "TGT_DEVICE_NOT_FOUND",
+	141: "FD_NAME_IN_USE",
+	142: "FD_NOT_FOUND",
+	148: "NET_TEST_NOT_FOUND",
+	151: "SCSI_WITH_NAME_ALREADY_EXISTS",
+	155: "SCSI_INITIATOR_NOT_FOUND",
+	168: "NO_PERMISSIONS",
+	170: "STORAGE_POOL_ALREADY_EXISTS",
+	171: "STORAGE_POOL_NOT_FOUND",
+	173: "STORAGE_POOL_NAME_ALREADY_EXISTS",
+	193: "TGT_IP_NOT_FOUND",
+	200: "TGT_IP_ALREADY_EXISTS",
+	206: "DEV_NAME_ALREADY_EXISTS",
+	210: "SECONDARY_MDM_ALREADY_EXISTS",
+	222: "FD_ALREADY_EXISTS",
+	234: "USER_NOT_FOUND",
+	247: "PERMISSION_DENIED",
+	250: "USER_ALREADY_EXIST",
+	264: "FAULT_SET_ALREADY_EXISTS",
+	265: "FAULT_SET_NOT_FOUND",
+	266: "FAULT_SET_NAME_ALREADY_EXISTS",
+	288: "NOT_SUPPORTED",
+	315: "VOL_ALREADY_MAPPED_TO_AN_INI",
+	325: "VOTER_ALREADY_EXISTS",
+	327: "REMOTE_SYSLOG_CLIENT_NOT_FOUND",
+	328: "IP_ALREADY_EXISTS",
+	329: "MANAGER_ID_ALREADY_EXISTS",
+	346: "PORT_ALREADY_EXISTS",
+}
+
+// errorForCode classifies an error code of the array, such as
+// "VOL_ALREADY_MAPPED_TO_THIS_INI".
+func errorForCode(code string) error {
+	switch {
+	case code == "":
+		return nil
+	case strings.Contains(code, "ALREADY_MAPPED"):
+		return ErrAlreadyMapped
+	case strings.Contains(code, "NOT_MAPPED"):
+		return ErrNotMapped
+	case code == "NOT_FOUND" || strings.HasSuffix(code, "_NOT_FOUND"):
+		return ErrNotFound
+	case strings.HasSuffix(code, "ALREADY_EXISTS") || strings.HasSuffix(code, "ALREADY_EXIST") ||
+		strings.HasSuffix(code, "_NAME_IN_USE"):
+		return ErrAlreadyExists
+	case code == "PERMISSION_DENIED" || code == "LDAP_AUTHENTICATION_FAIL" ||
+		strings.HasSuffix(code, "INVALID_CREDENTIALS") || strings.HasSuffix(code, "WRONG_CREDENTIALS"):
+		return ErrUnauthorized
+	case code == "NO_PERMISSIONS":
+		return ErrForbidden
+	case code == "NOT_SUPPORTED" || code == "VERSION_TOO_NEW" || code == "VERSION_TOO_OLD":
+		return ErrVersionUnsupported
+	}
+	return nil
+}
+
+var (
+	messageErrorsOnce sync.Once
+	messageErrors     map[string]error
+)
+
+// errorForMessage classifies an error message of the array by the code it is
+// the translation of. Messages shared by codes of different classes are not
+// classified.
+func errorForMessage(message string) error {
+	messageErrorsOnce.Do(func() {
+		messageErrors = map[string]error{}
+		ambiguous := map[string]bool{}
+		for code, msg := range errorCodeTranslationMap {
+			err := errorForCode(code)
+			if prev, ok := messageErrors[msg]; ok && prev != err {
+				ambiguous[msg] = true
+			}
+			messageErrors[msg] = err
+		}
+		for msg := range ambiguous {
+			delete(messageErrors, msg)
+		}
+	})
+	return messageErrors[message]
+}
+
+func errorForStatus(status int) error {
+	switch status {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrAlreadyExists
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goscaleio
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorIs(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want error
+	}{
+		"numeric code": {
+			&Error{Message: "Only a single mapping is allowed", HTTPStatusCode: http.StatusInternalServerError, ErrorCode: 81},
+			ErrAlreadyMapped,
+		},
+		"numeric code over message": {
+			&Error{Message: "Not found", HTTPStatusCode: http.StatusInternalServerError, ErrorCode: 84},
+			ErrNotMapped,
+		},
+		"detail code": {
+			&Error{Message: errorWithDetails, ErrorDetails: []ErrorMessageDetails{{Error: "VOL_ALREADY_MAPPED_TO_THIS_INI"}}},
+			ErrAlreadyMapped,
+		},
+		"detail message": {
+			&Error{Message: errorWithDetails, ErrorDetails: []ErrorMessageDetails{{ErrorMessage: "The volume is not mapped to SDC"}}},
+			ErrNotMapped,
+		},
+		"message": {
+			&Error{Message: "Could not find the volume", HTTPStatusCode: http.StatusInternalServerError},
+			ErrNotFound,
+		},
+		"generic not found": {&Error{Message: "Not found"}, ErrNotFound},
+		"already exists":    {&Error{Message: "Storage Pool name already exists"}, ErrAlreadyExists},
+		"name in use":       {&Error{Message: "Volume name already in use. Please use a different name."}, ErrAlreadyExists},
+		"permission denied": {Error{ErrorDetails: []ErrorMessageDetails{{Error: "PERMISSION_DENIED"}}}, ErrUnauthorized},
+		"no permission":     {&Error{ErrorDetails: []ErrorMessageDetails{{Error: "NO_PERMISSIONS"}}}, ErrForbidden},
+		"not supported":     {&Error{Message: "This command is not supported."}, ErrVersionUnsupported},
+		"status":            {&Error{Message: "Request failed", HTTPStatusCode: http.StatusUnauthorized}, ErrUnauthorized},
+		"wrapped":           {fmt.Errorf("deleting volume: %w", &Error{HTTPStatusCode: http.StatusNotFound}), ErrNotFound},
+		"unclassified":      {&Error{Message: "Invalid", HTTPStatusCode: http.StatusBadRequest}, nil},
+	}
+
+	sentinels := []error{
+		ErrNotFound, ErrAlreadyExists, ErrAlreadyMapped, ErrNotMapped,
+		ErrUnauthorized, ErrForbidden, ErrVersionUnsupported,
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, sentinel := range sentinels {
+				assert.Equal(t, sentinel == tc.want, errors.Is(tc.err, sentinel), sentinel.Error())
+			}
+		})
+	}
+}
+
+func TestGatewayErrors(t *testing.T) {
+	err := (&GatewayResponse{Message: "Package not found", StatusCode: http.StatusNotFound}).ToError()
+	assert.EqualError(t, err, "Package not found")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	failed := &ServiceFailedResponse{
+		Status:   http.StatusConflict,
+		Messages: []Messages{{DisplayMessage: "Deployment name already in use"}},
+	}
+	assert.EqualError(t, failed.ToError(), "Deployment name already in use")
+	assert.ErrorIs(t, failed.ToError(), ErrAlreadyExists)
+
+	failed = &ServiceFailedResponse{Error: "Forbidden", StatusCode: http.StatusForbidden}
+	assert.EqualError(t, failed.ToError(), "Forbidden")
+	assert.ErrorIs(t, failed.ToError(), ErrForbidden)
+}
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		code     int
+		name     string
+		expected error
+	}{
+		{3, "NOT_FOUND", ErrNotFound},
+		{7, "ALREADY_EXISTS", ErrAlreadyExists},
+		{47, "MIGRATE_NOT_FOUND", ErrNotFound},
+		{78, "TGT_NOT_FOUND", ErrNotFound},
+		{79, "VOL_NOT_FOUND", ErrNotFound},
+		{80, "INI_NOT_FOUND", ErrNotFound},
+		{81, "VOL_ALREADY_MAPPED_TO_THIS_INI", ErrAlreadyMapped},
+		{82, "VOL_ALREADY_MAPPED_TO_ALL_INIS", ErrAlreadyMapped},
+		{83, "VOL_ALREADY_MAPPED_TO_SCSI", ErrAlreadyMapped},
+		{84, "VOL_NOT_MAPPED_TO_INI", ErrNotMapped},
+		{85, "VOL_NOT_MAPPED_TO_SCSI", ErrNotMapped},
+		{91, "HOST_NOT_FOUND", ErrNotFound},
+		{98, "TGT_NAME_IN_USE", ErrAlreadyExists},
+		{99, "VOL_NAME_IN_USE", ErrAlreadyExists},
+		{116, "VIRTUAL_IP_ALREADY_EXIST", ErrAlreadyExists},
+		{126, "TGT_DEVICE_NOT_FOUND", ErrNotFound},
+		{141, "FD_NAME_IN_USE", ErrAlreadyExists},
+		{142, "FD_NOT_FOUND", ErrNotFound},
+		{148, "NET_TEST_NOT_FOUND", ErrNotFound},
+		{151, "SCSI_WITH_NAME_ALREADY_EXISTS", ErrAlreadyExists},
+		{155, "SCSI_INITIATOR_NOT_FOUND", ErrNotFound},
+		{168, "NO_PERMISSIONS", ErrForbidden},
+		{170, "STORAGE_POOL_ALREADY_EXISTS", ErrAlreadyExists},
+		{171, "STORAGE_POOL_NOT_FOUND", ErrNotFound},
+		{173, "STORAGE_POOL_NAME_ALREADY_EXISTS", ErrAlreadyExists},
+		{193, "TGT_IP_NOT_FOUND", ErrNotFound},
+		{200, "TGT_IP_ALREADY_EXISTS", ErrAlreadyExists},
+		{206, "DEV_NAME_ALREADY_EXISTS", ErrAlreadyExists},
+		{210, "SECONDARY_MDM_ALREADY_EXISTS", ErrAlreadyExists},
+		{222, "FD_ALREADY_EXISTS", ErrAlreadyExists},
+		{234, "USER_NOT_FOUND", ErrNotFound},
+		{247, "PERMISSION_DENIED", ErrUnauthorized},
+		{250, "USER_ALREADY_EXIST", ErrAlreadyExists},
+		{264, "FAULT_SET_ALREADY_EXISTS", ErrAlreadyExists},
+		{265, "FAULT_SET_NOT_FOUND", ErrNotFound},
+		{266, "FAULT_SET_NAME_ALREADY_EXISTS", ErrAlreadyExists},
+		{288, "NOT_SUPPORTED", ErrVersionUnsupported},
+		{315, "VOL_ALREADY_MAPPED_TO_AN_INI", ErrAlreadyMapped},
+		{325, "VOTER_ALREADY_EXISTS", ErrAlreadyExists},
+		{327, "REMOTE_SYSLOG_CLIENT_NOT_FOUND", ErrNotFound},
+		{328, "IP_ALREADY_EXISTS", ErrAlreadyExists},
+		{329, "MANAGER_ID_ALREADY_EXISTS", ErrAlreadyExists},
+		{346, "PORT_ALREADY_EXISTS", ErrAlreadyExists},
+	}
+
+	assert.Len(t, errorCodeNames, len(tests))
+	for _, tt := range tests {
+		assert.Equal(t, tt.name, errorCodeNames[tt.code], tt.code)
+
+		// the code alone, with a message that classifies as nothing
+		err := &Error{Message: "failure", HTTPStatusCode: http.StatusInternalServerError, ErrorCode: tt.code}
+		assert.ErrorIs(t, err, tt.expected, tt.code)
+	}
+}
+
+func TestErrorCodeNames(t *testing.T) {
+	for code, name := range errorCodeNames {
+		assert.NotEmpty(t, TranslateErrorCodeToErrorMessage(name), code)
+		assert.NotNil(t, errorForCode(name), name)
+	}
+}
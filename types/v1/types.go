@@ -52,7 +52,6 @@ type Error struct {
 
 func (e Error) Error() string {
 	if e.Message == errorWithDetails && len(e.ErrorDetails) > 0 {
-		fmt.Printf("goscaleio.Error Error with details  %#v\n", e)
 		if e.ErrorDetails[0].ErrorMessage != "" {
 			e.Message = e.ErrorDetails[0].ErrorMessage
 			return e.ErrorDetails[0].ErrorMessage
@@ -213,6 +212,31 @@ type BWC struct {
 	NumSeconds      int `json:"numSeconds"`
 }
 
+// IOPS returns the operations per second counted by b.
+func (b BWC) IOPS() float64 {
+	if b.NumSeconds == 0 {
+		return 0
+	}
+	return float64(b.NumOccured) / float64(b.NumSeconds)
+}
+
+// BytesPerSecond returns the bandwidth counted by b.
+func (b BWC) BytesPerSecond() float64 {
+	if b.NumSeconds == 0 {
+		return 0
+	}
+	return float64(b.TotalWeightInKb) * 1024 / float64(b.NumSeconds)
+}
+
+// AverageLatency returns the mean latency counted by b, a latency counter
+// such as UserDataSdcReadLatency whose weight is in microseconds.
+func (b BWC) AverageLatency() time.Duration {
+	if b.NumOccured == 0 {
+		return 0
+	}
+	return time.Duration(b.TotalWeightInKb) * time.Microsecond / time.Duration(b.NumOccured)
+}
+
 // Statistics defines struct of Statistics for PowerFlex Array
 type Statistics struct {
 	PrimaryReadFromDevBwc                    BWC `json:"primaryReadFromDevBwc"`
@@ -20,6 +20,7 @@ package goscaleio
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -69,3 +70,14 @@ func TestError(t *testing.T) {
 	}
 	assert.EqualError(t, e, "message2")
 }
+
+func TestBWC(t *testing.T) {
+	b := BWC{TotalWeightInKb: 4000, NumOccured: 100, NumSeconds: 5}
+	assert.Equal(t, 20.0, b.IOPS())
+	assert.Equal(t, 4000.0*1024/5, b.BytesPerSecond())
+	assert.Equal(t, 40*time.Microsecond, b.AverageLatency())
+
+	assert.Zero(t, BWC{}.IOPS())
+	assert.Zero(t, BWC{}.BytesPerSecond())
+	assert.Zero(t, BWC{}.AverageLatency())
+}
@@ -13,7 +13,7 @@
 package goscaleio
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -25,33 +25,28 @@ import (
 
 // UploadCompliance function is used for uploading the compliance file.
 func (gc *GatewayClient) UploadCompliance(uploadComplianceParam *types.UploadComplianceParam) (*types.UploadComplianceTopologyDetails, error) {
+	return gc.UploadComplianceCtx(context.Background(), uploadComplianceParam)
+}
+
+// UploadComplianceCtx function is used for uploading the compliance file.
+func (gc *GatewayClient) UploadComplianceCtx(ctx context.Context, uploadComplianceParam *types.UploadComplianceParam) (*types.UploadComplianceTopologyDetails, error) {
+	ctx, span := gc.startSpan(ctx, "UploadCompliance")
+	defer span.end()
+
 	var uploadResponse types.UploadComplianceTopologyDetails
 	jsonData, err := json.Marshal(uploadComplianceParam)
 	if err != nil {
 		return &uploadResponse, err
 	}
 
-	req, httpError := http.NewRequest(http.MethodPost, gc.host+"/Api/V1/FirmwareRepository", bytes.NewBuffer(jsonData))
-	if httpError != nil {
-		return &uploadResponse, httpError
-	}
-
-	req.Header.Set("Authorization", "Bearer "+gc.token)
-	setCookieError := setCookie(req.Header, gc.host)
-	if setCookieError != nil {
-		return nil, fmt.Errorf("Error While Handling Cookie: %s", setCookieError)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &uploadResponse, httpRespError
-	}
-
-	responseString, err := extractString(httpResp)
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodPost,
+		path:   "/Api/V1/FirmwareRepository",
+		body:   jsonData,
+		auth:   authToken,
+	})
 	if err != nil {
-		return &uploadResponse, fmt.Errorf("Error Extracting Response: %s", err)
+		return &uploadResponse, err
 	}
 
 	if httpResp.StatusCode != http.StatusCreated {
@@ -62,11 +57,6 @@ func (gc *GatewayClient) UploadCompliance(uploadComplianceParam *types.UploadCom
 		return &uploadResponse, fmt.Errorf("Error while uploading Compliance File")
 	}
 
-	err = storeCookie(httpResp.Header, gc.host)
-	if err != nil {
-		return &uploadResponse, fmt.Errorf("Error While Storing cookie: %s", err)
-	}
-
 	err = json.Unmarshal([]byte(responseString), &uploadResponse)
 	if err != nil {
 		return &uploadResponse, fmt.Errorf("Error getting upload compliance details: %s", err)
@@ -77,37 +67,30 @@ func (gc *GatewayClient) UploadCompliance(uploadComplianceParam *types.UploadCom
 
 // GetUploadComplianceDetails function is used for getting the details of the compliance upload
 func (gc *GatewayClient) GetUploadComplianceDetails(id string, newToken bool) (*types.UploadComplianceTopologyDetails, error) {
+	return gc.GetUploadComplianceDetailsCtx(context.Background(), id, newToken)
+}
+
+// GetUploadComplianceDetailsCtx function is used for getting the details of the compliance upload
+func (gc *GatewayClient) GetUploadComplianceDetailsCtx(ctx context.Context, id string, newToken bool) (*types.UploadComplianceTopologyDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetUploadComplianceDetails")
+	defer span.end()
+
 	var getUploadCompResponse types.UploadComplianceTopologyDetails
-	var err error
 	if newToken {
-		token, err := gc.NewTokenGeneration()
+		token, err := gc.NewTokenGenerationCtx(ctx)
 		if err != nil {
 			return nil, err
 		}
-		gc.token = token
-	}
-
-	req, httpError := http.NewRequest(http.MethodGet, gc.host+"/Api/V1/FirmwareRepository/"+id, nil)
-	if httpError != nil {
-		return &getUploadCompResponse, httpError
-	}
-
-	req.Header.Set("Authorization", "Bearer "+gc.token)
-	setCookieError := setCookie(req.Header, gc.host)
-	if setCookieError != nil {
-		return nil, fmt.Errorf("Error While Handling Cookie: %s", setCookieError)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &getUploadCompResponse, httpRespError
+		gc.setToken(token)
 	}
 
-	responseString, err := extractString(httpResp)
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   "/Api/V1/FirmwareRepository/" + id,
+		auth:   authToken,
+	})
 	if err != nil {
-		return &getUploadCompResponse, fmt.Errorf("Error Extracting Response: %s", err)
+		return &getUploadCompResponse, err
 	}
 
 	if httpResp.StatusCode != http.StatusOK {
@@ -118,11 +101,6 @@ func (gc *GatewayClient) GetUploadComplianceDetails(id string, newToken bool) (*
 		return &getUploadCompResponse, fmt.Errorf("Error Getting Compliance Details")
 	}
 
-	err3 := storeCookie(httpResp.Header, gc.host)
-	if err3 != nil {
-		return &getUploadCompResponse, fmt.Errorf("Error While Storing cookie: %s", err3)
-	}
-
 	err = json.Unmarshal([]byte(responseString), &getUploadCompResponse)
 	if err != nil {
 		return &getUploadCompResponse, fmt.Errorf("Error getting upload compliance details: %s", err)
@@ -133,62 +111,49 @@ func (gc *GatewayClient) GetUploadComplianceDetails(id string, newToken bool) (*
 
 // ApproveUnsignedFile is used for approving the unsigned file to upload
 func (gc *GatewayClient) ApproveUnsignedFile(id string) error {
-	jsonData := []byte(`{}`)
-
-	req, httpError := http.NewRequest("PUT", gc.host+"/Api/V1/FirmwareRepository/"+id+"/allowunsignedfile", bytes.NewBuffer(jsonData))
-	if httpError != nil {
-		return httpError
-	}
-
-	req.Header.Set("Authorization", "Bearer "+gc.token)
-	setCookieError := setCookie(req.Header, gc.host)
-	if setCookieError != nil {
-		return fmt.Errorf("Error While Handling Cookie: %s", setCookieError)
-	}
-	req.Header.Set("Content-Type", "application/json")
+	return gc.ApproveUnsignedFileCtx(context.Background(), id)
+}
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return httpRespError
+// ApproveUnsignedFileCtx is used for approving the unsigned file to upload
+func (gc *GatewayClient) ApproveUnsignedFileCtx(ctx context.Context, id string) error {
+	ctx, span := gc.startSpan(ctx, "ApproveUnsignedFile")
+	defer span.end()
+
+	httpResp, _, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodPut,
+		path:   "/Api/V1/FirmwareRepository/" + id + "/allowunsignedfile",
+		body:   []byte(`{}`),
+		auth:   authToken,
+	})
+	if err != nil {
+		return err
 	}
 
 	if httpResp.StatusCode != http.StatusNoContent {
 		return fmt.Errorf("Error while approving the unsigned Compliance file")
 	}
 
-	err3 := storeCookie(httpResp.Header, gc.host)
-	if err3 != nil {
-		return fmt.Errorf("Error While Storing cookie: %s", err3)
-	}
-
 	return nil
 }
 
 // GetAllUploadComplianceDetails returns all the firmware repository
 func (gc *GatewayClient) GetAllUploadComplianceDetails() (*[]types.UploadComplianceTopologyDetails, error) {
-	var getUploadCompResponse []types.UploadComplianceTopologyDetails
-	req, httpError := http.NewRequest("GET", gc.host+"/Api/V1/FirmwareRepository/", nil)
-	if httpError != nil {
-		return &getUploadCompResponse, httpError
-	}
-
-	req.Header.Set("Authorization", "Bearer "+gc.token)
-	setCookieError := setCookie(req.Header, gc.host)
-	if setCookieError != nil {
-		return nil, fmt.Errorf("Error While Handling Cookie: %s", setCookieError)
-	}
-	req.Header.Set("Content-Type", "application/json")
+	return gc.GetAllUploadComplianceDetailsCtx(context.Background())
+}
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &getUploadCompResponse, httpRespError
-	}
+// GetAllUploadComplianceDetailsCtx returns all the firmware repository
+func (gc *GatewayClient) GetAllUploadComplianceDetailsCtx(ctx context.Context) (*[]types.UploadComplianceTopologyDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetAllUploadComplianceDetails")
+	defer span.end()
 
-	responseString, err := extractString(httpResp)
+	var getUploadCompResponse []types.UploadComplianceTopologyDetails
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   "/Api/V1/FirmwareRepository/",
+		auth:   authToken,
+	})
 	if err != nil {
-		return &getUploadCompResponse, fmt.Errorf("Error Extracting Response: %s", err)
+		return &getUploadCompResponse, err
 	}
 
 	if httpResp.StatusCode != http.StatusOK {
@@ -199,11 +164,6 @@ func (gc *GatewayClient) GetAllUploadComplianceDetails() (*[]types.UploadComplia
 		return &getUploadCompResponse, fmt.Errorf("Error Getting Compliance Details")
 	}
 
-	err3 := storeCookie(httpResp.Header, gc.host)
-	if err3 != nil {
-		return &getUploadCompResponse, fmt.Errorf("Error While Storing cookie: %s", err3)
-	}
-
 	err = json.Unmarshal([]byte(responseString), &getUploadCompResponse)
 	if err != nil {
 		return &getUploadCompResponse, fmt.Errorf("Error getting upload compliance details: %s", err)
@@ -214,7 +174,15 @@ func (gc *GatewayClient) GetAllUploadComplianceDetails() (*[]types.UploadComplia
 
 // GetUploadComplianceDetailsUsingFilter filters the firmware repository based on name
 func (gc *GatewayClient) GetUploadComplianceDetailsUsingFilter(name string) (*types.UploadComplianceTopologyDetails, error) {
-	frDetails, err := gc.GetAllUploadComplianceDetails()
+	return gc.GetUploadComplianceDetailsUsingFilterCtx(context.Background(), name)
+}
+
+// GetUploadComplianceDetailsUsingFilterCtx filters the firmware repository based on name
+func (gc *GatewayClient) GetUploadComplianceDetailsUsingFilterCtx(ctx context.Context, name string) (*types.UploadComplianceTopologyDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetUploadComplianceDetailsUsingFilter")
+	defer span.end()
+
+	frDetails, err := gc.GetAllUploadComplianceDetailsCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -229,9 +197,17 @@ func (gc *GatewayClient) GetUploadComplianceDetailsUsingFilter(name string) (*ty
 
 // GetUploadComplianceDetailsUsingID returns all the details of the firmware repository using ID
 func (gc *GatewayClient) GetUploadComplianceDetailsUsingID(id string) (*types.FirmwareRepositoryDetails, error) {
+	return gc.GetUploadComplianceDetailsUsingIDCtx(context.Background(), id)
+}
+
+// GetUploadComplianceDetailsUsingIDCtx returns all the details of the firmware repository using ID
+func (gc *GatewayClient) GetUploadComplianceDetailsUsingIDCtx(ctx context.Context, id string) (*types.FirmwareRepositoryDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetUploadComplianceDetailsUsingID")
+	defer span.end()
+
 	var frResponse types.FirmwareRepositoryDetails
 
-	u, err := url.Parse(gc.host + "/Api/V1/FirmwareRepository/" + id)
+	u, err := url.Parse("/Api/V1/FirmwareRepository/" + id)
 	if err != nil {
 		return &frResponse, err
 	}
@@ -239,27 +215,13 @@ func (gc *GatewayClient) GetUploadComplianceDetailsUsingID(id string) (*types.Fi
 	q.Set("components", "true")
 	u.RawQuery = q.Encode()
 
-	req, httpError := http.NewRequest("GET", u.String(), nil)
-	if httpError != nil {
-		return &frResponse, httpError
-	}
-
-	req.Header.Set("Authorization", "Bearer "+gc.token)
-	setCookieError := setCookie(req.Header, gc.host)
-	if setCookieError != nil {
-		return nil, fmt.Errorf("Error While Handling Cookie: %s", setCookieError)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return &frResponse, httpRespError
-	}
-
-	responseString, err := extractString(httpResp)
+	httpResp, responseString, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodGet,
+		path:   u.String(),
+		auth:   authToken,
+	})
 	if err != nil {
-		return &frResponse, fmt.Errorf("Error Extracting Response: %s", err)
+		return &frResponse, err
 	}
 
 	if httpResp.StatusCode != http.StatusOK {
@@ -270,11 +232,6 @@ func (gc *GatewayClient) GetUploadComplianceDetailsUsingID(id string) (*types.Fi
 		return &frResponse, fmt.Errorf("Error Getting Compliance Details")
 	}
 
-	err3 := storeCookie(httpResp.Header, gc.host)
-	if err3 != nil {
-		return &frResponse, fmt.Errorf("Error While Storing cookie: %s", err3)
-	}
-
 	err = json.Unmarshal([]byte(responseString), &frResponse)
 	if err != nil {
 		return &frResponse, fmt.Errorf("Error getting upload compliance details: %s", err)
@@ -285,14 +242,22 @@ func (gc *GatewayClient) GetUploadComplianceDetailsUsingID(id string) (*types.Fi
 
 // GetFirmwareRepositoryDetailsUsingName returns all the details of the firmware repository using name
 func (gc *GatewayClient) GetFirmwareRepositoryDetailsUsingName(name string) (*types.FirmwareRepositoryDetails, error) {
+	return gc.GetFirmwareRepositoryDetailsUsingNameCtx(context.Background(), name)
+}
+
+// GetFirmwareRepositoryDetailsUsingNameCtx returns all the details of the firmware repository using name
+func (gc *GatewayClient) GetFirmwareRepositoryDetailsUsingNameCtx(ctx context.Context, name string) (*types.FirmwareRepositoryDetails, error) {
+	ctx, span := gc.startSpan(ctx, "GetFirmwareRepositoryDetailsUsingName")
+	defer span.end()
+
 	var fr *types.UploadComplianceTopologyDetails
 	var frDetails *types.FirmwareRepositoryDetails
 	var err error
-	fr, err = gc.GetUploadComplianceDetailsUsingFilter(name)
+	fr, err = gc.GetUploadComplianceDetailsUsingFilterCtx(ctx, name)
 	if err != nil {
 		return frDetails, err
 	}
-	frDetails, err = gc.GetUploadComplianceDetailsUsingID(fr.ID)
+	frDetails, err = gc.GetUploadComplianceDetailsUsingIDCtx(ctx, fr.ID)
 	if err != nil {
 		return frDetails, err
 	}
@@ -301,69 +266,58 @@ func (gc *GatewayClient) GetFirmwareRepositoryDetailsUsingName(name string) (*ty
 
 // DeleteFirmwareRepository deletes the particular firmware repository
 func (gc *GatewayClient) DeleteFirmwareRepository(id string) error {
-	req, httpError := http.NewRequest(http.MethodDelete, gc.host+"/Api/V1/FirmwareRepository/"+id, nil)
-	if httpError != nil {
-		return httpError
-	}
+	return gc.DeleteFirmwareRepositoryCtx(context.Background(), id)
+}
 
-	req.Header.Set("Authorization", "Bearer "+gc.token)
-	setCookieError := setCookie(req.Header, gc.host)
-	if setCookieError != nil {
-		return fmt.Errorf("Error While Handling Cookie: %s", setCookieError)
-	}
-	req.Header.Set("Content-Type", "application/json")
+// DeleteFirmwareRepositoryCtx deletes the particular firmware repository
+func (gc *GatewayClient) DeleteFirmwareRepositoryCtx(ctx context.Context, id string) error {
+	ctx, span := gc.startSpan(ctx, "DeleteFirmwareRepository")
+	defer span.end()
 
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return httpRespError
+	httpResp, _, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodDelete,
+		path:   "/Api/V1/FirmwareRepository/" + id,
+		auth:   authToken,
+	})
+	if err != nil {
+		return err
 	}
 
 	if httpResp.StatusCode != http.StatusNoContent {
 		return fmt.Errorf("Error while deleting firmware repository")
 	}
 
-	err3 := storeCookie(httpResp.Header, gc.host)
-	if err3 != nil {
-		return fmt.Errorf("Error While Storing cookie: %s", err3)
-	}
-
 	return nil
 }
 
 // TestConnection tests the connection to the source location.
 func (gc *GatewayClient) TestConnection(uploadComplianceParam *types.UploadComplianceParam) error {
+	return gc.TestConnectionCtx(context.Background(), uploadComplianceParam)
+}
+
+// TestConnectionCtx tests the connection to the source location.
+func (gc *GatewayClient) TestConnectionCtx(ctx context.Context, uploadComplianceParam *types.UploadComplianceParam) error {
+	ctx, span := gc.startSpan(ctx, "TestConnection")
+	defer span.end()
+
 	jsonData, err := json.Marshal(uploadComplianceParam)
 	if err != nil {
 		return err
 	}
 
-	req, httpError := http.NewRequest(http.MethodPost, gc.host+"/Api/V1/FirmwareRepository/connection", bytes.NewBuffer(jsonData))
-	if httpError != nil {
-		return httpError
-	}
-
-	req.Header.Set("Authorization", "Bearer "+gc.token)
-	setCookieError := setCookie(req.Header, gc.host)
-	if setCookieError != nil {
-		return fmt.Errorf("Error While Handling Cookie: %s", setCookieError)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := gc.http
-	httpResp, httpRespError := client.Do(req)
-	if httpRespError != nil {
-		return httpRespError
+	httpResp, _, err := gc.do(ctx, gatewayRequest{
+		method: http.MethodPost,
+		path:   "/Api/V1/FirmwareRepository/connection",
+		body:   jsonData,
+		auth:   authToken,
+	})
+	if err != nil {
+		return err
 	}
 
 	if httpResp.StatusCode != http.StatusNoContent {
 		return fmt.Errorf("Error while connecting to the source location. Please chack the credentials")
 	}
 
-	err3 := storeCookie(httpResp.Header, gc.host)
-	if err3 != nil {
-		return fmt.Errorf("Error While Storing cookie: %s", err3)
-	}
-
 	return nil
 }
@@ -13,23 +13,29 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // GetUser returns user
 func (s *System) GetUser() ([]types.User, error) {
-	defer TimeSpent("GetUser", time.Now())
+	return s.GetUserCtx(s.client.callContext())
+}
+
+// GetUserCtx returns user
+func (s *System) GetUserCtx(ctx context.Context) ([]types.User, error) {
+	ctx, span := s.client.startSpan(ctx, "GetUser")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/System::%v/relationships/User",
 		s.System.ID)
 
 	var user []types.User
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodGet, path, nil, &user)
 	if err != nil {
 		return nil, err
@@ -40,6 +46,14 @@ func (s *System) GetUser() ([]types.User, error) {
 
 // GetUserByIDName returns a specific user based on it's user id
 func (s *System) GetUserByIDName(userID string, username string) (*types.User, error) {
+	return s.GetUserByIDNameCtx(s.client.callContext(), userID, username)
+}
+
+// GetUserByIDNameCtx returns a specific user based on it's user id
+func (s *System) GetUserByIDNameCtx(ctx context.Context, userID string, username string) (*types.User, error) {
+	ctx, span := s.client.startSpan(ctx, "GetUserByIDName")
+	defer span.end()
+
 	if userID == "" && username == "" {
 		return nil, errors.New("user name or ID is mandatory, please enter a valid value")
 	}
@@ -47,7 +61,7 @@ func (s *System) GetUserByIDName(userID string, username string) (*types.User, e
 	if userID != "" {
 		path := fmt.Sprintf("/api/instances/User::%v", userID)
 		user := &types.User{}
-		err := s.client.getJSONWithRetry(http.MethodGet, path, nil, &user)
+		err := s.client.getJSONWithRetry(ctx, http.MethodGet, path, nil, &user)
 		if err != nil {
 			return nil, err
 		}
@@ -56,7 +70,7 @@ func (s *System) GetUserByIDName(userID string, username string) (*types.User, e
 
 	}
 	// Get user by username
-	allUsers, err := s.GetUser()
+	allUsers, err := s.GetUserCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -72,8 +86,16 @@ func (s *System) GetUserByIDName(userID string, username string) (*types.User, e
 
 // CreateUser creates a new user with some role.
 func (s *System) CreateUser(userParam *types.UserParam) (*types.UserResp, error) {
+	return s.CreateUserCtx(s.client.callContext(), userParam)
+}
+
+// CreateUserCtx creates a new user with some role.
+func (s *System) CreateUserCtx(ctx context.Context, userParam *types.UserParam) (*types.UserResp, error) {
+	ctx, span := s.client.startSpan(ctx, "CreateUser")
+	defer span.end()
+
 	userResp := &types.UserResp{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, "/api/types/User/instances", userParam, &userResp)
 	if err != nil {
 		return nil, err
@@ -83,17 +105,33 @@ func (s *System) CreateUser(userParam *types.UserParam) (*types.UserResp, error)
 
 // RemoveUser removes a particular user.
 func (s *System) RemoveUser(userID string) error {
+	return s.RemoveUserCtx(s.client.callContext(), userID)
+}
+
+// RemoveUserCtx removes a particular user.
+func (s *System) RemoveUserCtx(ctx context.Context, userID string) error {
+	ctx, span := s.client.startSpan(ctx, "RemoveUser")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/User::%v/action/removeUser", userID)
 	empty := &types.EmptyPayload{}
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, empty, nil)
 	return err
 }
 
 // SetUserRole sets a new role for a particular user.
 func (s *System) SetUserRole(userRole *types.UserRoleParam, userID string) error {
+	return s.SetUserRoleCtx(s.client.callContext(), userRole, userID)
+}
+
+// SetUserRoleCtx sets a new role for a particular user.
+func (s *System) SetUserRoleCtx(ctx context.Context, userRole *types.UserRoleParam, userID string) error {
+	ctx, span := s.client.startSpan(ctx, "SetUserRole")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/User::%v/action/setUserRole", userID)
-	err := s.client.getJSONWithRetry(
+	err := s.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, userRole, nil)
 	return err
 }
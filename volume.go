@@ -13,6 +13,7 @@
 package goscaleio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -58,7 +59,16 @@ func (sp *StoragePool) GetVolume(
 	volumehref, volumeid, ancestorvolumeid, volumename string,
 	getSnapshots bool,
 ) ([]*types.Volume, error) {
-	defer TimeSpent("GetVolume", time.Now())
+	return sp.GetVolumeCtx(sp.client.callContext(), volumehref, volumeid, ancestorvolumeid, volumename, getSnapshots)
+}
+
+// GetVolumeCtx returns a volume
+func (sp *StoragePool) GetVolumeCtx(ctx context.Context,
+	volumehref, volumeid, ancestorvolumeid, volumename string,
+	getSnapshots bool,
+) ([]*types.Volume, error) {
+	ctx, span := sp.client.startSpan(ctx, "GetVolume")
+	defer span.end()
 
 	var (
 		err     error
@@ -68,8 +78,8 @@ func (sp *StoragePool) GetVolume(
 	)
 
 	if volumename != "" {
-		volumeid, err = sp.FindVolumeID(volumename)
-		if err != nil && err.Error() == "Not found" {
+		volumeid, err = sp.FindVolumeIDCtx(ctx, volumename)
+		if errors.Is(err, ErrNotFound) {
 			return nil, nil
 		}
 		if err != nil {
@@ -91,10 +101,10 @@ func (sp *StoragePool) GetVolume(
 	}
 
 	if volumehref == "" && volumeid == "" {
-		err = sp.client.getJSONWithRetry(
+		err = sp.client.getJSONWithRetry(ctx,
 			http.MethodGet, path, nil, &volumes)
 	} else {
-		err = sp.client.getJSONWithRetry(
+		err = sp.client.getJSONWithRetry(ctx,
 			http.MethodGet, path, nil, volume)
 	}
 	if err != nil {
@@ -117,7 +127,13 @@ func (sp *StoragePool) GetVolume(
 
 // FindVolumeID retruns a volume ID based on name
 func (sp *StoragePool) FindVolumeID(volumename string) (string, error) {
-	defer TimeSpent("FindVolumeID", time.Now())
+	return sp.FindVolumeIDCtx(sp.client.callContext(), volumename)
+}
+
+// FindVolumeIDCtx retruns a volume ID based on name
+func (sp *StoragePool) FindVolumeIDCtx(ctx context.Context, volumename string) (string, error) {
+	ctx, span := sp.client.startSpan(ctx, "FindVolumeID")
+	defer span.end()
 
 	volumeQeryIDByKeyParam := &types.VolumeQeryIDByKeyParam{
 		Name: volumename,
@@ -125,7 +141,7 @@ func (sp *StoragePool) FindVolumeID(volumename string) (string, error) {
 
 	path := "/api/types/Volume/instances/action/queryIdByKey"
 
-	volumeID, err := sp.client.getStringWithRetry(
+	volumeID, err := sp.client.getStringWithRetry(ctx,
 		http.MethodPost, path, volumeQeryIDByKeyParam)
 	if err != nil {
 		return "", err
@@ -192,14 +208,22 @@ func getVolumeMapping(sysID string, volID string) (mappedVolumes []*SdcMappedVol
 func (sp *StoragePool) CreateVolume(
 	volume *types.VolumeParam,
 ) (*types.VolumeResp, error) {
-	defer TimeSpent("CreateVolume", time.Now())
+	return sp.CreateVolumeCtx(sp.client.callContext(), volume)
+}
+
+// CreateVolumeCtx creates a volume
+func (sp *StoragePool) CreateVolumeCtx(ctx context.Context,
+	volume *types.VolumeParam,
+) (*types.VolumeResp, error) {
+	ctx, span := sp.client.startSpan(ctx, "CreateVolume")
+	defer span.end()
 
 	path := "/api/types/Volume/instances"
 
 	volume.StoragePoolID = sp.StoragePool.ID
 	volume.ProtectionDomainID = sp.StoragePool.ProtectionDomainID
 	volumeResp := &types.VolumeResp{}
-	err := sp.client.getJSONWithRetry(
+	err := sp.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, volume, volumeResp)
 	if err != nil {
 		return nil, err
@@ -210,7 +234,13 @@ func (sp *StoragePool) CreateVolume(
 
 // GetVTree returns a volume's vtree
 func (v *Volume) GetVTree() (*types.VTree, error) {
-	defer TimeSpent("GetVTree", time.Now())
+	return v.GetVTreeCtx(v.client.callContext())
+}
+
+// GetVTreeCtx returns a volume's vtree
+func (v *Volume) GetVTreeCtx(ctx context.Context) (*types.VTree, error) {
+	ctx, span := v.client.startSpan(ctx, "GetVTree")
+	defer span.end()
 
 	link, err := GetLink(v.Volume.Links, "/api/parent/relationship/vtreeId")
 	if err != nil {
@@ -218,7 +248,7 @@ func (v *Volume) GetVTree() (*types.VTree, error) {
 	}
 
 	vtree := &types.VTree{}
-	err = v.client.getJSONWithRetry(
+	err = v.client.getJSONWithRetry(ctx,
 		http.MethodGet, link.HREF, nil, vtree)
 	if err != nil {
 		return nil, err
@@ -229,7 +259,13 @@ func (v *Volume) GetVTree() (*types.VTree, error) {
 
 // GetVolumeStatistics returns a volume's statistics
 func (v *Volume) GetVolumeStatistics() (*types.VolumeStatistics, error) {
-	defer TimeSpent("GetStatistics", time.Now())
+	return v.GetVolumeStatisticsCtx(v.client.callContext())
+}
+
+// GetVolumeStatisticsCtx returns a volume's statistics
+func (v *Volume) GetVolumeStatisticsCtx(ctx context.Context) (*types.VolumeStatistics, error) {
+	ctx, span := v.client.startSpan(ctx, "GetStatistics")
+	defer span.end()
 
 	link, err := GetLink(v.Volume.Links, "/api/Volume/relationship/Statistics")
 	if err != nil {
@@ -237,7 +273,7 @@ func (v *Volume) GetVolumeStatistics() (*types.VolumeStatistics, error) {
 	}
 
 	var stats types.VolumeStatistics
-	err = v.client.getJSONWithRetry(
+	err = v.client.getJSONWithRetry(ctx,
 		http.MethodGet, link.HREF, nil, &stats)
 	if err != nil {
 		return nil, err
@@ -246,9 +282,45 @@ func (v *Volume) GetVolumeStatistics() (*types.VolumeStatistics, error) {
 	return &stats, nil
 }
 
+// GetVolumesByIDs returns the volumes with the given IDs, fetched in batches
+func (s *System) GetVolumesByIDs(ids []string) ([]types.Volume, error) {
+	return s.GetVolumesByIDsCtx(s.client.callContext(), ids)
+}
+
+// GetVolumesByIDsCtx returns the volumes with the given IDs, fetched in batches
+func (s *System) GetVolumesByIDsCtx(ctx context.Context, ids []string) ([]types.Volume, error) {
+	ctx, span := s.client.startSpan(ctx, "GetVolumesByIDs")
+	defer span.end()
+
+	return queryBySelectedIDs[types.Volume](ctx, s.client, "Volume", ids)
+}
+
+// GetVolumeStatisticsByIDs returns the statistics of the volumes with the
+// given IDs, by volume ID, fetched in batches. Volumes the array returns no
+// statistics for are left out.
+func (s *System) GetVolumeStatisticsByIDs(ids []string) (map[string]*types.VolumeStatistics, error) {
+	return s.GetVolumeStatisticsByIDsCtx(s.client.callContext(), ids)
+}
+
+// GetVolumeStatisticsByIDsCtx returns the statistics of the volumes with the
+// given IDs, by volume ID, fetched in batches. Volumes the array returns no
+// statistics for are left out.
+func (s *System) GetVolumeStatisticsByIDsCtx(ctx context.Context, ids []string) (map[string]*types.VolumeStatistics, error) {
+	ctx, span := s.client.startSpan(ctx, "GetVolumeStatisticsByIDs")
+	defer span.end()
+
+	return statisticsByIDs[types.VolumeStatistics](ctx, s.client, "Volume", volumeStatisticsProperties, ids)
+}
+
 // RemoveVolume removes a volume
 func (v *Volume) RemoveVolume(removeMode string) error {
-	defer TimeSpent("RemoveVolume", time.Now())
+	return v.RemoveVolumeCtx(v.client.callContext(), removeMode)
+}
+
+// RemoveVolumeCtx removes a volume
+func (v *Volume) RemoveVolumeCtx(ctx context.Context, removeMode string) error {
+	ctx, span := v.client.startSpan(ctx, "RemoveVolume")
+	defer span.end()
 
 	link, err := GetLink(v.Volume.Links, "self")
 	if err != nil {
@@ -264,25 +336,41 @@ func (v *Volume) RemoveVolume(removeMode string) error {
 		RemoveMode: removeMode,
 	}
 
-	err = v.client.getJSONWithRetry(
+	err = v.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, removeVolumeParam, nil)
 	return err
 }
 
 // SetVolumeName sets a volume's name
 func (v *Volume) SetVolumeName(newName string) error {
+	return v.SetVolumeNameCtx(v.client.callContext(), newName)
+}
+
+// SetVolumeNameCtx sets a volume's name
+func (v *Volume) SetVolumeNameCtx(ctx context.Context, newName string) error {
+	ctx, span := v.client.startSpan(ctx, "SetVolumeName")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/Volume::%s/action/setVolumeName", v.Volume.ID)
 
 	payload := &types.SetVolumeNameParam{
 		NewName: newName,
 	}
-	err := v.client.getJSONWithRetry(
+	err := v.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, payload, nil)
 	return err
 }
 
 // SetVolumeSize sets a volume's size
 func (v *Volume) SetVolumeSize(sizeInGB string) error {
+	return v.SetVolumeSizeCtx(v.client.callContext(), sizeInGB)
+}
+
+// SetVolumeSizeCtx sets a volume's size
+func (v *Volume) SetVolumeSizeCtx(ctx context.Context, sizeInGB string) error {
+	ctx, span := v.client.startSpan(ctx, "SetVolumeSize")
+	defer span.end()
+
 	link, err := GetLink(v.Volume.Links, "self")
 	if err != nil {
 		return err
@@ -291,7 +379,7 @@ func (v *Volume) SetVolumeSize(sizeInGB string) error {
 	payload := &types.SetVolumeSizeParam{
 		SizeInGB: sizeInGB,
 	}
-	err = v.client.getJSONWithRetry(
+	err = v.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, payload, nil)
 	return err
 }
@@ -303,6 +391,14 @@ type AutoSnapshotParam struct {
 
 // LockAutoSnapshot locks volume's auto snapshot in snapshotpolicy
 func (v *Volume) LockAutoSnapshot() error {
+	return v.LockAutoSnapshotCtx(v.client.callContext())
+}
+
+// LockAutoSnapshotCtx locks volume's auto snapshot in snapshotpolicy
+func (v *Volume) LockAutoSnapshotCtx(ctx context.Context) error {
+	ctx, span := v.client.startSpan(ctx, "LockAutoSnapshot")
+	defer span.end()
+
 	if v.Volume.VolumeType != "Snapshot" {
 		return errors.New("Volume type should be snapshot")
 	}
@@ -315,13 +411,21 @@ func (v *Volume) LockAutoSnapshot() error {
 	payload := AutoSnapshotParam{
 		AutoSnapshotWillBeRemoved: false,
 	}
-	err = v.client.getJSONWithRetry(
+	err = v.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, payload, nil)
 	return err
 }
 
 // UnlockAutoSnapshot unlocks volume's auto snapshot in snapshotpolicy
 func (v *Volume) UnlockAutoSnapshot() error {
+	return v.UnlockAutoSnapshotCtx(v.client.callContext())
+}
+
+// UnlockAutoSnapshotCtx unlocks volume's auto snapshot in snapshotpolicy
+func (v *Volume) UnlockAutoSnapshotCtx(ctx context.Context) error {
+	ctx, span := v.client.startSpan(ctx, "UnlockAutoSnapshot")
+	defer span.end()
+
 	if v.Volume.VolumeType != "Snapshot" {
 		return errors.New("Volume type should be snapshot")
 	}
@@ -335,7 +439,7 @@ func (v *Volume) UnlockAutoSnapshot() error {
 	payload := AutoSnapshotParam{
 		AutoSnapshotWillBeRemoved: false,
 	}
-	err = v.client.getJSONWithRetry(http.MethodPost, path, payload, nil)
+	err = v.client.getJSONWithRetry(ctx, http.MethodPost, path, payload, nil)
 	return err
 }
 
@@ -346,6 +450,14 @@ type SetVolumeAccessModeLimitParam struct {
 
 // SetVolumeAccessModeLimit sets access mode for volume/snapshot
 func (v *Volume) SetVolumeAccessModeLimit(mode string) error {
+	return v.SetVolumeAccessModeLimitCtx(v.client.callContext(), mode)
+}
+
+// SetVolumeAccessModeLimitCtx sets access mode for volume/snapshot
+func (v *Volume) SetVolumeAccessModeLimitCtx(ctx context.Context, mode string) error {
+	ctx, span := v.client.startSpan(ctx, "SetVolumeAccessModeLimit")
+	defer span.end()
+
 	link, err := GetLink(v.Volume.Links, "self")
 	if err != nil {
 		return err
@@ -355,7 +467,7 @@ func (v *Volume) SetVolumeAccessModeLimit(mode string) error {
 	payload := SetVolumeAccessModeLimitParam{
 		AccessModeLimit: mode,
 	}
-	err = v.client.getJSONWithRetry(http.MethodPost, path, payload, nil)
+	err = v.client.getJSONWithRetry(ctx, http.MethodPost, path, payload, nil)
 	return err
 }
 
@@ -366,6 +478,14 @@ type SetSnapshotSecurityParam struct {
 
 // SetSnapshotSecurity set retention period in min on snapshot
 func (v *Volume) SetSnapshotSecurity(retentionPeriodInMin string) error {
+	return v.SetSnapshotSecurityCtx(v.client.callContext(), retentionPeriodInMin)
+}
+
+// SetSnapshotSecurityCtx set retention period in min on snapshot
+func (v *Volume) SetSnapshotSecurityCtx(ctx context.Context, retentionPeriodInMin string) error {
+	ctx, span := v.client.startSpan(ctx, "SetSnapshotSecurity")
+	defer span.end()
+
 	link, err := GetLink(v.Volume.Links, "self")
 	if err != nil {
 		return err
@@ -375,7 +495,7 @@ func (v *Volume) SetSnapshotSecurity(retentionPeriodInMin string) error {
 	payload := SetSnapshotSecurityParam{
 		RetentionPeriodInMin: retentionPeriodInMin,
 	}
-	err = v.client.getJSONWithRetry(http.MethodPost, path, payload, nil)
+	err = v.client.getJSONWithRetry(ctx, http.MethodPost, path, payload, nil)
 	return err
 }
 
@@ -387,6 +507,14 @@ type SetVolumeMappingAccessModeParam struct {
 
 // SetVolumeMappingAccessMode set access mode of mapped sdc on snapshot
 func (v *Volume) SetVolumeMappingAccessMode(accessmode string, sdcid string) error {
+	return v.SetVolumeMappingAccessModeCtx(v.client.callContext(), accessmode, sdcid)
+}
+
+// SetVolumeMappingAccessModeCtx set access mode of mapped sdc on snapshot
+func (v *Volume) SetVolumeMappingAccessModeCtx(ctx context.Context, accessmode string, sdcid string) error {
+	ctx, span := v.client.startSpan(ctx, "SetVolumeMappingAccessMode")
+	defer span.end()
+
 	link, err := GetLink(v.Volume.Links, "self")
 	if err != nil {
 		return err
@@ -396,7 +524,7 @@ func (v *Volume) SetVolumeMappingAccessMode(accessmode string, sdcid string) err
 		AccessMode: accessmode,
 		SdcID:      sdcid,
 	}
-	err = v.client.getJSONWithRetry(http.MethodPost, path, payload, nil)
+	err = v.client.getJSONWithRetry(ctx, http.MethodPost, path, payload, nil)
 	return err
 }
 
@@ -407,6 +535,14 @@ type SetVolumeUseRmCacheParam struct {
 
 // SetVolumeUseRmCache set volume rm cahce use
 func (v *Volume) SetVolumeUseRmCache(useRmCache bool) error {
+	return v.SetVolumeUseRmCacheCtx(v.client.callContext(), useRmCache)
+}
+
+// SetVolumeUseRmCacheCtx set volume rm cahce use
+func (v *Volume) SetVolumeUseRmCacheCtx(ctx context.Context, useRmCache bool) error {
+	ctx, span := v.client.startSpan(ctx, "SetVolumeUseRmCache")
+	defer span.end()
+
 	link, err := GetLink(v.Volume.Links, "self")
 	if err != nil {
 		return err
@@ -415,7 +551,7 @@ func (v *Volume) SetVolumeUseRmCache(useRmCache bool) error {
 	payload := SetVolumeUseRmCacheParam{
 		UseRmCache: types.GetBoolType(useRmCache),
 	}
-	err = v.client.getJSONWithRetry(http.MethodPost, path, payload, nil)
+	err = v.client.getJSONWithRetry(ctx, http.MethodPost, path, payload, nil)
 	return err
 }
 
@@ -426,6 +562,14 @@ type SetCompressionMethodParam struct {
 
 // SetCompressionMethod set the volume compression method.
 func (v *Volume) SetCompressionMethod(compressionMethod string) error {
+	return v.SetCompressionMethodCtx(v.client.callContext(), compressionMethod)
+}
+
+// SetCompressionMethodCtx set the volume compression method.
+func (v *Volume) SetCompressionMethodCtx(ctx context.Context, compressionMethod string) error {
+	ctx, span := v.client.startSpan(ctx, "SetCompressionMethod")
+	defer span.end()
+
 	link, err := GetLink(v.Volume.Links, "self")
 	if err != nil {
 		return err
@@ -434,17 +578,25 @@ func (v *Volume) SetCompressionMethod(compressionMethod string) error {
 	payload := SetCompressionMethodParam{
 		CompressionMethod: compressionMethod,
 	}
-	err = v.client.getJSONWithRetry(http.MethodPost, path, payload, nil)
+	err = v.client.getJSONWithRetry(ctx, http.MethodPost, path, payload, nil)
 	return err
 }
 
 // UnmarkForReplication Depricated Message (3.6)
 func (v *Volume) UnmarkForReplication() error {
+	return v.UnmarkForReplicationCtx(v.client.callContext())
+}
+
+// UnmarkForReplicationCtx Depricated Message (3.6)
+func (v *Volume) UnmarkForReplicationCtx(ctx context.Context) error {
+	ctx, span := v.client.startSpan(ctx, "UnmarkForReplication")
+	defer span.end()
+
 	path := fmt.Sprintf("/api/instances/Volume::%s/action/unmarkForReplication", v.Volume.ID)
 
 	payload := &types.EmptyPayload{}
 
-	err := v.client.getJSONWithRetry(
+	err := v.client.getJSONWithRetry(ctx,
 		http.MethodPost, path, payload, nil)
 
 	return err
@@ -13,21 +13,27 @@
 package goscaleio
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"time"
 
 	types "github.com/dell/goscaleio/types/v1"
 )
 
 // GetVTrees returns vtrees present in the cluster
 func (c *Client) GetVTrees() ([]types.VTreeDetails, error) {
-	defer TimeSpent("GetVTrees", time.Now())
+	return c.GetVTreesCtx(c.callContext())
+}
+
+// GetVTreesCtx returns vtrees present in the cluster
+func (c *Client) GetVTreesCtx(ctx context.Context) ([]types.VTreeDetails, error) {
+	ctx, span := c.startSpan(ctx, "GetVTrees")
+	defer span.end()
 
 	path := "/api/types/VTree/instances"
 
 	var vTree []types.VTreeDetails
-	err := c.getJSONWithRetry(http.MethodGet, path, nil, &vTree)
+	err := c.getJSONWithRetry(ctx, http.MethodGet, path, nil, &vTree)
 	if err != nil {
 		return nil, err
 	}
@@ -37,12 +43,18 @@ func (c *Client) GetVTrees() ([]types.VTreeDetails, error) {
 
 // GetVTreeByID returns the VTree details for the given ID
 func (c *Client) GetVTreeByID(id string) (*types.VTreeDetails, error) {
-	defer TimeSpent("GetVTreeByID", time.Now())
+	return c.GetVTreeByIDCtx(c.callContext(), id)
+}
+
+// GetVTreeByIDCtx returns the VTree details for the given ID
+func (c *Client) GetVTreeByIDCtx(ctx context.Context, id string) (*types.VTreeDetails, error) {
+	ctx, span := c.startSpan(ctx, "GetVTreeByID")
+	defer span.end()
 
 	path := fmt.Sprintf("/api/instances/VTree::%v", id)
 
 	var vTree types.VTreeDetails
-	err := c.getJSONWithRetry(http.MethodGet, path, nil, &vTree)
+	err := c.getJSONWithRetry(ctx, http.MethodGet, path, nil, &vTree)
 	if err != nil {
 		return nil, err
 	}
@@ -51,29 +63,31 @@ func (c *Client) GetVTreeByID(id string) (*types.VTreeDetails, error) {
 
 // GetVTreeInstances returns the VTree details for the given IDs
 func (c *Client) GetVTreeInstances(ids []string) ([]types.VTreeDetails, error) {
-	defer TimeSpent("GetVTrees", time.Now())
+	return c.GetVTreeInstancesCtx(c.callContext(), ids)
+}
 
-	path := "/api/types/VTree/instances/action/queryBySelectedIds"
+// GetVTreeInstancesCtx returns the VTree details for the given IDs
+func (c *Client) GetVTreeInstancesCtx(ctx context.Context, ids []string) ([]types.VTreeDetails, error) {
+	ctx, span := c.startSpan(ctx, "GetVTrees")
+	defer span.end()
 
-	payload := types.VTreeQueryBySelectedIDsParam{
-		IDs: ids,
-	}
-	var vTree []types.VTreeDetails
-	err := c.getJSONWithRetry(http.MethodPost, path, payload, &vTree)
-	if err != nil {
-		return nil, err
-	}
-	return vTree, nil
+	return queryBySelectedIDs[types.VTreeDetails](ctx, c, "VTree", ids)
 }
 
 // GetVTreeByVolumeID returns VTree details based on Volume ID
 func (c *Client) GetVTreeByVolumeID(id string) (*types.VTreeDetails, error) {
-	defer TimeSpent("GetVTreeByVolumeID", time.Now())
+	return c.GetVTreeByVolumeIDCtx(c.callContext(), id)
+}
+
+// GetVTreeByVolumeIDCtx returns VTree details based on Volume ID
+func (c *Client) GetVTreeByVolumeIDCtx(ctx context.Context, id string) (*types.VTreeDetails, error) {
+	ctx, span := c.startSpan(ctx, "GetVTreeByVolumeID")
+	defer span.end()
 
-	volDetails, err := c.GetVolume("", id, "", "", false)
+	volDetails, err := c.GetVolumeCtx(ctx, "", id, "", "", false)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.GetVTreeByID(volDetails[0].VTreeID)
+	return c.GetVTreeByIDCtx(ctx, volDetails[0].VTreeID)
 }